@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net/mail"
 	"os"
 	"strings"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
 )
 
 var aliasCmd = &cobra.Command{
@@ -30,14 +32,24 @@ var aliasAddCmd = &cobra.Command{
 
 Argumentos:
   nome     - Nome do alias (ex: me, team, alerts)
-  provider - Provider (tg, mail, zap, google_chat)
-  target   - Target (chat_id, email, número, webhook_url)`,
+  provider - Provider registrado (ex: tg, mail, zap, google_chat, waha) ou url
+  target   - Target (chat_id, email, número, webhook_url) ou, com provider url,
+             a URL do provider (ex: tgram://TOKEN/CHAT_ID)
+
+Para aliases de email, --cc e --bcc definem cópias incluídas em todo envio.
+
+Exemplos:
+  cast alias add me tg 123456789 --name "Meu Telegram"
+  cast alias add diretoria mail "diretor@empresa.com" --cc "secretaria@empresa.com" --bcc "arquivo@empresa.com"
+  cast alias add plantao url "tgram://123456:ABC-DEF/-1001234567890"`,
 	Args: cobra.ExactArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		aliasName := args[0]
 		provider := args[1]
 		target := args[2]
 		description, _ := cmd.Flags().GetString("name")
+		cc, _ := cmd.Flags().GetString("cc")
+		bcc, _ := cmd.Flags().GetString("bcc")
 
 		// Carrega configuração existente
 		cfg, err := config.LoadConfig()
@@ -59,11 +71,11 @@ Argumentos:
 		}
 
 		// Valida provider
-		normalizedProvider := normalizeProviderName(provider)
+		normalizedProvider := normalizeAliasProvider(provider)
 		if normalizedProvider == "" {
 			red := color.New(color.FgRed, color.Bold)
 			red.Fprintf(os.Stderr, "✗ Erro: Provider '%s' inválido\n", provider)
-			return fmt.Errorf("provider '%s' inválido (suportados: tg, mail, zap, google_chat)", provider)
+			return fmt.Errorf("provider '%s' inválido (suportados: %s)", provider, providerListHelp())
 		}
 
 		// Valida target
@@ -72,13 +84,22 @@ Argumentos:
 			red.Fprintf(os.Stderr, "✗ Erro: Target não pode estar vazio\n")
 			return fmt.Errorf("target não pode estar vazio")
 		}
+		if err := validateAliasURL(normalizedProvider, target); err != nil {
+			return err
+		}
 
 		// Adiciona alias
-		cfg.Aliases[aliasName] = config.AliasConfig{
+		alias := config.AliasConfig{
 			Provider: normalizedProvider,
 			Target:   target,
 			Name:     description,
+			Cc:       cc,
+			Bcc:      bcc,
 		}
+		if err := validateAliasCopies(alias); err != nil {
+			return err
+		}
+		cfg.Aliases[aliasName] = alias
 
 		// Salva configuração
 		if err := config.Save(cfg); err != nil {
@@ -124,7 +145,7 @@ var aliasListCmd = &cobra.Command{
 			if desc == "" {
 				desc = "-"
 			}
-			fmt.Printf("%-15s %-10s %-30s %s\n", name, alias.Provider, alias.Target, desc)
+			fmt.Printf("%-15s %-10s %-30s %s\n", name, alias.Provider, aliasTargetDisplay(alias), desc)
 		}
 		return nil
 	},
@@ -217,27 +238,26 @@ var aliasShowCmd = &cobra.Command{
 
 		// Formata provider name para exibição
 		providerDisplay := alias.Provider
-		switch alias.Provider {
-		case "tg":
-			providerDisplay = "tg (Telegram)"
-		case "mail":
-			providerDisplay = "mail (Email)"
-		case "zap":
-			providerDisplay = "zap (WhatsApp)"
-		case "google_chat":
-			providerDisplay = "google_chat (Google Chat)"
+		if reg, ok := providers.Lookup(alias.Provider); ok {
+			providerDisplay = fmt.Sprintf("%s (%s)", alias.Provider, reg.DisplayName)
 		}
 
 		// Exibe em formato "Ficha Técnica"
 		cyan := color.New(color.FgCyan)
 		cyan.Printf("Alias:      %s\n", aliasName)
 		cyan.Printf("Provider:   %s\n", providerDisplay)
-		cyan.Printf("Target:     %s\n", alias.Target)
+		cyan.Printf("Target:     %s\n", aliasTargetDisplay(*alias))
 		if alias.Name != "" {
 			cyan.Printf("Descrição:  %s\n", alias.Name)
 		} else {
 			cyan.Println("Descrição:  -")
 		}
+		if alias.Cc != "" {
+			cyan.Printf("Cc:         %s\n", alias.Cc)
+		}
+		if alias.Bcc != "" {
+			cyan.Printf("Bcc:        %s\n", alias.Bcc)
+		}
 
 		return nil
 	},
@@ -277,7 +297,7 @@ Mantém os outros campos intactos.`,
 		description, _ := cmd.Flags().GetString("name")
 
 		if cmd.Flags().Changed("provider") {
-			normalizedProvider := normalizeProviderName(provider)
+			normalizedProvider := normalizeAliasProvider(provider)
 			if normalizedProvider == "" {
 				red := color.New(color.FgRed, color.Bold)
 				red.Fprintf(os.Stderr, "✗ Erro: Provider '%s' inválido\n", provider)
@@ -298,10 +318,19 @@ Mantém os outros campos intactos.`,
 		if cmd.Flags().Changed("name") {
 			alias.Name = description
 		}
+		if cmd.Flags().Changed("cc") {
+			alias.Cc, _ = cmd.Flags().GetString("cc")
+		}
+		if cmd.Flags().Changed("bcc") {
+			alias.Bcc, _ = cmd.Flags().GetString("bcc")
+		}
+		if err := validateAliasCopies(*alias); err != nil {
+			return err
+		}
 
 		// Valida provider se foi alterado
 		if cmd.Flags().Changed("provider") {
-			normalizedProvider := normalizeProviderName(alias.Provider)
+			normalizedProvider := normalizeAliasProvider(alias.Provider)
 			if normalizedProvider == "" {
 				red := color.New(color.FgRed, color.Bold)
 				red.Fprintf(os.Stderr, "✗ Erro: Provider '%s' inválido\n", alias.Provider)
@@ -309,6 +338,10 @@ Mantém os outros campos intactos.`,
 			}
 		}
 
+		if err := validateAliasURL(alias.Provider, alias.Target); err != nil {
+			return err
+		}
+
 		// Atualiza no map
 		cfg.Aliases[aliasName] = *alias
 
@@ -326,12 +359,73 @@ Mantém os outros campos intactos.`,
 	},
 }
 
+// normalizeAliasProvider normaliza o provider de um alias, aceitando também "url"
+// (alias definido por URL de provider). Retorna "" se o provider for inválido.
+func normalizeAliasProvider(provider string) string {
+	if strings.EqualFold(provider, providers.URLProvider) {
+		return providers.URLProvider
+	}
+	return providers.NormalizeShortName(provider)
+}
+
+// validateAliasURL valida a URL de um alias com provider url.
+func validateAliasURL(provider string, target string) error {
+	if provider != providers.URLProvider {
+		return nil
+	}
+	if _, _, _, err := providers.ResolveURL(target); err != nil {
+		red := color.New(color.FgRed, color.Bold)
+		red.Fprintf(os.Stderr, "✗ Erro na URL do provider: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateAliasCopies valida as cópias padrão (--cc, --bcc), aceitas apenas em aliases de email.
+func validateAliasCopies(alias config.AliasConfig) error {
+	if alias.Cc == "" && alias.Bcc == "" {
+		return nil
+	}
+	red := color.New(color.FgRed, color.Bold)
+	provider := alias.Provider
+	if provider == providers.URLProvider {
+		if reg, _, _, err := providers.ResolveURL(alias.Target); err == nil {
+			provider = reg.Name
+		}
+	}
+	if reg, ok := providers.Lookup(provider); !ok || reg.Name != "email" {
+		red.Fprintf(os.Stderr, "✗ Erro: --cc e --bcc são suportados apenas em aliases de email\n")
+		return fmt.Errorf("--cc e --bcc são suportados apenas em aliases de email")
+	}
+	for flag, value := range map[string]string{"cc": alias.Cc, "bcc": alias.Bcc} {
+		for _, address := range config.ParseTargets(value) {
+			if _, err := mail.ParseAddress(address); err != nil {
+				red.Fprintf(os.Stderr, "✗ Erro: endereço inválido em --%s: %s\n", flag, address)
+				return fmt.Errorf("--%s: endereço inválido '%s'", flag, address)
+			}
+		}
+	}
+	return nil
+}
+
+// aliasTargetDisplay retorna o target para exibição, ocultando as credenciais de URLs.
+func aliasTargetDisplay(alias config.AliasConfig) string {
+	if alias.Provider == providers.URLProvider {
+		return providers.RedactURL(alias.Target)
+	}
+	return alias.Target
+}
+
 func init() {
 	aliasAddCmd.Flags().StringP("name", "n", "", "Nome descritivo do alias")
+	aliasAddCmd.Flags().String("cc", "", "Cópia padrão em todo envio (apenas email; separados por vírgula)")
+	aliasAddCmd.Flags().String("bcc", "", "Cópia oculta padrão em todo envio (apenas email; separados por vírgula)")
 	aliasRemoveCmd.Flags().BoolP("confirm", "y", false, "Confirma sem perguntar")
-	aliasUpdateCmd.Flags().StringP("provider", "p", "", "Provider (tg, mail, zap, google_chat)")
-	aliasUpdateCmd.Flags().StringP("target", "t", "", "Target (chat_id, email, número, webhook_url)")
+	aliasUpdateCmd.Flags().StringP("provider", "p", "", "Provider ("+providerListHelp()+" ou url)")
+	aliasUpdateCmd.Flags().StringP("target", "t", "", "Target (chat_id, email, número, webhook_url ou URL do provider)")
 	aliasUpdateCmd.Flags().StringP("name", "n", "", "Nome descritivo do alias")
+	aliasUpdateCmd.Flags().String("cc", "", "Cópia padrão em todo envio (apenas email; vazio remove)")
+	aliasUpdateCmd.Flags().String("bcc", "", "Cópia oculta padrão em todo envio (apenas email; vazio remove)")
 
 	aliasCmd.AddCommand(aliasAddCmd)
 	aliasCmd.AddCommand(aliasListCmd)
@@ -340,21 +434,3 @@ func init() {
 	aliasCmd.AddCommand(aliasUpdateCmd)
 	rootCmd.AddCommand(aliasCmd)
 }
-
-// normalizeProviderName normaliza o nome do provider.
-func normalizeProviderName(name string) string {
-	switch strings.ToLower(name) {
-	case "tg", "telegram":
-		return "tg"
-	case "mail", "email":
-		return "mail"
-	case "zap", "whatsapp":
-		return "zap"
-	case "google_chat", "googlechat":
-		return "google_chat"
-	case "waha":
-		return "waha"
-	default:
-		return ""
-	}
-}
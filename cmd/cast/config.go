@@ -12,6 +12,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
 	"github.com/spf13/viper"
 )
 
@@ -411,24 +412,40 @@ Este comando ajuda a identificar onde cada configuração está sendo definida.`
 }
 
 // maskSensitiveData mascara campos sensíveis na configuração.
+// Os campos sensíveis são definidos no schema de cada provider registrado
+// (tokens, senhas e webhooks, que também carregam credenciais na URL).
 func maskSensitiveData(cfg *config.Config) {
-	// Telegram
-	if cfg.Telegram.Token != "" {
-		cfg.Telegram.Token = "*****"
-	}
-
-	// WhatsApp
-	if cfg.WhatsApp.AccessToken != "" {
-		cfg.WhatsApp.AccessToken = "*****"
+	for _, reg := range providers.Registered() {
+		for _, f := range reg.Fields {
+			if !f.Secret {
+				continue
+			}
+			if value, err := cfg.GetField(reg.Name, f.Key); err == nil && value != "" {
+				cfg.SetField(reg.Name, f.Key, "*****")
+			}
+		}
 	}
 
-	// Email
-	if cfg.Email.Password != "" {
-		cfg.Email.Password = "*****"
+	// Endpoints de webhook: segredos literais em headers e hmac_secret
+	// (referências ${env:...} e ${file:...} não expõem o valor e são mantidas)
+	if len(cfg.Webhook.Endpoints) > 0 {
+		endpoints := make(map[string]config.WebhookEndpoint, len(cfg.Webhook.Endpoints))
+		for name, endpoint := range cfg.Webhook.Endpoints {
+			headers := make(map[string]string, len(endpoint.Headers))
+			for header, value := range endpoint.Headers {
+				if !providers.IsSecretRef(value) {
+					value = "*****"
+				}
+				headers[header] = value
+			}
+			endpoint.Headers = headers
+			if endpoint.HMACSecret != "" && !providers.IsSecretRef(endpoint.HMACSecret) {
+				endpoint.HMACSecret = "*****"
+			}
+			endpoints[name] = endpoint
+		}
+		cfg.Webhook.Endpoints = endpoints
 	}
-
-	// Google Chat - Webhook URL pode conter tokens, mas não vamos mascarar por padrão
-	// pois é necessário para debug
 }
 
 // showConfigSources mostra a origem de cada configuração.
@@ -477,56 +494,27 @@ func showConfigSources() {
 		return "DEFAULT"
 	}
 
-	// Telegram
-	cyan.Println("Telegram:")
-	showSource("  token", cfg.Telegram.Token, getSource("telegram.token"))
-	showSource("  default_chat_id", cfg.Telegram.DefaultChatID, getSource("telegram.default_chat_id"))
-	showSource("  api_url", cfg.Telegram.APIURL, getSource("telegram.api_url"))
-	showSource("  timeout", fmt.Sprintf("%d", cfg.Telegram.Timeout), getSource("telegram.timeout"))
-	fmt.Println()
-
-	// WhatsApp
-	cyan.Println("WhatsApp:")
-	showSource("  phone_number_id", cfg.WhatsApp.PhoneNumberID, getSource("whatsapp.phone_number_id"))
-	// Usa função maskToken de gateway.go (mesmo pacote)
-	maskedToken := cfg.WhatsApp.AccessToken
-	if len(maskedToken) > 8 {
-		maskedToken = maskedToken[:4] + "*****" + maskedToken[len(maskedToken)-4:]
-	} else if maskedToken != "" {
-		maskedToken = "*****"
-	}
-	showSource("  access_token", maskedToken, getSource("whatsapp.access_token"))
-	showSource("  business_account_id", cfg.WhatsApp.BusinessAccountID, getSource("whatsapp.business_account_id"))
-	showSource("  api_version", cfg.WhatsApp.APIVersion, getSource("whatsapp.api_version"))
-	showSource("  api_url", cfg.WhatsApp.APIURL, getSource("whatsapp.api_url"))
-	showSource("  timeout", fmt.Sprintf("%d", cfg.WhatsApp.Timeout), getSource("whatsapp.timeout"))
-	fmt.Println()
-
-	// Email
-	cyan.Println("Email:")
-	showSource("  smtp_host", cfg.Email.SMTPHost, getSource("email.smtp_host"))
-	showSource("  smtp_port", fmt.Sprintf("%d", cfg.Email.SMTPPort), getSource("email.smtp_port"))
-	showSource("  username", cfg.Email.Username, getSource("email.username"))
-	// Usa função maskToken de gateway.go (mesmo pacote)
-	maskedPassword := cfg.Email.Password
-	if len(maskedPassword) > 8 {
-		maskedPassword = maskedPassword[:4] + "*****" + maskedPassword[len(maskedPassword)-4:]
-	} else if maskedPassword != "" {
-		maskedPassword = "*****"
+	// Providers (gerado a partir do schema de cada provider registrado)
+	for _, reg := range providers.Registered() {
+		cyan.Printf("%s:\n", reg.DisplayName)
+		for _, f := range reg.Fields {
+			key := reg.Name + "." + f.Key
+			value, err := cfg.GetField(reg.Name, f.Key)
+			if err != nil {
+				continue
+			}
+			if f.Secret {
+				// Usa função maskToken de gateway.go (mesmo pacote)
+				if len(value) > 8 {
+					value = maskToken(value)
+				} else if value != "" {
+					value = "*****"
+				}
+			}
+			showSource("  "+f.Key, value, getSource(key))
+		}
+		fmt.Println()
 	}
-	showSource("  password", maskedPassword, getSource("email.password"))
-	showSource("  from_email", cfg.Email.FromEmail, getSource("email.from_email"))
-	showSource("  from_name", cfg.Email.FromName, getSource("email.from_name"))
-	showSource("  use_tls", fmt.Sprintf("%v", cfg.Email.UseTLS), getSource("email.use_tls"))
-	showSource("  use_ssl", fmt.Sprintf("%v", cfg.Email.UseSSL), getSource("email.use_ssl"))
-	showSource("  timeout", fmt.Sprintf("%d", cfg.Email.Timeout), getSource("email.timeout"))
-	fmt.Println()
-
-	// Google Chat
-	cyan.Println("Google Chat:")
-	showSource("  webhook_url", cfg.GoogleChat.WebhookURL, getSource("google_chat.webhook_url"))
-	showSource("  timeout", fmt.Sprintf("%d", cfg.GoogleChat.Timeout), getSource("google_chat.timeout"))
-	fmt.Println()
 
 	// Aliases
 	if cfg.Aliases != nil && len(cfg.Aliases) > 0 {
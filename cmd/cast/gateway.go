@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/smtp"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
 )
 
 var gatewayCmd = &cobra.Command{
@@ -83,21 +85,11 @@ var gatewayShowCmd = &cobra.Command{
 
 		// Mostra provider específico
 		providerName := args[0]
-		normalized := normalizeGatewayName(providerName)
-		switch normalized {
-		case "telegram":
-			showTelegramConfig(cfg.Telegram, mask)
-		case "email":
-			showEmailConfig(cfg.Email, mask)
-		case "whatsapp":
-			showWhatsAppConfig(cfg.WhatsApp, mask)
-		case "google_chat":
-			showGoogleChatConfig(cfg.GoogleChat, mask)
-		case "waha":
-			showWAHAConfig(cfg.WAHA, mask)
-		default:
+		reg, ok := providers.Lookup(providerName)
+		if !ok {
 			return fmt.Errorf("provider desconhecido: %s", providerName)
 		}
+		showGatewayConfig(reg, cfg, mask)
 
 		return nil
 	},
@@ -119,8 +111,8 @@ var gatewayRemoveCmd = &cobra.Command{
 			return err
 		}
 
-		normalized := normalizeGatewayName(providerName)
-		if normalized == "" {
+		reg, ok := providers.Lookup(providerName)
+		if !ok {
 			return fmt.Errorf("provider desconhecido: %s", providerName)
 		}
 
@@ -138,17 +130,16 @@ var gatewayRemoveCmd = &cobra.Command{
 		}
 
 		// Remove configuração
-		switch normalized {
-		case "telegram":
-			cfg.Telegram = config.TelegramConfig{}
-		case "email":
-			cfg.Email = config.EmailConfig{}
-		case "whatsapp":
-			cfg.WhatsApp = config.WhatsAppConfig{}
-		case "google_chat":
-			cfg.GoogleChat = config.GoogleChatConfig{}
-		case "waha":
-			cfg.WAHA = config.WAHAConfig{}
+		remove := func(_ *cobra.Command, cfg *config.Config) error {
+			return cfg.ResetSection(reg.Name)
+		}
+		if handler, ok := gatewayHandlers[reg.Name]; ok && handler.Remove != nil {
+			remove = handler.Remove
+		}
+		if err := remove(cmd, cfg); err != nil {
+			red := color.New(color.FgRed, color.Bold)
+			red.Fprintf(os.Stderr, "✗ Erro: %v\n", err)
+			return err
 		}
 
 		// Salva
@@ -178,8 +169,8 @@ Falha se o gateway não estiver configurado.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		providerName := args[0]
-		normalized := normalizeGatewayName(providerName)
-		if normalized == "" {
+		reg, ok := providers.Lookup(providerName)
+		if !ok {
 			return fmt.Errorf("provider desconhecido: %s", providerName)
 		}
 
@@ -192,21 +183,7 @@ Falha se o gateway não estiver configurado.`,
 		}
 
 		// Verifica se gateway existe
-		var exists bool
-		switch normalized {
-		case "telegram":
-			exists = cfg.Telegram.Token != ""
-		case "email":
-			exists = cfg.Email.SMTPHost != ""
-		case "whatsapp":
-			exists = cfg.WhatsApp.PhoneNumberID != "" && cfg.WhatsApp.AccessToken != ""
-		case "google_chat":
-			exists = cfg.GoogleChat.WebhookURL != ""
-		case "waha":
-			exists = cfg.WAHA.APIURL != ""
-		}
-
-		if !exists {
+		if reg.Configured == nil || !reg.Configured(cfg) {
 			red := color.New(color.FgRed, color.Bold)
 			red.Fprintf(os.Stderr, "✗ Gateway '%s' não está configurado\n", providerName)
 			red.Println("Use 'cast gateway add' para configurar primeiro")
@@ -214,29 +191,16 @@ Falha se o gateway não estiver configurado.`,
 		}
 
 		// Atualiza apenas campos fornecidos
-		switch normalized {
-		case "telegram":
-			if err := updateTelegramViaFlags(cmd, cfg); err != nil {
-				return err
+		update := updateGatewayViaSchema
+		if handler, ok := gatewayHandlers[reg.Name]; ok && handler.Update != nil {
+			update = func(cmd *cobra.Command, _ *providers.Registration, cfg *config.Config) error {
+				return handler.Update(cmd, cfg)
 			}
-		case "email":
-			if err := updateEmailViaFlags(cmd, cfg); err != nil {
-				return err
-			}
-		case "whatsapp":
-			if err := updateWhatsAppViaFlags(cmd, cfg); err != nil {
-				return err
-			}
-		case "google_chat":
-			if err := updateGoogleChatViaFlags(cmd, cfg); err != nil {
-				return err
-			}
-		case "waha":
-			if err := updateWAHAViaFlags(cmd, cfg); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("update não implementado para: %s", normalized)
+		}
+		if err := update(cmd, reg, cfg); err != nil {
+			red := color.New(color.FgRed, color.Bold)
+			red.Fprintf(os.Stderr, "✗ Erro: %v\n", err)
+			return err
 		}
 
 		// Valida configuração completa antes de salvar
@@ -268,16 +232,23 @@ var gatewayTestCmd = &cobra.Command{
 
 Telegram: Chama getMe na API
 Email: Conecta ao SMTP, faz autenticação e fecha conexão
+       (com --dkim, assina uma mensagem de teste e verifica a assinatura DKIM localmente)
 WhatsApp: Chama endpoint de metadados
 Google Chat: Valida URL do webhook`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		providerName := args[0]
 		target, _ := cmd.Flags().GetString("target")
-		normalized := normalizeGatewayName(providerName)
-		if normalized == "" {
+		checkDKIM, _ := cmd.Flags().GetBool("dkim")
+		reg, ok := providers.Lookup(providerName)
+		if !ok {
 			return fmt.Errorf("provider desconhecido: %s", providerName)
 		}
+		if checkDKIM && reg.Name != "email" {
+			red := color.New(color.FgRed, color.Bold)
+			red.Fprintln(os.Stderr, "✗ --dkim é suportado apenas pelo provider email")
+			return fmt.Errorf("--dkim é suportado apenas pelo provider email")
+		}
 
 		// Carrega configuração
 		cfg, err := config.LoadConfig()
@@ -287,75 +258,26 @@ Google Chat: Valida URL do webhook`,
 			return err
 		}
 
+		if checkDKIM {
+			return testEmailDKIM(cfg.Email)
+		}
+
 		// Testa gateway
-		switch normalized {
-		case "telegram":
-			return testTelegram(cfg.Telegram)
-		case "email":
-			return testEmail(cfg.Email, target)
-		case "whatsapp":
-			return testWhatsApp(cfg.WhatsApp)
-		case "google_chat":
-			return testGoogleChat(cfg.GoogleChat, target)
-		case "waha":
-			return testWAHA(cfg.WAHA)
-		default:
-			return fmt.Errorf("teste não implementado para: %s", normalized)
+		if handler, ok := gatewayHandlers[reg.Name]; ok && handler.Test != nil {
+			return handler.Test(cfg, target)
 		}
+		return testGatewayViaRegistry(reg, cfg, target)
 	},
 }
 
 func init() {
-	// Flags para gateway add
-	gatewayAddCmd.Flags().String("token", "", "Token do Telegram")
-	gatewayAddCmd.Flags().String("default-chat-id", "", "Chat ID padrão do Telegram")
-	gatewayAddCmd.Flags().String("smtp-host", "", "Servidor SMTP")
-	gatewayAddCmd.Flags().Int("smtp-port", 0, "Porta SMTP")
-	gatewayAddCmd.Flags().String("username", "", "Usuário SMTP")
-	gatewayAddCmd.Flags().String("password", "", "Senha SMTP")
-	gatewayAddCmd.Flags().String("from-email", "", "Email remetente")
-	gatewayAddCmd.Flags().String("from-name", "", "Nome remetente")
-	gatewayAddCmd.Flags().Bool("use-tls", false, "Usar TLS")
-	gatewayAddCmd.Flags().Bool("use-ssl", false, "Usar SSL")
-	gatewayAddCmd.Flags().Int("timeout", 0, "Timeout em segundos")
-	// Flags WhatsApp
-	gatewayAddCmd.Flags().String("phone-id", "", "Phone Number ID do WhatsApp")
-	gatewayAddCmd.Flags().String("access-token", "", "Access Token do WhatsApp")
-	gatewayAddCmd.Flags().String("business-account-id", "", "Business Account ID do WhatsApp (opcional)")
-	gatewayAddCmd.Flags().String("api-version", "", "API Version do WhatsApp (padrão: v18.0)")
-	// Flags Google Chat
-	gatewayAddCmd.Flags().String("webhook-url", "", "Webhook URL do Google Chat")
-	// Flags WAHA
-	gatewayAddCmd.Flags().String("api-url", "", "URL da API WAHA")
-	gatewayAddCmd.Flags().String("session", "default", "Nome da sessão WAHA")
-	gatewayAddCmd.Flags().String("api-key", "", "API Key WAHA (opcional)")
+	// Flags de configuração geradas a partir do schema dos providers registrados
+	registerGatewayFlags(gatewayAddCmd)
+	registerGatewayFlags(gatewayUpdateCmd)
 	gatewayAddCmd.Flags().BoolP("interactive", "i", false, "Modo wizard interativo")
 
-	// Flags para gateway update (mesmas do add)
-	gatewayUpdateCmd.Flags().String("token", "", "Token do Telegram")
-	gatewayUpdateCmd.Flags().String("default-chat-id", "", "Chat ID padrão do Telegram")
-	gatewayUpdateCmd.Flags().String("smtp-host", "", "Servidor SMTP")
-	gatewayUpdateCmd.Flags().Int("smtp-port", 0, "Porta SMTP")
-	gatewayUpdateCmd.Flags().String("username", "", "Usuário SMTP")
-	gatewayUpdateCmd.Flags().String("password", "", "Senha SMTP")
-	gatewayUpdateCmd.Flags().String("from-email", "", "Email remetente")
-	gatewayUpdateCmd.Flags().String("from-name", "", "Nome remetente")
-	gatewayUpdateCmd.Flags().Bool("use-tls", false, "Usar TLS")
-	gatewayUpdateCmd.Flags().Bool("use-ssl", false, "Usar SSL")
-	gatewayUpdateCmd.Flags().Int("timeout", 0, "Timeout em segundos")
-	// Flags WhatsApp
-	gatewayUpdateCmd.Flags().String("phone-id", "", "Phone Number ID do WhatsApp")
-	gatewayUpdateCmd.Flags().String("access-token", "", "Access Token do WhatsApp")
-	gatewayUpdateCmd.Flags().String("business-account-id", "", "Business Account ID do WhatsApp")
-	gatewayUpdateCmd.Flags().String("api-version", "", "API Version do WhatsApp")
-	// Flags Google Chat
-	gatewayUpdateCmd.Flags().String("webhook-url", "", "Webhook URL do Google Chat")
-	// Flags WAHA
-	gatewayUpdateCmd.Flags().String("api-url", "", "URL da API WAHA")
-	gatewayUpdateCmd.Flags().String("session", "", "Nome da sessão WAHA")
-	gatewayUpdateCmd.Flags().String("api-key", "", "API Key WAHA (opcional)")
-
 	gatewayTestCmd.Flags().StringP("target", "t", "", "Target para teste (opcional, para Email e Google Chat)")
+	gatewayTestCmd.Flags().Bool("dkim", false, "Verifica localmente a assinatura DKIM e a chave publicada no DNS (Email)")
 
 	gatewayShowCmd.Flags().BoolP("mask", "m", true, "Mascara campos sensíveis")
 	gatewayRemoveCmd.Flags().BoolP("confirm", "y", false, "Confirma sem perguntar")
@@ -368,24 +290,6 @@ func init() {
 	rootCmd.AddCommand(gatewayCmd)
 }
 
-// normalizeGatewayName normaliza o nome do gateway.
-func normalizeGatewayName(name string) string {
-	switch strings.ToLower(name) {
-	case "tg", "telegram":
-		return "telegram"
-	case "mail", "email":
-		return "email"
-	case "zap", "whatsapp":
-		return "whatsapp"
-	case "google_chat", "googlechat":
-		return "google_chat"
-	case "waha":
-		return "waha"
-	default:
-		return ""
-	}
-}
-
 // runGatewayWizard executa o wizard interativo para configurar um gateway.
 func runGatewayWizard(providerName string) error {
 	// Se provider não foi especificado, pergunta
@@ -393,7 +297,7 @@ func runGatewayWizard(providerName string) error {
 		var selected string
 		prompt := &survey.Select{
 			Message: "Selecione o gateway a configurar:",
-			Options: []string{"telegram", "email", "whatsapp", "google_chat", "waha"},
+			Options: providers.Names(),
 		}
 		if err := survey.AskOne(prompt, &selected); err != nil {
 			return err
@@ -401,8 +305,8 @@ func runGatewayWizard(providerName string) error {
 		providerName = selected
 	}
 
-	normalized := normalizeGatewayName(providerName)
-	if normalized == "" {
+	reg, ok := providers.Lookup(providerName)
+	if !ok {
 		return fmt.Errorf("provider desconhecido: %s", providerName)
 	}
 
@@ -412,27 +316,17 @@ func runGatewayWizard(providerName string) error {
 		cfg = &config.Config{}
 	}
 
-	// Executa wizard específico do provider
-	switch normalized {
-	case "telegram":
-		return runTelegramWizard(cfg)
-	case "email":
-		return runEmailWizard(cfg)
-	case "whatsapp":
-		return runWhatsAppWizard(cfg)
-	case "google_chat":
-		return runGoogleChatWizard(cfg)
-	case "waha":
-		return runWAHAWizard(cfg)
-	default:
-		return fmt.Errorf("wizard não implementado para: %s", normalized)
+	// Executa wizard específico do provider (ou o wizard gerado pelo schema)
+	if handler, ok := gatewayHandlers[reg.Name]; ok && handler.Wizard != nil {
+		return handler.Wizard(cfg)
 	}
+	return runSchemaWizard(reg, cfg)
 }
 
 // runGatewayAddFlags executa o add via flags.
 func runGatewayAddFlags(cmd *cobra.Command, providerName string) error {
-	normalized := normalizeGatewayName(providerName)
-	if normalized == "" {
+	reg, ok := providers.Lookup(providerName)
+	if !ok {
 		return fmt.Errorf("provider desconhecido: %s", providerName)
 	}
 
@@ -441,20 +335,18 @@ func runGatewayAddFlags(cmd *cobra.Command, providerName string) error {
 		cfg = &config.Config{}
 	}
 
-	switch normalized {
-	case "telegram":
-		return addTelegramViaFlags(cmd, cfg)
-	case "email":
-		return addEmailViaFlags(cmd, cfg)
-	case "whatsapp":
-		return addWhatsAppViaFlags(cmd, cfg)
-	case "google_chat":
-		return addGoogleChatViaFlags(cmd, cfg)
-	case "waha":
-		return addWAHAViaFlags(cmd, cfg)
-	default:
-		return fmt.Errorf("add via flags não implementado para: %s (use --interactive)", normalized)
+	add := addGatewayViaSchema
+	if handler, ok := gatewayHandlers[reg.Name]; ok && handler.Add != nil {
+		add = func(cmd *cobra.Command, _ *providers.Registration, cfg *config.Config) error {
+			return handler.Add(cmd, cfg)
+		}
+	}
+	if err := add(cmd, reg, cfg); err != nil {
+		red := color.New(color.FgRed, color.Bold)
+		red.Fprintf(os.Stderr, "✗ Erro: %v\n", err)
+		return err
 	}
+	return nil
 }
 
 // runTelegramWizard executa o wizard para Telegram.
@@ -1096,8 +988,9 @@ func addEmailViaFlags(cmd *cobra.Command, cfg *config.Config) error {
 	useSSL, _ := cmd.Flags().GetBool("use-ssl")
 	timeout, _ := cmd.Flags().GetInt("timeout")
 
-	if smtpHost == "" || username == "" || password == "" {
-		return fmt.Errorf("smtp-host, username e password são obrigatórios")
+	oauth2TokenURL, _ := cmd.Flags().GetString("oauth2-token-url")
+	if smtpHost == "" || username == "" || (password == "" && oauth2TokenURL == "") {
+		return fmt.Errorf("smtp-host, username e password (ou oauth2-token-url) são obrigatórios")
 	}
 
 	if smtpPort == 0 {
@@ -1125,6 +1018,9 @@ func addEmailViaFlags(cmd *cobra.Command, cfg *config.Config) error {
 	cfg.Email.UseTLS = useTLS
 	cfg.Email.UseSSL = useSSL
 	cfg.Email.Timeout = timeout
+	if err := setEmailSchemaFlags(cmd, cfg); err != nil {
+		return err
+	}
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("erro ao salvar: %w", err)
@@ -1249,133 +1145,7 @@ func addWAHAViaFlags(cmd *cobra.Command, cfg *config.Config) error {
 	return nil
 }
 
-// showAllGateways mostra todos os gateways configurados.
-func showAllGateways(cfg *config.Config, mask bool) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println("Gateways Configurados:")
-	cyan.Println()
-
-	// Telegram
-	if cfg.Telegram.Token != "" {
-		showTelegramConfig(cfg.Telegram, mask)
-		cyan.Println()
-	}
-
-	// Email
-	if cfg.Email.SMTPHost != "" {
-		showEmailConfig(cfg.Email, mask)
-		cyan.Println()
-	}
-
-	// WhatsApp
-	if cfg.WhatsApp.PhoneNumberID != "" {
-		showWhatsAppConfig(cfg.WhatsApp, mask)
-		cyan.Println()
-	}
-
-	// Google Chat
-	if cfg.GoogleChat.WebhookURL != "" {
-		showGoogleChatConfig(cfg.GoogleChat, mask)
-		cyan.Println()
-	}
-
-	// WAHA
-	if cfg.WAHA.APIURL != "" {
-		showWAHAConfig(cfg.WAHA, mask)
-		cyan.Println()
-	}
-
-	// Verifica se nenhum gateway está configurado
-	if cfg.Telegram.Token == "" && cfg.Email.SMTPHost == "" &&
-		cfg.WhatsApp.PhoneNumberID == "" && cfg.GoogleChat.WebhookURL == "" &&
-		cfg.WAHA.APIURL == "" {
-		yellow := color.New(color.FgYellow)
-		yellow.Println("Nenhum gateway configurado")
-		yellow.Println("Use 'cast gateway add <provider>' para configurar")
-	}
-}
-
-// Funções auxiliares para mostrar configurações
-func showTelegramConfig(cfg config.TelegramConfig, mask bool) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println("Telegram:")
-	if cfg.Token != "" {
-		if mask {
-			cyan.Printf("  Token: %s\n", maskToken(cfg.Token))
-		} else {
-			cyan.Printf("  Token: %s\n", cfg.Token)
-		}
-	}
-	cyan.Printf("  Default Chat ID: %s\n", cfg.DefaultChatID)
-	cyan.Printf("  API URL: %s\n", cfg.APIURL)
-	cyan.Printf("  Timeout: %d segundos\n", cfg.Timeout)
-}
-
-func showEmailConfig(cfg config.EmailConfig, mask bool) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println("Email:")
-	cyan.Printf("  SMTP Host: %s\n", cfg.SMTPHost)
-	cyan.Printf("  SMTP Port: %d\n", cfg.SMTPPort)
-	cyan.Printf("  Username: %s\n", cfg.Username)
-	if mask {
-		cyan.Println("  Password: *****")
-	} else {
-		cyan.Printf("  Password: %s\n", cfg.Password)
-	}
-	cyan.Printf("  From Email: %s\n", cfg.FromEmail)
-	cyan.Printf("  From Name: %s\n", cfg.FromName)
-	cyan.Printf("  Use TLS: %v\n", cfg.UseTLS)
-	cyan.Printf("  Use SSL: %v\n", cfg.UseSSL)
-	cyan.Printf("  Timeout: %d segundos\n", cfg.Timeout)
-}
-
-func showWhatsAppConfig(cfg config.WhatsAppConfig, mask bool) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println("WhatsApp:")
-	cyan.Printf("  Phone Number ID: %s\n", cfg.PhoneNumberID)
-	if mask {
-		cyan.Println("  Access Token: *****")
-	} else {
-		cyan.Printf("  Access Token: %s\n", cfg.AccessToken)
-	}
-	cyan.Printf("  Business Account ID: %s\n", cfg.BusinessAccountID)
-	cyan.Printf("  API Version: %s\n", cfg.APIVersion)
-	cyan.Printf("  API URL: %s\n", cfg.APIURL)
-	cyan.Printf("  Timeout: %d segundos\n", cfg.Timeout)
-}
-
-func showGoogleChatConfig(cfg config.GoogleChatConfig, mask bool) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println("Google Chat:")
-	if mask {
-		cyan.Println("  Webhook URL: *****")
-	} else {
-		cyan.Printf("  Webhook URL: %s\n", cfg.WebhookURL)
-	}
-	cyan.Printf("  Timeout: %d segundos\n", cfg.Timeout)
-}
-
-func showWAHAConfig(cfg config.WAHAConfig, mask bool) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println("WAHA:")
-	cyan.Printf("  API URL: %s\n", cfg.APIURL)
-	cyan.Printf("  Session: %s\n", cfg.Session)
-	if mask {
-		if cfg.APIKey != "" {
-			cyan.Printf("  API Key: %s\n", maskToken(cfg.APIKey))
-		} else {
-			cyan.Println("  API Key: (não configurada)")
-		}
-	} else {
-		if cfg.APIKey != "" {
-			cyan.Printf("  API Key: %s\n", cfg.APIKey)
-		} else {
-			cyan.Println("  API Key: (não configurada)")
-		}
-	}
-	cyan.Printf("  Timeout: %d segundos\n", cfg.Timeout)
-}
-
+// maskToken mascara um token mantendo apenas os 4 primeiros e 4 últimos caracteres.
 func maskToken(token string) string {
 	if len(token) <= 8 {
 		return "*****"
@@ -1444,6 +1214,21 @@ func updateEmailViaFlags(cmd *cobra.Command, cfg *config.Config) error {
 		cfg.Email.Timeout = timeout
 	}
 
+	return setEmailSchemaFlags(cmd, cfg)
+}
+
+// setEmailSchemaFlags atribui os campos smtp_max_messages, dkim_* e oauth2_* do schema do email
+// informados via flags.
+func setEmailSchemaFlags(cmd *cobra.Command, cfg *config.Config) error {
+	reg, _ := providers.Lookup("email")
+	for _, f := range reg.Fields {
+		if !(f.Key == "smtp_max_messages" || strings.HasPrefix(f.Key, "dkim_") || strings.HasPrefix(f.Key, "oauth2_")) || !cmd.Flags().Changed(f.Flag) {
+			continue
+		}
+		if err := setSchemaField(reg, cfg, f, cmd.Flags().Lookup(f.Flag).Value.String()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1579,7 +1364,7 @@ func testTelegram(cfg config.TelegramConfig) error {
 
 // testEmail testa conectividade SMTP sem enviar email.
 func testEmail(cfg config.EmailConfig, target string) error {
-	if cfg.SMTPHost == "" || cfg.Username == "" || cfg.Password == "" {
+	if cfg.SMTPHost == "" || cfg.Username == "" || (cfg.Password == "" && cfg.OAuth2TokenURL == "") {
 		red := color.New(color.FgRed, color.Bold)
 		red.Println("✗ Email não está configurado")
 		return fmt.Errorf("email não está configurado")
@@ -1651,8 +1436,13 @@ func testEmail(cfg config.EmailConfig, target string) error {
 		}
 	}
 
-	// Autenticação
-	auth := smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SMTPHost)
+	// Autenticação (XOAUTH2 quando OAuth2 está configurado, senão PLAIN)
+	auth, err := providers.NewEmailSMTPAuth(&cfg)
+	if err != nil {
+		red := color.New(color.FgRed, color.Bold)
+		red.Printf("✗ Erro ao obter credenciais: %v\n", err)
+		return err
+	}
 	if err := conn.Auth(auth); err != nil {
 		red := color.New(color.FgRed, color.Bold)
 		red.Printf("✗ Erro na autenticação: %v\n", err)
@@ -1681,6 +1471,32 @@ func testEmail(cfg config.EmailConfig, target string) error {
 	return nil
 }
 
+// testEmailDKIM assina uma mensagem de teste com a configuração DKIM, verifica a
+// assinatura localmente e compara a chave publicada no DNS.
+func testEmailDKIM(cfg config.EmailConfig) error {
+	red := color.New(color.FgRed, color.Bold)
+	green := color.New(color.FgHiGreen, color.Bold)
+	yellow := color.New(color.FgYellow)
+	cyan := color.New(color.FgCyan)
+
+	check, err := providers.CheckDKIM(&cfg, net.LookupTXT)
+	if err != nil {
+		red.Printf("✗ DKIM: %v\n", err)
+		return err
+	}
+
+	green.Printf("✓ Assinatura DKIM válida (%s, d=%s, s=%s)\n", check.Algorithm, check.Domain, check.Selector)
+	cyan.Printf("  Headers assinados: %s\n", strings.Join(check.Headers, ", "))
+	if check.DNSErr != nil {
+		yellow.Printf("⚠ DNS: %v\n", check.DNSErr)
+		cyan.Printf("  Publique o registro TXT em %s:\n", check.DNSName)
+		fmt.Printf("  %s\n", check.Record)
+		return nil
+	}
+	green.Printf("✓ Chave publicada em %s confere com a chave privada\n", check.DNSName)
+	return nil
+}
+
 // testWhatsApp testa conectividade do WhatsApp chamando endpoint de metadados.
 func testWhatsApp(cfg config.WhatsAppConfig) error {
 	if cfg.PhoneNumberID == "" || cfg.AccessToken == "" {
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+// gatewayHandler agrupa rotinas específicas de um gateway que substituem as
+// rotinas genéricas geradas a partir do schema do provider registrado.
+type gatewayHandler struct {
+	Wizard func(cfg *config.Config) error
+	Add    func(cmd *cobra.Command, cfg *config.Config) error
+	Update func(cmd *cobra.Command, cfg *config.Config) error
+	Test   func(cfg *config.Config, target string) error
+	Show   func(cfg *config.Config, mask bool)
+	Remove func(cmd *cobra.Command, cfg *config.Config) error
+}
+
+// gatewayHandlers contém as rotinas customizadas (wizards com orientações e testes
+// com diagnóstico detalhado). Providers sem entrada usam as rotinas do schema.
+var gatewayHandlers = map[string]gatewayHandler{
+	"telegram": {
+		Wizard: runTelegramWizard,
+		Add:    addTelegramViaFlags,
+		Update: updateTelegramViaFlags,
+		Test: func(cfg *config.Config, _ string) error {
+			return testTelegram(cfg.Telegram)
+		},
+	},
+	"email": {
+		Wizard: runEmailWizard,
+		Add:    addEmailViaFlags,
+		Update: updateEmailViaFlags,
+		Test: func(cfg *config.Config, target string) error {
+			return testEmail(cfg.Email, target)
+		},
+	},
+	"whatsapp": {
+		Wizard: runWhatsAppWizard,
+		Add:    addWhatsAppViaFlags,
+		Update: updateWhatsAppViaFlags,
+		Test: func(cfg *config.Config, _ string) error {
+			return testWhatsApp(cfg.WhatsApp)
+		},
+	},
+	"google_chat": {
+		Wizard: runGoogleChatWizard,
+		Add:    addGoogleChatViaFlags,
+		Update: updateGoogleChatViaFlags,
+		Test: func(cfg *config.Config, target string) error {
+			return testGoogleChat(cfg.GoogleChat, target)
+		},
+	},
+	"waha": {
+		Wizard: runWAHAWizard,
+		Add:    addWAHAViaFlags,
+		Update: updateWAHAViaFlags,
+		Test: func(cfg *config.Config, _ string) error {
+			return testWAHA(cfg.WAHA)
+		},
+	},
+}
+
+// registerGatewayFlags registra em cmd uma flag para cada campo do schema dos providers.
+// Flags compartilhadas (ex: --timeout) são registradas uma única vez. Se os providers
+// declaram tipos diferentes para a mesma flag (ex: --default-priority, número no Gotify e
+// P1-P5 no Opsgenie), ela é registrada como string e cada provider valida o valor.
+func registerGatewayFlags(cmd *cobra.Command) {
+	type flagInfo struct {
+		field    providers.ConfigField
+		owners   []string
+		labels   []string // "Rótulo (Provider)" de cada provider
+		conflict bool     // Tipos diferentes entre os providers
+		relabel  bool     // Rótulos diferentes entre os providers
+	}
+
+	var order []string
+	infos := map[string]*flagInfo{}
+	for _, reg := range providers.Registered() {
+		for _, f := range reg.Fields {
+			if f.Flag == "" {
+				continue
+			}
+			info, ok := infos[f.Flag]
+			if !ok {
+				info = &flagInfo{field: f}
+				infos[f.Flag] = info
+				order = append(order, f.Flag)
+			}
+			info.conflict = info.conflict || f.Kind != info.field.Kind
+			info.relabel = info.relabel || f.FlagUsage() != info.field.FlagUsage()
+			info.owners = append(info.owners, reg.DisplayName)
+			info.labels = append(info.labels, fmt.Sprintf("%s (%s)", f.FlagUsage(), reg.DisplayName))
+		}
+	}
+
+	for _, name := range order {
+		info := infos[name]
+		usage := fmt.Sprintf("%s (%s)", info.field.FlagUsage(), strings.Join(info.owners, ", "))
+		if info.relabel {
+			usage = strings.Join(info.labels, "; ")
+		}
+		kind := info.field.Kind
+		if info.conflict {
+			kind = providers.FieldString
+		}
+		switch kind {
+		case providers.FieldInt:
+			cmd.Flags().Int(name, 0, usage)
+		case providers.FieldBool:
+			cmd.Flags().Bool(name, false, usage)
+		default:
+			cmd.Flags().String(name, "", usage)
+		}
+	}
+}
+
+// setSchemaField valida e atribui o valor de um campo do schema na configuração.
+func setSchemaField(reg *providers.Registration, cfg *config.Config, f providers.ConfigField, value string) error {
+	if f.Validate != nil {
+		if err := f.Validate(value); err != nil {
+			return fmt.Errorf("%s: %w", f.Key, err)
+		}
+	}
+	return cfg.SetField(reg.Name, f.Key, value)
+}
+
+// checkRequiredFields verifica se todos os campos obrigatórios do schema estão preenchidos.
+func checkRequiredFields(reg *providers.Registration, cfg *config.Config) error {
+	for _, f := range reg.Fields {
+		if !f.Required {
+			continue
+		}
+		value, err := cfg.GetField(reg.Name, f.Key)
+		if err != nil {
+			return err
+		}
+		if value == "" || value == "0" {
+			if f.Flag != "" {
+				return fmt.Errorf("%s é obrigatório (use --%s)", f.Flag, f.Flag)
+			}
+			return fmt.Errorf("%s é obrigatório", f.Key)
+		}
+	}
+	return nil
+}
+
+// addGatewayViaSchema adiciona um gateway via flags usando o schema do provider.
+func addGatewayViaSchema(cmd *cobra.Command, reg *providers.Registration, cfg *config.Config) error {
+	// Add configura a seção do zero
+	if err := cfg.ResetSection(reg.Name); err != nil {
+		return err
+	}
+
+	for _, f := range reg.Fields {
+		value := f.Default
+		if f.Flag != "" && cmd.Flags().Changed(f.Flag) {
+			value = cmd.Flags().Lookup(f.Flag).Value.String()
+		}
+		if value == "" {
+			continue
+		}
+		if err := setSchemaField(reg, cfg, f, value); err != nil {
+			return err
+		}
+	}
+
+	if err := checkRequiredFields(reg, cfg); err != nil {
+		return err
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("erro ao salvar: %w", err)
+	}
+
+	green := color.New(color.FgHiGreen, color.Bold)
+	green.Printf("✓ Configuração do %s salva com sucesso\n", reg.DisplayName)
+
+	return nil
+}
+
+// updateGatewayViaSchema atualiza um gateway via flags (apenas campos fornecidos).
+func updateGatewayViaSchema(cmd *cobra.Command, reg *providers.Registration, cfg *config.Config) error {
+	for _, f := range reg.Fields {
+		if f.Flag == "" || !cmd.Flags().Changed(f.Flag) {
+			continue
+		}
+		value := cmd.Flags().Lookup(f.Flag).Value.String()
+		if err := setSchemaField(reg, cfg, f, value); err != nil {
+			return err
+		}
+	}
+	return checkRequiredFields(reg, cfg)
+}
+
+// runSchemaWizard executa um wizard interativo gerado a partir do schema do provider.
+func runSchemaWizard(reg *providers.Registration, cfg *config.Config) error {
+	cyan := color.New(color.FgCyan)
+
+	for _, f := range reg.Fields {
+		current, _ := cfg.GetField(reg.Name, f.Key)
+		if current == "" || current == "0" {
+			current = f.Default
+		}
+
+		var answer string
+		switch {
+		case f.Kind == providers.FieldBool:
+			def, _ := strconv.ParseBool(current)
+			var confirmed bool
+			if err := survey.AskOne(&survey.Confirm{Message: f.Label + "?", Default: def}, &confirmed); err != nil {
+				return err
+			}
+			answer = strconv.FormatBool(confirmed)
+		case f.Secret:
+			if err := survey.AskOne(&survey.Password{Message: f.Label + ":"}, &answer); err != nil {
+				return err
+			}
+			if answer == "" {
+				answer = current
+			}
+		default:
+			if err := survey.AskOne(&survey.Input{Message: f.Label + ":", Default: current}, &answer); err != nil {
+				return err
+			}
+		}
+
+		answer = strings.TrimSpace(answer)
+		if f.Required && answer == "" {
+			return fmt.Errorf("%s é obrigatório", f.Label)
+		}
+		if err := setSchemaField(reg, cfg, f, answer); err != nil {
+			return err
+		}
+	}
+
+	// Mostra resumo
+	cyan.Println("\nConfiguração a ser salva:")
+	printGatewayFields(reg, cfg, true)
+
+	// Confirmação
+	var confirm bool
+	if err := survey.AskOne(&survey.Confirm{
+		Message: "Confirmar e salvar?",
+		Default: true,
+	}, &confirm); err != nil {
+		return err
+	}
+
+	if !confirm {
+		yellow := color.New(color.FgYellow)
+		yellow.Println("Operação cancelada")
+		return nil
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("erro ao salvar: %w", err)
+	}
+
+	green := color.New(color.FgHiGreen, color.Bold)
+	green.Printf("✓ Configuração do %s salva com sucesso\n", reg.DisplayName)
+
+	return nil
+}
+
+// showAllGateways mostra todos os gateways configurados.
+func showAllGateways(cfg *config.Config, mask bool) {
+	cyan := color.New(color.FgCyan)
+	cyan.Println("Gateways Configurados:")
+	cyan.Println()
+
+	configured := 0
+	for _, reg := range providers.Registered() {
+		if reg.Configured == nil || !reg.Configured(cfg) {
+			continue
+		}
+		showGatewayConfig(reg, cfg, mask)
+		cyan.Println()
+		configured++
+	}
+
+	// Verifica se nenhum gateway está configurado
+	if configured == 0 {
+		yellow := color.New(color.FgYellow)
+		yellow.Println("Nenhum gateway configurado")
+		yellow.Println("Use 'cast gateway add <provider>' para configurar")
+	}
+}
+
+// showGatewayConfig mostra a configuração de um gateway a partir do schema.
+func showGatewayConfig(reg *providers.Registration, cfg *config.Config, mask bool) {
+	if handler, ok := gatewayHandlers[reg.Name]; ok && handler.Show != nil {
+		handler.Show(cfg, mask)
+		return
+	}
+	cyan := color.New(color.FgCyan)
+	cyan.Printf("%s:\n", reg.DisplayName)
+	printGatewayFields(reg, cfg, mask)
+}
+
+// printGatewayFields imprime os campos do schema de um gateway, mascarando segredos.
+func printGatewayFields(reg *providers.Registration, cfg *config.Config, mask bool) {
+	cyan := color.New(color.FgCyan)
+	for _, f := range reg.Fields {
+		value, err := cfg.GetField(reg.Name, f.Key)
+		if err != nil {
+			continue
+		}
+		if f.Secret {
+			if value == "" {
+				value = "(não configurada)"
+			} else if mask {
+				value = maskToken(value)
+			}
+		}
+		if f.Key == "timeout" {
+			value += " segundos"
+		}
+		cyan.Printf("  %s: %s\n", f.Label, value)
+	}
+}
+
+// testGatewayViaRegistry testa um gateway usando a rotina de teste registrada pelo provider.
+func testGatewayViaRegistry(reg *providers.Registration, cfg *config.Config, target string) error {
+	red := color.New(color.FgRed, color.Bold)
+
+	if reg.Configured != nil && !reg.Configured(cfg) {
+		red.Printf("✗ %s não está configurado\n", reg.DisplayName)
+		return fmt.Errorf("%s não está configurado", reg.Name)
+	}
+
+	if reg.Test == nil {
+		yellow := color.New(color.FgYellow)
+		yellow.Printf("⚠ Teste não implementado para: %s\n", reg.Name)
+		return fmt.Errorf("teste não implementado para: %s", reg.Name)
+	}
+
+	start := time.Now()
+	if err := reg.Test(cfg, target); err != nil {
+		red.Printf("✗ Erro no teste: %v\n", err)
+		return err
+	}
+
+	green := color.New(color.FgHiGreen, color.Bold)
+	green.Printf("✓ Conectividade OK (%dms)\n", time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// providerListHelp retorna a lista de nomes curtos dos providers para textos de help.
+func providerListHelp() string {
+	return strings.Join(providers.ShortNames(), ", ")
+}
+
+// gatewayListHelp retorna a lista de nomes canônicos dos providers para textos de help.
+func gatewayListHelp() string {
+	return strings.Join(providers.Names(), ", ")
+}
+
+// providerDisplayListHelp retorna os nomes amigáveis dos providers (ex: Telegram, Email).
+func providerDisplayListHelp() string {
+	names := make([]string, 0, len(providers.Names()))
+	for _, reg := range providers.Registered() {
+		names = append(names, reg.DisplayName)
+	}
+	return strings.Join(names, ", ")
+}
+
+// printGatewayFlagsHelp imprime as flags de configuração de cada provider registrado.
+func printGatewayFlagsHelp() {
+	for _, reg := range providers.Registered() {
+		fmt.Printf("  # %s:\n", reg.DisplayName)
+		for _, f := range reg.Fields {
+			if f.Flag == "" {
+				continue
+			}
+			flag := "--" + f.Flag
+			switch f.Kind {
+			case providers.FieldInt:
+				flag += " int"
+			case providers.FieldString:
+				flag += " string"
+			}
+			desc := f.FlagUsage()
+			if !f.Required {
+				desc += " (opcional"
+				if f.Default != "" {
+					desc += ", padrão: " + f.Default
+				}
+				desc += ")"
+			}
+			fmt.Printf("  %-28s %s\n", flag, desc)
+		}
+		fmt.Println()
+	}
+}
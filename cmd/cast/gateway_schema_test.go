@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+func TestRegisterGatewayFlags_ConflictingKinds(t *testing.T) {
+	cmd := &cobra.Command{Use: "add"}
+	registerGatewayFlags(cmd)
+
+	// Gotify declara --default-priority como número e o Opsgenie como P1-P5
+	flag := cmd.Flags().Lookup("default-priority")
+	if flag == nil {
+		t.Fatal("Flag --default-priority não registrada")
+	}
+	if flag.Value.Type() != "string" {
+		t.Errorf("Flag com tipos diferentes entre providers deveria ser string, obtido %s", flag.Value.Type())
+	}
+
+	tests := []struct {
+		provider string
+		value    string
+		wantErr  bool
+	}{
+		{"opsgenie", "P1", false},
+		{"opsgenie", "7", true},
+		{"gotify", "8", false},
+		{"gotify", "P1", true},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{Use: "add"}
+		registerGatewayFlags(cmd)
+		if err := cmd.Flags().Set("default-priority", tt.value); err != nil {
+			t.Fatalf("--default-priority %s rejeitado na leitura das flags: %v", tt.value, err)
+		}
+
+		reg, _ := providers.Lookup(tt.provider)
+		cfg := &config.Config{}
+		err := updateGatewayViaSchema(cmd, reg, cfg)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s --default-priority %s: esperado erro de validação", tt.provider, tt.value)
+			}
+			continue
+		}
+		// Campos obrigatórios ausentes não importam aqui, apenas a prioridade gravada
+		if got, _ := cfg.GetField(reg.Name, "default_priority"); got != tt.value {
+			t.Errorf("%s --default-priority %s: gravado %q (erro: %v)", tt.provider, tt.value, got, err)
+		}
+	}
+}
+
+func TestRegisterGatewayFlags_UsageSeparateFromLabel(t *testing.T) {
+	cmd := &cobra.Command{Use: "add"}
+	registerGatewayFlags(cmd)
+
+	reg, ok := providers.Lookup("email")
+	if !ok {
+		t.Fatal("Provider email não registrado")
+	}
+	field, _ := reg.Field("smtp_max_messages")
+	if field.Label != "Mensagens por conexão SMTP" {
+		t.Errorf("Label deveria ser curto no gateway show, obtido %q", field.Label)
+	}
+	flag := cmd.Flags().Lookup("smtp-max-messages")
+	if flag == nil || flag.Usage != field.Usage+" (Email)" {
+		t.Errorf("Help da flag deveria usar a descrição completa, obtido %v", flag)
+	}
+
+	if key, _ := reg.Field("dkim_private_key"); !key.Secret {
+		t.Error("dkim_private_key aceita a chave PEM inline e deveria ser secreto")
+	}
+}
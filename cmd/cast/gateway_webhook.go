@@ -0,0 +1,452 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+func init() {
+	gatewayHandlers["webhook"] = gatewayHandler{
+		Wizard: runWebhookWizard,
+		Add:    addWebhookViaFlags,
+		Update: updateWebhookViaFlags,
+		Show:   showWebhookConfig,
+		Remove: removeWebhookViaFlags,
+	}
+
+	for _, cmd := range []*cobra.Command{gatewayAddCmd, gatewayUpdateCmd} {
+		registerWebhookEndpointFlags(cmd)
+	}
+	gatewayRemoveCmd.Flags().String("name", "", "Remove apenas o endpoint informado (Webhook)")
+}
+
+// registerWebhookEndpointFlags registra as flags de definição de endpoints de webhook.
+func registerWebhookEndpointFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Nome do endpoint (Webhook)")
+	cmd.Flags().String("url", "", "URL do endpoint (Webhook)")
+	cmd.Flags().String("method", "", "Método HTTP: POST, PUT, PATCH, GET ou DELETE (Webhook, padrão: POST)")
+	cmd.Flags().StringArray("header", nil, "Header no formato Nome=Valor, aceita ${env:VAR} e ${file:/caminho} (Webhook, pode ser repetido)")
+	cmd.Flags().String("body", "", "Template Go do corpo ou @arquivo (Webhook)")
+	cmd.Flags().String("format", "", "Formato do corpo: json, form ou text (Webhook, padrão: json)")
+	cmd.Flags().String("hmac-secret", "", "Segredo para assinatura HMAC do corpo (Webhook)")
+	cmd.Flags().String("hmac-header", "", "Header da assinatura HMAC (Webhook, padrão: X-Signature-256)")
+	cmd.Flags().String("hmac-algorithm", "", "Algoritmo HMAC: sha256, sha1 ou sha512 (Webhook, padrão: sha256)")
+	cmd.Flags().String("expect-status", "", "Status HTTP esperados separados por vírgula, ex: 200,202 (Webhook, padrão: 2xx)")
+}
+
+// webhookEndpointName retorna o nome informado em --name, em minúsculas como o
+// viper carrega as chaves do cast.yaml.
+func webhookEndpointName(cmd *cobra.Command) string {
+	name, _ := cmd.Flags().GetString("name")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// parseWebhookHeaders converte entradas Nome=Valor em mapa de headers.
+func parseWebhookHeaders(entries []string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("header inválido: '%s' (use Nome=Valor)", entry)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// parseExpectedStatus converte "200,201" em lista de status HTTP.
+func parseExpectedStatus(value string) ([]int, error) {
+	var codes []int
+	for _, part := range config.ParseTargets(value) {
+		code, err := strconv.Atoi(part)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("status HTTP inválido: '%s'", part)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// applyWebhookEndpointFlags aplica as flags fornecidas sobre a definição do endpoint.
+func applyWebhookEndpointFlags(cmd *cobra.Command, endpoint *config.WebhookEndpoint) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("url") {
+		endpoint.URL, _ = flags.GetString("url")
+	}
+	if flags.Changed("method") {
+		method, _ := flags.GetString("method")
+		endpoint.Method = strings.ToUpper(method)
+	}
+	if flags.Changed("header") {
+		entries, _ := flags.GetStringArray("header")
+		headers, err := parseWebhookHeaders(entries)
+		if err != nil {
+			return err
+		}
+		if endpoint.Headers == nil {
+			endpoint.Headers = map[string]string{}
+		}
+		for name, value := range headers {
+			// Valor vazio remove o header (ex: --header "X-Antigo=")
+			if value == "" {
+				delete(endpoint.Headers, name)
+				continue
+			}
+			endpoint.Headers[name] = value
+		}
+	}
+	if flags.Changed("body") {
+		body, _ := flags.GetString("body")
+		if strings.HasPrefix(body, "@") {
+			data, err := os.ReadFile(strings.TrimPrefix(body, "@"))
+			if err != nil {
+				return fmt.Errorf("erro ao ler template do corpo: %w", err)
+			}
+			body = string(data)
+		}
+		endpoint.Body = body
+	}
+	if flags.Changed("format") {
+		format, _ := flags.GetString("format")
+		endpoint.Format = strings.ToLower(format)
+	}
+	if flags.Changed("hmac-secret") {
+		endpoint.HMACSecret, _ = flags.GetString("hmac-secret")
+	}
+	if flags.Changed("hmac-header") {
+		endpoint.HMACHeader, _ = flags.GetString("hmac-header")
+	}
+	if flags.Changed("hmac-algorithm") {
+		algorithm, _ := flags.GetString("hmac-algorithm")
+		endpoint.HMACAlgorithm = strings.ToLower(algorithm)
+	}
+	if flags.Changed("expect-status") {
+		value, _ := flags.GetString("expect-status")
+		codes, err := parseExpectedStatus(value)
+		if err != nil {
+			return err
+		}
+		endpoint.ExpectedStatus = codes
+	}
+
+	return providers.ValidateWebhookEndpoint(*endpoint)
+}
+
+// addWebhookViaFlags adiciona (ou substitui) um endpoint de webhook via flags.
+func addWebhookViaFlags(cmd *cobra.Command, cfg *config.Config) error {
+	name := webhookEndpointName(cmd)
+	if name == "" {
+		return fmt.Errorf("name é obrigatório (use --name)")
+	}
+	if !cmd.Flags().Changed("url") {
+		return fmt.Errorf("url é obrigatório (use --url)")
+	}
+
+	var endpoint config.WebhookEndpoint
+	if err := applyWebhookEndpointFlags(cmd, &endpoint); err != nil {
+		return err
+	}
+
+	if cfg.Webhook.Endpoints == nil {
+		cfg.Webhook.Endpoints = map[string]config.WebhookEndpoint{}
+	}
+	cfg.Webhook.Endpoints[name] = endpoint
+	if cmd.Flags().Changed("timeout") {
+		cfg.Webhook.Timeout, _ = cmd.Flags().GetInt("timeout")
+	}
+	if cfg.Webhook.Timeout == 0 {
+		cfg.Webhook.Timeout = 30
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("erro ao salvar: %w", err)
+	}
+
+	green := color.New(color.FgHiGreen, color.Bold)
+	green.Printf("✓ Webhook '%s' salvo com sucesso\n", name)
+
+	return nil
+}
+
+// updateWebhookViaFlags atualiza um endpoint de webhook (apenas campos fornecidos).
+// Sem --name, atualiza apenas o timeout padrão.
+func updateWebhookViaFlags(cmd *cobra.Command, cfg *config.Config) error {
+	if cmd.Flags().Changed("timeout") {
+		cfg.Webhook.Timeout, _ = cmd.Flags().GetInt("timeout")
+	}
+
+	name := webhookEndpointName(cmd)
+	if name == "" {
+		for _, flag := range []string{"url", "method", "header", "body", "format", "hmac-secret", "hmac-header", "hmac-algorithm", "expect-status"} {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("--%s requer --name com o endpoint a atualizar", flag)
+			}
+		}
+		return nil
+	}
+
+	endpoint, ok := cfg.Webhook.Endpoints[name]
+	if !ok {
+		return fmt.Errorf("webhook '%s' não encontrado (use 'cast gateway add webhook --name %s --url ...')", name, name)
+	}
+	if err := applyWebhookEndpointFlags(cmd, &endpoint); err != nil {
+		return err
+	}
+	cfg.Webhook.Endpoints[name] = endpoint
+
+	return nil
+}
+
+// removeWebhookViaFlags remove um endpoint (--name) ou toda a configuração de webhooks.
+func removeWebhookViaFlags(cmd *cobra.Command, cfg *config.Config) error {
+	name := webhookEndpointName(cmd)
+	if name == "" {
+		return cfg.ResetSection("webhook")
+	}
+	if _, ok := cfg.Webhook.Endpoints[name]; !ok {
+		return fmt.Errorf("webhook '%s' não encontrado", name)
+	}
+	delete(cfg.Webhook.Endpoints, name)
+	return nil
+}
+
+// showWebhookConfig mostra o timeout padrão e os endpoints configurados.
+func showWebhookConfig(cfg *config.Config, mask bool) {
+	cyan := color.New(color.FgCyan)
+	cyan.Println("Webhook HTTP:")
+	cyan.Printf("  Timeout: %d segundos\n", cfg.Webhook.Timeout)
+
+	names := make([]string, 0, len(cfg.Webhook.Endpoints))
+	for name := range cfg.Webhook.Endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		cyan.Println("  Endpoints: (nenhum)")
+		return
+	}
+
+	for _, name := range names {
+		printWebhookEndpoint(name, cfg.Webhook.Endpoints[name], mask)
+	}
+}
+
+// printWebhookEndpoint imprime a definição de um endpoint, mascarando segredos literais.
+// Referências ${env:...} e ${file:...} são mostradas como estão.
+func printWebhookEndpoint(name string, endpoint config.WebhookEndpoint, mask bool) {
+	cyan := color.New(color.FgCyan)
+
+	maskValue := func(value string) string {
+		if mask && !providers.IsSecretRef(value) {
+			return maskToken(value)
+		}
+		return value
+	}
+
+	method := endpoint.Method
+	if method == "" {
+		method = "POST"
+	}
+	format := endpoint.Format
+	if format == "" {
+		format = "json"
+	}
+
+	cyan.Printf("  %s:\n", name)
+	cyan.Printf("    URL: %s %s\n", method, endpoint.URL)
+	cyan.Printf("    Formato: %s\n", format)
+
+	headerNames := make([]string, 0, len(endpoint.Headers))
+	for header := range endpoint.Headers {
+		headerNames = append(headerNames, header)
+	}
+	sort.Strings(headerNames)
+	for _, header := range headerNames {
+		cyan.Printf("    Header %s: %s\n", http.CanonicalHeaderKey(header), maskValue(endpoint.Headers[header]))
+	}
+
+	if endpoint.Body != "" {
+		cyan.Printf("    Body: %s\n", strings.ReplaceAll(strings.TrimSpace(endpoint.Body), "\n", " "))
+	}
+	if endpoint.HMACSecret != "" {
+		algorithm := endpoint.HMACAlgorithm
+		if algorithm == "" {
+			algorithm = "sha256"
+		}
+		header := endpoint.HMACHeader
+		if header == "" {
+			header = "X-Signature-256"
+		}
+		cyan.Printf("    HMAC: %s em %s (segredo: %s)\n", algorithm, header, maskValue(endpoint.HMACSecret))
+	}
+	if len(endpoint.ExpectedStatus) > 0 {
+		codes := make([]string, len(endpoint.ExpectedStatus))
+		for i, code := range endpoint.ExpectedStatus {
+			codes[i] = strconv.Itoa(code)
+		}
+		cyan.Printf("    Status esperado: %s\n", strings.Join(codes, ", "))
+	}
+	if endpoint.Timeout > 0 {
+		cyan.Printf("    Timeout: %d segundos\n", endpoint.Timeout)
+	}
+}
+
+// runWebhookWizard executa o wizard para adicionar um endpoint de webhook.
+func runWebhookWizard(cfg *config.Config) error {
+	var answers struct {
+		Name         string `survey:"name"`
+		URL          string `survey:"url"`
+		Method       string `survey:"method"`
+		Format       string `survey:"format"`
+		Headers      string `survey:"headers"`
+		Body         string `survey:"body"`
+		HMACSecret   string `survey:"hmacsecret"`
+		ExpectStatus string `survey:"expectstatus"`
+	}
+
+	questions := []*survey.Question{
+		{
+			Name:     "name",
+			Prompt:   &survey.Input{Message: "Nome do endpoint (usado em 'cast send webhook <nome>'):"},
+			Validate: survey.Required,
+		},
+		{
+			Name:   "url",
+			Prompt: &survey.Input{Message: "URL do endpoint:"},
+			Validate: func(val interface{}) error {
+				url, _ := val.(string)
+				if url == "" {
+					return fmt.Errorf("URL é obrigatória")
+				}
+				return providers.ValidateHTTPURL(url)
+			},
+		},
+		{
+			Name: "method",
+			Prompt: &survey.Select{
+				Message: "Método HTTP:",
+				Options: []string{"POST", "PUT", "PATCH", "GET", "DELETE"},
+				Default: "POST",
+			},
+		},
+		{
+			Name: "format",
+			Prompt: &survey.Select{
+				Message: "Formato do corpo:",
+				Options: []string{"json", "form", "text"},
+				Default: "json",
+			},
+		},
+		{
+			Name:   "headers",
+			Prompt: &survey.Input{Message: "Headers (Nome=Valor separados por ';', aceita ${env:VAR}; opcional):"},
+		},
+		{
+			Name:   "body",
+			Prompt: &survey.Input{Message: "Template do corpo ou @arquivo (opcional, vazio usa o padrão):"},
+		},
+		{
+			Name:   "hmacsecret",
+			Prompt: &survey.Password{Message: "Segredo HMAC (opcional):"},
+		},
+		{
+			Name:   "expectstatus",
+			Prompt: &survey.Input{Message: "Status HTTP esperados (ex: 200,202; vazio aceita 2xx):"},
+		},
+	}
+
+	if err := survey.Ask(questions, &answers); err != nil {
+		return err
+	}
+
+	endpoint := config.WebhookEndpoint{
+		URL:        strings.TrimSpace(answers.URL),
+		Method:     answers.Method,
+		Format:     answers.Format,
+		HMACSecret: answers.HMACSecret,
+	}
+
+	if answers.Headers != "" {
+		var entries []string
+		for _, entry := range strings.Split(answers.Headers, ";") {
+			if strings.TrimSpace(entry) != "" {
+				entries = append(entries, entry)
+			}
+		}
+		headers, err := parseWebhookHeaders(entries)
+		if err != nil {
+			return err
+		}
+		endpoint.Headers = headers
+	}
+
+	endpoint.Body = answers.Body
+	if strings.HasPrefix(endpoint.Body, "@") {
+		data, err := os.ReadFile(strings.TrimPrefix(endpoint.Body, "@"))
+		if err != nil {
+			return fmt.Errorf("erro ao ler template do corpo: %w", err)
+		}
+		endpoint.Body = string(data)
+	}
+
+	codes, err := parseExpectedStatus(answers.ExpectStatus)
+	if err != nil {
+		return err
+	}
+	endpoint.ExpectedStatus = codes
+
+	if err := providers.ValidateWebhookEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	// Mostra resumo
+	cyan := color.New(color.FgCyan)
+	cyan.Println("\nConfiguração a ser salva:")
+	printWebhookEndpoint(answers.Name, endpoint, true)
+
+	// Confirmação
+	var confirm bool
+	if err := survey.AskOne(&survey.Confirm{
+		Message: "Confirmar e salvar?",
+		Default: true,
+	}, &confirm); err != nil {
+		return err
+	}
+
+	if !confirm {
+		yellow := color.New(color.FgYellow)
+		yellow.Println("Operação cancelada")
+		return nil
+	}
+
+	if cfg.Webhook.Endpoints == nil {
+		cfg.Webhook.Endpoints = map[string]config.WebhookEndpoint{}
+	}
+	cfg.Webhook.Endpoints[strings.ToLower(answers.Name)] = endpoint
+	if cfg.Webhook.Timeout == 0 {
+		cfg.Webhook.Timeout = 30
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("erro ao salvar: %w", err)
+	}
+
+	green := color.New(color.FgHiGreen, color.Bold)
+	green.Printf("✓ Webhook '%s' salvo com sucesso\n", answers.Name)
+
+	return nil
+}
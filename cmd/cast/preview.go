@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+var previewCmd = &cobra.Command{
+	Use:           "preview [provider|alias] [target] [message]",
+	Short:         "Exibe a requisição que o send faria, sem enviar",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Long: `Monta a mensagem como o 'cast send' e exibe a requisição HTTP (método, URL, headers
+e corpo JSON) que seria enviada, sem acessar a rede. Aceita os mesmos argumentos e flags
+do send, incluindo aliases e URLs de provider.
+
+Para email, exibe a mensagem MIME exatamente como seria transmitida ao servidor SMTP
+(com --individual ou --merge, todas as mensagens em formato mbox).
+
+Segredos configurados (tokens, senhas) e headers de autenticação são mascarados;
+use --show-secrets para exibi-los. Em fluxos de várias etapas (ex: upload de anexo
+antes da mensagem), apenas a primeira etapa é exibida.
+
+Exemplos:
+  cast preview tg me "Deploy finalizado"
+  cast preview slack "#ops" "Deploy finalizado" --blocks @blocks.json
+  cast preview webhook default "Alerta" --var severity=high
+  cast preview mail admin@empresa.com "Relatório" --markdown > relatorio.eml`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		red := color.New(color.FgRed, color.Bold)
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao carregar configuração: %v\n", err)
+			return err
+		}
+
+		providerName, target, message, err := previewArgs(cmd, cfg, args)
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro: %v\n", err)
+			return err
+		}
+		if strings.EqualFold(providerName, providers.URLProvider) {
+			reg, urlCfg, urlTarget, err := providers.ResolveURL(target)
+			if err != nil {
+				red.Fprintf(os.Stderr, "✗ Erro na URL do provider: %v\n", err)
+				return err
+			}
+			providerName, target, cfg = reg.Name, urlTarget, urlCfg
+		}
+
+		provider, err := providers.GetProvider(providerName, cfg)
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao obter provider: %v\n", err)
+			return err
+		}
+		reg, _ := providers.Lookup(provider.Name())
+		opts := buildSendOptions(cmd, reg.Name)
+
+		if emailProv, ok := provider.(providers.EmailProviderExtended); ok {
+			// A mensagem MIME vai para a saída padrão (salvo --to-file ou --mbox informados)
+			if opts.Get("to-file") == "" && opts.Get("mbox") == "" {
+				if opts.Get("individual") == "true" || opts.Get("merge") != "" {
+					opts.Values["mbox"] = []string{"-"}
+				} else {
+					opts.Values["to-file"] = []string{"-"}
+				}
+			}
+			if _, err := emailProv.SendEmailWithOptions(target, message, opts); err != nil {
+				red.Fprintf(os.Stderr, "✗ Erro ao montar email: %v\n", err)
+				return err
+			}
+			return nil
+		}
+
+		requests, err := providers.PreviewHTTP(provider, target, message, opts)
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro: %v\n", err)
+			return err
+		}
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+		var secrets []string
+		if !showSecrets {
+			secrets = providerSecrets(reg, cfg)
+		}
+		for i, req := range requests {
+			if i > 0 {
+				fmt.Println()
+			}
+			printCapturedRequest(req, secrets, !showSecrets)
+		}
+		return nil
+	},
+}
+
+func init() {
+	previewCmd.Flags().StringP("subject", "s", "", "Assunto (providers com suporte a assunto)")
+	previewCmd.Flags().StringSliceP("attachment", "a", []string{}, "Caminho do arquivo anexo (pode ser usado múltiplas vezes)")
+	previewCmd.Flags().Bool("show-secrets", false, "Exibe tokens e senhas sem máscara")
+	registerSendFlags(previewCmd)
+	rootCmd.AddCommand(previewCmd)
+}
+
+// previewArgs interpreta os argumentos como o send: alias e mensagem, ou provider, target e
+// mensagem (no email, com 4 argumentos e sem --subject, o terceiro é o assunto).
+func previewArgs(cmd *cobra.Command, cfg *config.Config, args []string) (string, string, string, error) {
+	if alias := cfg.GetAlias(args[0]); alias != nil {
+		return alias.Provider, alias.Target, processNewlines(strings.Join(args[1:], " ")), nil
+	}
+	if len(args) < 3 {
+		return "", "", "", fmt.Errorf("formato inválido: requer provider, target e message, ou alias e message")
+	}
+
+	message := strings.Join(args[2:], " ")
+	normalized := strings.ToLower(args[0])
+	if subject, _ := cmd.Flags().GetString("subject"); (normalized == "mail" || normalized == "email") && len(args) == 4 && subject == "" {
+		cmd.Flags().Set("subject", args[2])
+		message = args[3]
+	}
+	return args[0], args[1], processNewlines(message), nil
+}
+
+// providerSecrets retorna os valores dos campos secretos configurados do provider, já com as
+// referências ${env:NOME} e ${file:/caminho} resolvidas, para mascará-los no preview.
+func providerSecrets(reg *providers.Registration, cfg *config.Config) []string {
+	var secrets []string
+	for _, f := range reg.Fields {
+		if !f.Secret {
+			continue
+		}
+		value, err := cfg.GetField(reg.Name, f.Key)
+		if err != nil || value == "" {
+			continue
+		}
+		if resolved, err := providers.ResolveSecretRefs(value); err == nil && resolved != "" {
+			value = resolved
+		}
+		secrets = append(secrets, value)
+	}
+	return secrets
+}
+
+// isAuthHeader indica se o header costuma carregar credenciais.
+func isAuthHeader(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "authorization") || strings.Contains(lower, "token") ||
+		strings.Contains(lower, "key") || strings.Contains(lower, "secret")
+}
+
+// printCapturedRequest imprime a requisição: linha de método e URL, headers ordenados e corpo.
+func printCapturedRequest(req providers.CapturedRequest, secrets []string, mask bool) {
+	hide := func(s string) string {
+		for _, secret := range secrets {
+			s = strings.ReplaceAll(s, secret, maskToken(secret))
+		}
+		return s
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	cyan.Printf("%s %s\n", req.Method, hide(req.URL))
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if mask && isAuthHeader(name) {
+				value = maskToken(value)
+			}
+			fmt.Printf("%s: %s\n", name, hide(value))
+		}
+	}
+	if len(req.Body) > 0 {
+		fmt.Println()
+		fmt.Println(hide(string(req.Body)))
+	}
+}
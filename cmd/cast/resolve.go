@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:          "resolve <provider|alias> [target]",
+	Short:        "Resolve (ou reconhece) um incidente aberto no PagerDuty ou Opsgenie",
+	SilenceUsage: true,
+	Long: `Resolve um incidente aberto com 'cast send ... --dedup-key', usando a mesma chave.
+No PagerDuty a chave é a dedup_key; no Opsgenie, o alias do alerta.
+
+Sem target, usa o serviço padrão (PagerDuty routing_key). Aliases também são aceitos.
+
+Exemplos:
+  cast resolve pd database --dedup-key db-down
+  cast resolve opsgenie --dedup-key db-down --note "Failover concluído"
+  cast resolve pd database --dedup-key db-down --ack`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		red := color.New(color.FgRed, color.Bold)
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		key, _ := cmd.Flags().GetString("dedup-key")
+		note, _ := cmd.Flags().GetString("note")
+		ack, _ := cmd.Flags().GetBool("ack")
+
+		if key == "" {
+			red.Fprintf(os.Stderr, "✗ Erro: --dedup-key é obrigatório\n")
+			return fmt.Errorf("--dedup-key é obrigatório")
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao carregar configuração: %v\n", err)
+			return err
+		}
+
+		providerName, target := args[0], "default"
+		if len(args) == 2 {
+			target = args[1]
+		} else if alias := cfg.GetAlias(args[0]); alias != nil {
+			providerName, target = alias.Provider, alias.Target
+		}
+
+		provider, err := providers.GetProviderWithVerbose(providerName, cfg, verbose)
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao obter provider: %v\n", err)
+			return err
+		}
+		incidents, ok := provider.(providers.IncidentProvider)
+		if !ok {
+			err := fmt.Errorf("provider '%s' não gerencia incidentes (use pagerduty ou opsgenie)", provider.Name())
+			red.Fprintf(os.Stderr, "✗ Erro: %v\n", err)
+			return err
+		}
+
+		action := "resolvido"
+		if ack {
+			action = "reconhecido"
+			err = incidents.Acknowledge(target, key, note)
+		} else {
+			err = incidents.Resolve(target, key, note)
+		}
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao atualizar incidente: %v\n", err)
+			return err
+		}
+
+		green := color.New(color.FgHiGreen, color.Bold)
+		green.Printf("✓ Incidente '%s' %s via %s\n", key, action, provider.Name())
+		return nil
+	},
+}
+
+func init() {
+	resolveCmd.Flags().StringP("dedup-key", "k", "", "Chave usada na abertura (PagerDuty dedup_key, Opsgenie alias)")
+	resolveCmd.Flags().String("note", "", "Nota registrada no alerta (Opsgenie)")
+	resolveCmd.Flags().Bool("ack", false, "Apenas reconhece o incidente, sem resolvê-lo")
+	resolveCmd.Flags().BoolP("verbose", "v", false, "Mostra informações detalhadas de debug")
+	rootCmd.AddCommand(resolveCmd)
+}
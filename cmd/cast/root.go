@@ -11,8 +11,7 @@ var rootCmd = &cobra.Command{
 	Use:           "cast",
 	Short:         "CAST - Ferramenta CLI para envio agnóstico de mensagens",
 	SilenceErrors: true, // Erros são tratados pelos comandos com formatação customizada
-	Long: `Ferramenta CLI standalone para envio agnóstico de mensagens (Fire & Forget).
-Suporta múltiplos canais: Telegram, WhatsApp, Email, Google Chat.`,
+	// Long é gerado em init() a partir dos providers registrados
 	Run: func(cmd *cobra.Command, args []string) {
 		ShowRootHelp()
 	},
@@ -49,6 +48,9 @@ func printBanner() {
 }
 
 func init() {
+	// Lista de canais gerada a partir do registro de providers
+	rootCmd.Long = fmt.Sprintf(`Ferramenta CLI standalone para envio agnóstico de mensagens (Fire & Forget).
+Suporta múltiplos canais: %s.`, providerDisplayListHelp())
 	rootCmd.AddCommand(sendCmd)
 	setupPortugueseHelp()
 }
@@ -124,6 +126,38 @@ func setupCustomHelp() {
 	configReloadCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
 		ShowConfigReloadHelp()
 	})
+	// SMS commands
+	smsCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowSMSHelp()
+	})
+	smsStatusCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowSMSStatusHelp()
+	})
+	smsSegmentsCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowSMSSegmentsHelp()
+	})
+
+	// Signal commands
+	signalCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowSignalHelp()
+	})
+	signalLinkCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowSignalLinkHelp()
+	})
+	signalGroupsCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowSignalGroupsHelp()
+	})
+
+	// Preview command
+	previewCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowPreviewHelp()
+	})
+
+	// Resolve command
+	resolveCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		ShowResolveHelp()
+	})
+
 	// Adiciona help para config sources (se existir)
 	if configSourcesCmd := configCmd.Commands(); configSourcesCmd != nil {
 		for _, cmd := range configSourcesCmd {
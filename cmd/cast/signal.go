@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+var signalCmd = &cobra.Command{
+	Use:   "signal",
+	Short: "Utilitários do Signal (vinculação de aparelho e grupos)",
+	Long: `Utilitários do provider Signal (signal-cli-rest-api).
+
+Exemplos:
+  cast signal link
+  cast signal groups`,
+}
+
+var signalLinkCmd = &cobra.Command{
+	Use:          "link",
+	Short:        "Exibe o QR code para vincular a signal-cli-rest-api ao seu celular",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		red := color.New(color.FgRed, color.Bold)
+		deviceName, _ := cmd.Flags().GetString("device-name")
+		output, _ := cmd.Flags().GetString("output")
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao carregar configuração: %v\n", err)
+			return err
+		}
+
+		data, err := providers.FetchSignalLinkQRCode(&cfg.Signal, deviceName)
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao obter QR code: %v\n", err)
+			return err
+		}
+
+		if output != "" {
+			if err := os.WriteFile(output, data, 0644); err != nil {
+				red.Fprintf(os.Stderr, "✗ Erro ao salvar QR code: %v\n", err)
+				return err
+			}
+			color.New(color.FgGreen).Printf("✓ QR code salvo em %s\n", output)
+		} else {
+			qr, err := renderQRCode(data)
+			if err != nil {
+				red.Fprintf(os.Stderr, "✗ Erro ao renderizar QR code: %v (use --output qr.png)\n", err)
+				return err
+			}
+			fmt.Print(qr)
+		}
+
+		fmt.Println()
+		fmt.Println("No celular: Signal → Configurações → Aparelhos vinculados → Vincular novo aparelho.")
+		fmt.Println("O código expira em poucos minutos; depois de vincular, configure o número com:")
+		fmt.Println("  cast gateway update signal --number +5511999998888")
+		return nil
+	},
+}
+
+var signalGroupsCmd = &cobra.Command{
+	Use:          "groups",
+	Short:        "Lista os grupos da conta configurada",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		red := color.New(color.FgRed, color.Bold)
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao carregar configuração: %v\n", err)
+			return err
+		}
+
+		groups, err := providers.ListSignalGroups(&cfg.Signal)
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao listar grupos: %v\n", err)
+			return err
+		}
+		if len(groups) == 0 {
+			color.New(color.FgYellow).Println("Nenhum grupo encontrado")
+			return nil
+		}
+
+		cyan := color.New(color.FgCyan, color.Bold)
+		for _, g := range groups {
+			cyan.Printf("%s", g.Name)
+			fmt.Printf(" (%d membros)\n", len(g.Members))
+			fmt.Printf("  %s\n", g.ID)
+		}
+		fmt.Println()
+		fmt.Println("Use o ID (group.XXX) ou group:Nome como target em 'cast send signal'.")
+		return nil
+	},
+}
+
+// renderQRCode converte o PNG de um QR code em texto com meios-blocos Unicode
+// (duas linhas de módulos por linha de terminal). Os módulos claros são desenhados,
+// o que funciona em terminais de fundo escuro, e uma margem de 2 módulos é adicionada.
+func renderQRCode(data []byte) (string, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("PNG inválido: %w", err)
+	}
+	modules, err := qrModules(img)
+	if err != nil {
+		return "", err
+	}
+
+	const quiet = 2
+	size := len(modules)
+	dark := func(row, col int) bool {
+		row, col = row-quiet, col-quiet
+		return row >= 0 && row < size && col >= 0 && col < size && modules[row][col]
+	}
+
+	var sb strings.Builder
+	total := size + 2*quiet
+	for row := 0; row < total; row += 2 {
+		for col := 0; col < total; col++ {
+			top, bottom := !dark(row, col), !dark(row+1, col) && row+1 < total
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// qrModules amostra a imagem e retorna a matriz de módulos (true = escuro).
+// O tamanho do módulo é obtido do padrão localizador superior esquerdo, que tem 7 módulos de largura.
+func qrModules(img image.Image) ([][]bool, error) {
+	bounds := img.Bounds()
+	isDark := func(x, y int) bool {
+		r, g, b, _ := img.At(x, y).RGBA()
+		return (r+g+b)/3 < 0x8000
+	}
+
+	minX, minY, maxX, maxY := bounds.Max.X, bounds.Max.Y, -1, -1
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if isDark(x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return nil, fmt.Errorf("nenhum QR code encontrado na imagem")
+	}
+
+	run := 0
+	for x := minX; x <= maxX && isDark(x, minY); x++ {
+		run++
+	}
+	moduleSize := float64(run) / 7
+	if moduleSize < 1 {
+		return nil, fmt.Errorf("QR code com resolução insuficiente")
+	}
+
+	size := int(math.Round(float64(maxX-minX+1) / moduleSize))
+	if size < 21 {
+		return nil, fmt.Errorf("QR code não reconhecido (%d módulos)", size)
+	}
+
+	modules := make([][]bool, size)
+	for row := range modules {
+		modules[row] = make([]bool, size)
+		for col := range modules[row] {
+			x := minX + int((float64(col)+0.5)*moduleSize)
+			y := minY + int((float64(row)+0.5)*moduleSize)
+			modules[row][col] = isDark(x, y)
+		}
+	}
+	return modules, nil
+}
+
+func init() {
+	signalLinkCmd.Flags().String("device-name", "cast", "Nome do aparelho exibido no Signal")
+	signalLinkCmd.Flags().StringP("output", "o", "", "Salva o QR code em um arquivo PNG em vez de exibi-lo")
+
+	signalCmd.AddCommand(signalLinkCmd)
+	signalCmd.AddCommand(signalGroupsCmd)
+	rootCmd.AddCommand(signalCmd)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// buildQRImage desenha uma matriz de módulos como PNG (módulo de 5px e margem branca de 12px).
+func buildQRImage(t *testing.T, modules [][]bool) []byte {
+	const moduleSize, margin = 5, 12
+	size := len(modules)*moduleSize + 2*margin
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	for row := range modules {
+		for col := range modules[row] {
+			if !modules[row][col] {
+				continue
+			}
+			for dy := 0; dy < moduleSize; dy++ {
+				for dx := 0; dx < moduleSize; dx++ {
+					img.SetGray(margin+col*moduleSize+dx, margin+row*moduleSize+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Erro ao gerar PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// fakeQRModules gera uma matriz 21x21 com os três padrões localizadores e dados arbitrários.
+func fakeQRModules() [][]bool {
+	const size = 21
+	modules := make([][]bool, size)
+	for row := range modules {
+		modules[row] = make([]bool, size)
+	}
+	finder := func(top, left int) {
+		for r := 0; r < 7; r++ {
+			for c := 0; c < 7; c++ {
+				ring := r == 0 || r == 6 || c == 0 || c == 6
+				center := r >= 2 && r <= 4 && c >= 2 && c <= 4
+				modules[top+r][left+c] = ring || center
+			}
+		}
+	}
+	finder(0, 0)
+	finder(0, size-7)
+	finder(size-7, 0)
+	for row := 8; row < size; row++ {
+		for col := 8; col < size; col++ {
+			modules[row][col] = (row*col)%3 == 0
+		}
+	}
+	return modules
+}
+
+func TestQRModules_RoundTrip(t *testing.T) {
+	expected := fakeQRModules()
+	img, err := png.Decode(bytes.NewReader(buildQRImage(t, expected)))
+	if err != nil {
+		t.Fatalf("Erro ao decodificar PNG: %v", err)
+	}
+
+	got, err := qrModules(img)
+	if err != nil {
+		t.Fatalf("Erro ao amostrar módulos: %v", err)
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Esperado %d módulos, obtido %d", len(expected), len(got))
+	}
+	for row := range expected {
+		for col := range expected[row] {
+			if got[row][col] != expected[row][col] {
+				t.Fatalf("Módulo (%d,%d) divergente", row, col)
+			}
+		}
+	}
+}
+
+func TestRenderQRCode(t *testing.T) {
+	out, err := renderQRCode(buildQRImage(t, fakeQRModules()))
+	if err != nil {
+		t.Fatalf("Erro ao renderizar: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	// 21 módulos + margem de 2 de cada lado = 25 colunas e 13 linhas (2 módulos por linha)
+	if len(lines) != 13 {
+		t.Errorf("Esperado 13 linhas, obtido %d", len(lines))
+	}
+	for i, line := range lines {
+		if n := len([]rune(line)); n != 25 {
+			t.Errorf("Linha %d com %d colunas, esperado 25", i, n)
+		}
+	}
+	// Primeira linha: margem clara sobre margem clara
+	if !strings.HasPrefix(lines[0], "█████") {
+		t.Errorf("Margem superior inesperada: %q", lines[0])
+	}
+
+	if _, err := renderQRCode([]byte("não é png")); err == nil {
+		t.Error("Esperado erro para conteúdo que não é PNG")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+	"github.com/eduardoalcantara/cast/internal/providers"
+)
+
+var smsCmd = &cobra.Command{
+	Use:   "sms",
+	Short: "Utilitários de SMS (status de entrega e estimativa de segmentos)",
+	Long: `Utilitários do provider SMS (API Messages da Twilio ou gateway compatível).
+
+Exemplos:
+  cast sms status SM0123456789abcdef0123456789abcdef
+  cast sms segments "Seu código de acesso é 123456"`,
+}
+
+var smsStatusCmd = &cobra.Command{
+	Use:          "status <sid>",
+	Short:        "Consulta o status de entrega de uma mensagem",
+	SilenceUsage: true,
+	Long: `Consulta o status de entrega de uma mensagem pelo SID exibido em 'cast send sms ... --verbose'.
+
+Status possíveis: queued, sending, sent, delivered, undelivered, failed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		red := color.New(color.FgRed, color.Bold)
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao carregar configuração: %v\n", err)
+			return err
+		}
+
+		status, err := providers.LookupSMSStatus(&cfg.SMS, args[0])
+		if err != nil {
+			red.Fprintf(os.Stderr, "✗ Erro ao consultar status: %v\n", err)
+			return err
+		}
+
+		statusColor := color.New(color.FgYellow, color.Bold)
+		switch status.Status {
+		case "delivered", "sent":
+			statusColor = color.New(color.FgGreen, color.Bold)
+		case "failed", "undelivered":
+			statusColor = color.New(color.FgRed, color.Bold)
+		}
+
+		fmt.Printf("SID:        %s\n", status.SID)
+		fmt.Print("Status:     ")
+		statusColor.Println(status.Status)
+		fmt.Printf("De/Para:    %s → %s\n", status.From, status.To)
+		if status.NumSegments != "" {
+			fmt.Printf("Segmentos:  %s\n", status.NumSegments)
+		}
+		if status.DateSent != "" {
+			fmt.Printf("Enviada em: %s\n", status.DateSent)
+		}
+		if status.Price != "" {
+			fmt.Printf("Custo:      %s %s\n", strings.TrimPrefix(status.Price, "-"), status.PriceUnit)
+		}
+		if status.ErrorCode != nil {
+			red.Printf("Erro:       %d %s\n", *status.ErrorCode, status.ErrorMessage)
+		}
+		return nil
+	},
+}
+
+var smsSegmentsCmd = &cobra.Command{
+	Use:   "segments <mensagem>",
+	Short: "Estima a codificação e o número de segmentos de uma mensagem",
+	Long: `Estima a codificação (GSM-7 ou UCS-2) e quantos segmentos serão cobrados por destinatário.
+
+Caracteres fora do alfabeto GSM-7 (ex: "ã", "õ", "ê", emojis) forçam UCS-2,
+reduzindo o segmento de 160 para 70 caracteres.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seg := providers.EstimateSMSSegments(args[0])
+		fmt.Printf("Codificação: %s\n", seg.Encoding)
+		fmt.Printf("Tamanho:     %d (de %d por segmento)\n", seg.Units, seg.PerSegment)
+		fmt.Printf("Segmentos:   %d\n", seg.Segments)
+		if seg.Encoding == "UCS-2" {
+			yellow := color.New(color.FgYellow)
+			yellow.Println("⚠ A mensagem contém caracteres fora do alfabeto GSM-7; remova acentos como ã/õ/ê para usar 160 caracteres por segmento")
+		}
+	},
+}
+
+func init() {
+	smsCmd.AddCommand(smsStatusCmd)
+	smsCmd.AddCommand(smsSegmentsCmd)
+	rootCmd.AddCommand(smsCmd)
+}
@@ -17,6 +17,24 @@ type Config struct {
 	Email     EmailConfig                 `mapstructure:"email" yaml:"email" json:"email"`
 	GoogleChat GoogleChatConfig           `mapstructure:"google_chat" yaml:"google_chat" json:"google_chat"`
 	WAHA      WAHAConfig                  `mapstructure:"waha" yaml:"waha" json:"waha"`
+	Slack     SlackConfig                 `mapstructure:"slack" yaml:"slack" json:"slack"`
+	Teams     TeamsConfig                 `mapstructure:"teams" yaml:"teams" json:"teams"`
+	Discord   DiscordConfig               `mapstructure:"discord" yaml:"discord" json:"discord"`
+	Webhook   WebhookConfig               `mapstructure:"webhook" yaml:"webhook" json:"webhook"`
+	Ntfy      NtfyConfig                  `mapstructure:"ntfy" yaml:"ntfy" json:"ntfy"`
+	Gotify    GotifyConfig                `mapstructure:"gotify" yaml:"gotify" json:"gotify"`
+	Matrix    MatrixConfig                `mapstructure:"matrix" yaml:"matrix" json:"matrix"`
+	Mattermost MattermostConfig           `mapstructure:"mattermost" yaml:"mattermost" json:"mattermost"`
+	RocketChat RocketChatConfig           `mapstructure:"rocketchat" yaml:"rocketchat" json:"rocketchat"`
+	SMS       SMSConfig                   `mapstructure:"sms" yaml:"sms" json:"sms"`
+	Signal    SignalConfig                `mapstructure:"signal" yaml:"signal" json:"signal"`
+	PagerDuty PagerDutyConfig             `mapstructure:"pagerduty" yaml:"pagerduty" json:"pagerduty"`
+	Opsgenie  OpsgenieConfig              `mapstructure:"opsgenie" yaml:"opsgenie" json:"opsgenie"`
+	MQTT      MQTTConfig                  `mapstructure:"mqtt" yaml:"mqtt" json:"mqtt"`
+	NATS      NATSConfig                  `mapstructure:"nats" yaml:"nats" json:"nats"`
+	Syslog    SyslogConfig                `mapstructure:"syslog" yaml:"syslog" json:"syslog"`
+	Journald  JournaldConfig              `mapstructure:"journald" yaml:"journald" json:"journald"`
+	File      FileConfig                  `mapstructure:"file" yaml:"file" json:"file"`
 	Aliases   map[string]AliasConfig      `mapstructure:"aliases" yaml:"aliases" json:"aliases"`
 }
 
@@ -49,6 +67,24 @@ type EmailConfig struct {
 	UseTLS    bool   `mapstructure:"use_tls" yaml:"use_tls" json:"use_tls"`
 	UseSSL    bool   `mapstructure:"use_ssl" yaml:"use_ssl" json:"use_ssl"`
 	Timeout   int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+	// Limite de mensagens por conexão SMTP nos envios em lote (0 = sem limite)
+	SMTPMaxMessages int `mapstructure:"smtp_max_messages" yaml:"smtp_max_messages,omitempty" json:"smtp_max_messages,omitempty"`
+
+	// DKIM: assinatura das mensagens enviadas (opcional). A chave privada pode ser o caminho
+	// de um arquivo PEM, o próprio PEM ou uma referência ${env:NOME} / ${file:/caminho}.
+	DKIMDomain     string   `mapstructure:"dkim_domain" yaml:"dkim_domain,omitempty" json:"dkim_domain,omitempty"`
+	DKIMSelector   string   `mapstructure:"dkim_selector" yaml:"dkim_selector,omitempty" json:"dkim_selector,omitempty"`
+	DKIMPrivateKey string   `mapstructure:"dkim_private_key" yaml:"dkim_private_key,omitempty" json:"dkim_private_key,omitempty"`
+	DKIMHeaders    []string `mapstructure:"dkim_headers" yaml:"dkim_headers,omitempty" json:"dkim_headers,omitempty"` // Vazio = headers padrão
+
+	// OAuth2 (XOAUTH2): com oauth2_token_url configurado, substitui as senhas no SMTP e no IMAP.
+	// Com refresh token usa o fluxo refresh_token; sem ele, client_credentials. Segredos aceitam
+	// referências ${env:NOME} / ${file:/caminho}.
+	OAuth2TokenURL     string `mapstructure:"oauth2_token_url" yaml:"oauth2_token_url,omitempty" json:"oauth2_token_url,omitempty"`
+	OAuth2ClientID     string `mapstructure:"oauth2_client_id" yaml:"oauth2_client_id,omitempty" json:"oauth2_client_id,omitempty"`
+	OAuth2ClientSecret string `mapstructure:"oauth2_client_secret" yaml:"oauth2_client_secret,omitempty" json:"oauth2_client_secret,omitempty"`
+	OAuth2RefreshToken string `mapstructure:"oauth2_refresh_token" yaml:"oauth2_refresh_token,omitempty" json:"oauth2_refresh_token,omitempty"`
+	OAuth2Scope        string `mapstructure:"oauth2_scope" yaml:"oauth2_scope,omitempty" json:"oauth2_scope,omitempty"`
 
 	// IMAP: usado apenas se wait-for-response estiver ativo
 	IMAPHost     string `mapstructure:"imap_host" yaml:"imap_host" json:"imap_host"`
@@ -82,11 +118,202 @@ type WAHAConfig struct {
 	Timeout int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
 }
 
+// SlackConfig contém as configurações do Slack (Incoming Webhook e Web API).
+type SlackConfig struct {
+	WebhookURL     string `mapstructure:"webhook_url" yaml:"webhook_url" json:"webhook_url"`
+	BotToken       string `mapstructure:"bot_token" yaml:"bot_token" json:"bot_token"`
+	DefaultChannel string `mapstructure:"default_channel" yaml:"default_channel" json:"default_channel"`
+	Username       string `mapstructure:"username" yaml:"username" json:"username"`
+	IconEmoji      string `mapstructure:"icon_emoji" yaml:"icon_emoji" json:"icon_emoji"`
+	APIURL         string `mapstructure:"api_url" yaml:"api_url" json:"api_url"`
+	Timeout        int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// TeamsConfig contém as configurações do Microsoft Teams (Incoming Webhook / Workflows).
+type TeamsConfig struct {
+	WebhookURL string `mapstructure:"webhook_url" yaml:"webhook_url" json:"webhook_url"`
+	Timeout    int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// DiscordConfig contém as configurações do Discord (Webhooks).
+type DiscordConfig struct {
+	WebhookURL string `mapstructure:"webhook_url" yaml:"webhook_url" json:"webhook_url"`
+	Username   string `mapstructure:"username" yaml:"username" json:"username"`
+	AvatarURL  string `mapstructure:"avatar_url" yaml:"avatar_url" json:"avatar_url"`
+	Timeout    int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// WebhookConfig contém as configurações do provider genérico de webhooks HTTP.
+type WebhookConfig struct {
+	Timeout   int                        `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+	Endpoints map[string]WebhookEndpoint `mapstructure:"endpoints" yaml:"endpoints" json:"endpoints"`
+}
+
+// WebhookEndpoint define um endpoint HTTP nomeado (ex: cast send webhook deploy "msg").
+// Valores de headers e hmac_secret aceitam referências a segredos: ${env:NOME} e ${file:/caminho}.
+type WebhookEndpoint struct {
+	URL            string            `mapstructure:"url" yaml:"url" json:"url"`
+	Method         string            `mapstructure:"method" yaml:"method" json:"method"`
+	Headers        map[string]string `mapstructure:"headers" yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body           string            `mapstructure:"body" yaml:"body,omitempty" json:"body,omitempty"`       // Go template
+	Format         string            `mapstructure:"format" yaml:"format,omitempty" json:"format,omitempty"` // json, form ou text
+	HMACSecret     string            `mapstructure:"hmac_secret" yaml:"hmac_secret,omitempty" json:"hmac_secret,omitempty"`
+	HMACHeader     string            `mapstructure:"hmac_header" yaml:"hmac_header,omitempty" json:"hmac_header,omitempty"`
+	HMACAlgorithm  string            `mapstructure:"hmac_algorithm" yaml:"hmac_algorithm,omitempty" json:"hmac_algorithm,omitempty"` // sha256 (padrão), sha1 ou sha512
+	ExpectedStatus []int             `mapstructure:"expected_status" yaml:"expected_status,omitempty" json:"expected_status,omitempty"`
+	Timeout        int               `mapstructure:"timeout" yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// NtfyConfig contém as configurações do ntfy (ntfy.sh ou servidor próprio).
+type NtfyConfig struct {
+	ServerURL    string `mapstructure:"server_url" yaml:"server_url" json:"server_url"`
+	DefaultTopic string `mapstructure:"default_topic" yaml:"default_topic" json:"default_topic"`
+	Token        string `mapstructure:"token" yaml:"token" json:"token"` // Access token (tk_...) ou usuário:senha
+	Timeout      int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// GotifyConfig contém as configurações do Gotify.
+type GotifyConfig struct {
+	ServerURL       string `mapstructure:"server_url" yaml:"server_url" json:"server_url"`
+	AppToken        string `mapstructure:"app_token" yaml:"app_token" json:"app_token"`
+	DefaultPriority int    `mapstructure:"default_priority" yaml:"default_priority" json:"default_priority"`
+	Markdown        bool   `mapstructure:"markdown" yaml:"markdown" json:"markdown"`
+	Timeout         int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// MatrixConfig contém as configurações do Matrix (Client-Server API).
+type MatrixConfig struct {
+	HomeserverURL string `mapstructure:"homeserver_url" yaml:"homeserver_url" json:"homeserver_url"`
+	AccessToken   string `mapstructure:"access_token" yaml:"access_token" json:"access_token"`
+	DefaultRoom   string `mapstructure:"default_room" yaml:"default_room" json:"default_room"` // Room ID (!abc:servidor) ou alias (#sala:servidor)
+	Timeout       int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// MattermostConfig contém as configurações do Mattermost (Incoming Webhooks e REST API v4).
+type MattermostConfig struct {
+	WebhookURL     string `mapstructure:"webhook_url" yaml:"webhook_url" json:"webhook_url"`
+	ServerURL      string `mapstructure:"server_url" yaml:"server_url" json:"server_url"`
+	AccessToken    string `mapstructure:"access_token" yaml:"access_token" json:"access_token"` // Bot ou personal access token
+	DefaultChannel string `mapstructure:"default_channel" yaml:"default_channel" json:"default_channel"` // ID do canal ou equipe/canal
+	Username       string `mapstructure:"username" yaml:"username" json:"username"`
+	IconURL        string `mapstructure:"icon_url" yaml:"icon_url" json:"icon_url"`
+	Timeout        int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// RocketChatConfig contém as configurações do Rocket.Chat (Incoming Webhooks e REST API).
+type RocketChatConfig struct {
+	WebhookURL     string `mapstructure:"webhook_url" yaml:"webhook_url" json:"webhook_url"`
+	ServerURL      string `mapstructure:"server_url" yaml:"server_url" json:"server_url"`
+	UserID         string `mapstructure:"user_id" yaml:"user_id" json:"user_id"`
+	AuthToken      string `mapstructure:"auth_token" yaml:"auth_token" json:"auth_token"` // Personal access token
+	DefaultChannel string `mapstructure:"default_channel" yaml:"default_channel" json:"default_channel"` // #canal ou @usuario
+	Username       string `mapstructure:"username" yaml:"username" json:"username"`
+	AvatarURL      string `mapstructure:"avatar_url" yaml:"avatar_url" json:"avatar_url"`
+	Timeout        int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// SMSConfig contém as configurações de SMS (API Messages da Twilio ou gateway compatível).
+type SMSConfig struct {
+	BaseURL             string `mapstructure:"base_url" yaml:"base_url" json:"base_url"` // Padrão: https://api.twilio.com
+	AccountSID          string `mapstructure:"account_sid" yaml:"account_sid" json:"account_sid"`
+	AuthToken           string `mapstructure:"auth_token" yaml:"auth_token" json:"auth_token"`
+	FromNumber          string `mapstructure:"from_number" yaml:"from_number" json:"from_number"` // E.164
+	MessagingServiceSID string `mapstructure:"messaging_service_sid" yaml:"messaging_service_sid" json:"messaging_service_sid"` // Alternativa ao from_number
+	DefaultTo           string `mapstructure:"default_to" yaml:"default_to" json:"default_to"`
+	Timeout             int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// SignalConfig contém as configurações do Signal (via signal-cli-rest-api).
+type SignalConfig struct {
+	APIURL           string `mapstructure:"api_url" yaml:"api_url" json:"api_url"` // Ex: http://localhost:8080
+	Number           string `mapstructure:"number" yaml:"number" json:"number"` // Conta registrada/vinculada (E.164)
+	DefaultRecipient string `mapstructure:"default_recipient" yaml:"default_recipient" json:"default_recipient"` // Número ou group.ID
+	Timeout          int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// PagerDutyConfig contém as configurações do PagerDuty (Events API v2).
+type PagerDutyConfig struct {
+	EventsURL       string            `mapstructure:"events_url" yaml:"events_url" json:"events_url"` // Padrão: https://events.pagerduty.com
+	RoutingKey      string            `mapstructure:"routing_key" yaml:"routing_key" json:"routing_key"` // Integration key do serviço padrão
+	Services        map[string]string `mapstructure:"services" yaml:"services,omitempty" json:"services,omitempty"` // Nome do serviço -> routing key
+	DefaultSeverity string            `mapstructure:"default_severity" yaml:"default_severity" json:"default_severity"` // critical, error, warning ou info
+	Source          string            `mapstructure:"source" yaml:"source" json:"source"` // Padrão: hostname da máquina
+	Timeout         int               `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// OpsgenieConfig contém as configurações do Opsgenie (Alert API v2).
+type OpsgenieConfig struct {
+	APIURL            string `mapstructure:"api_url" yaml:"api_url" json:"api_url"` // Padrão: https://api.opsgenie.com (EU: https://api.eu.opsgenie.com)
+	APIKey            string `mapstructure:"api_key" yaml:"api_key" json:"api_key"` // Chave de uma integração API
+	DefaultPriority   string `mapstructure:"default_priority" yaml:"default_priority" json:"default_priority"` // P1 a P5
+	DefaultResponders string `mapstructure:"default_responders" yaml:"default_responders" json:"default_responders"` // Ex: team:SRE,user:ana@exemplo.com
+	Source            string `mapstructure:"source" yaml:"source" json:"source"` // Padrão: hostname da máquina
+	Timeout           int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// MQTTConfig contém as configurações do MQTT (publicação em broker MQTT 3.1.1).
+type MQTTConfig struct {
+	BrokerURL    string `mapstructure:"broker_url" yaml:"broker_url" json:"broker_url"` // mqtt://host:1883 ou mqtts://host:8883
+	ClientID     string `mapstructure:"client_id" yaml:"client_id" json:"client_id"` // Padrão: cast-<aleatório>
+	Username     string `mapstructure:"username" yaml:"username" json:"username"`
+	Password     string `mapstructure:"password" yaml:"password" json:"password"`
+	DefaultTopic string `mapstructure:"default_topic" yaml:"default_topic" json:"default_topic"`
+	QoS          int    `mapstructure:"qos" yaml:"qos" json:"qos"` // 0, 1 ou 2
+	Retain       bool   `mapstructure:"retain" yaml:"retain" json:"retain"`
+	CAFile       string `mapstructure:"ca_file" yaml:"ca_file" json:"ca_file"` // CA adicional para mqtts://
+	TLSInsecure  bool   `mapstructure:"tls_insecure" yaml:"tls_insecure" json:"tls_insecure"` // Não verifica o certificado (apenas testes)
+	Timeout      int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// NATSConfig contém as configurações do NATS (core e JetStream).
+type NATSConfig struct {
+	ServerURL      string `mapstructure:"server_url" yaml:"server_url" json:"server_url"` // nats://host:4222 ou tls://host:4222
+	Token          string `mapstructure:"token" yaml:"token" json:"token"`
+	Username       string `mapstructure:"username" yaml:"username" json:"username"`
+	Password       string `mapstructure:"password" yaml:"password" json:"password"`
+	DefaultSubject string `mapstructure:"default_subject" yaml:"default_subject" json:"default_subject"`
+	JetStream      bool   `mapstructure:"jetstream" yaml:"jetstream" json:"jetstream"` // Aguarda confirmação do stream
+	CAFile         string `mapstructure:"ca_file" yaml:"ca_file" json:"ca_file"`
+	TLSInsecure    bool   `mapstructure:"tls_insecure" yaml:"tls_insecure" json:"tls_insecure"`
+	Timeout        int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// SyslogConfig contém as configurações do syslog remoto ou local (RFC 5424).
+type SyslogConfig struct {
+	Address         string `mapstructure:"address" yaml:"address" json:"address"` // udp://host:514, tcp://host:514, tls://host:6514 ou unix:///dev/log
+	Facility        string `mapstructure:"facility" yaml:"facility" json:"facility"` // user, daemon, local0..local7...
+	DefaultSeverity string `mapstructure:"default_severity" yaml:"default_severity" json:"default_severity"`
+	AppName         string `mapstructure:"app_name" yaml:"app_name" json:"app_name"`
+	Hostname        string `mapstructure:"hostname" yaml:"hostname" json:"hostname"` // Padrão: hostname da máquina
+	CAFile          string `mapstructure:"ca_file" yaml:"ca_file" json:"ca_file"`
+	TLSInsecure     bool   `mapstructure:"tls_insecure" yaml:"tls_insecure" json:"tls_insecure"`
+	Timeout         int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// JournaldConfig contém as configurações do systemd-journald.
+type JournaldConfig struct {
+	SocketPath      string `mapstructure:"socket_path" yaml:"socket_path" json:"socket_path"`
+	Identifier      string `mapstructure:"identifier" yaml:"identifier" json:"identifier"` // SYSLOG_IDENTIFIER
+	Facility        string `mapstructure:"facility" yaml:"facility" json:"facility"`
+	DefaultSeverity string `mapstructure:"default_severity" yaml:"default_severity" json:"default_severity"`
+	Timeout         int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// FileConfig contém as configurações do arquivo de saída (somente acréscimo).
+type FileConfig struct {
+	Path    string `mapstructure:"path" yaml:"path" json:"path"`
+	Format  string `mapstructure:"format" yaml:"format" json:"format"` // jsonl ou text
+	Sync    bool   `mapstructure:"sync" yaml:"sync" json:"sync"`       // fsync após cada gravação
+	Timeout int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
 // AliasConfig representa um alias para facilitar o uso do CLI.
 type AliasConfig struct {
 	Provider string `mapstructure:"provider" yaml:"provider" json:"provider"`
 	Target   string `mapstructure:"target" yaml:"target" json:"target"`
 	Name     string `mapstructure:"name" yaml:"name" json:"name"`
+	Cc       string `mapstructure:"cc" yaml:"cc,omitempty" json:"cc,omitempty"`    // Cópia padrão (apenas email)
+	Bcc      string `mapstructure:"bcc" yaml:"bcc,omitempty" json:"bcc,omitempty"` // Cópia oculta padrão (apenas email)
 }
 
 // Load inicializa e carrega a configuração seguindo a ordem de precedência:
@@ -127,6 +354,17 @@ func Load() error {
 	viper.BindEnv("email.use_tls")
 	viper.BindEnv("email.use_ssl")
 	viper.BindEnv("email.timeout")
+	viper.BindEnv("email.smtp_max_messages")
+	// DKIM
+	viper.BindEnv("email.dkim_domain")
+	viper.BindEnv("email.dkim_selector")
+	viper.BindEnv("email.dkim_private_key")
+	// OAuth2
+	viper.BindEnv("email.oauth2_token_url")
+	viper.BindEnv("email.oauth2_client_id")
+	viper.BindEnv("email.oauth2_client_secret")
+	viper.BindEnv("email.oauth2_refresh_token")
+	viper.BindEnv("email.oauth2_scope")
 	// IMAP
 	viper.BindEnv("email.imap_host")
 	viper.BindEnv("email.imap_port")
@@ -153,6 +391,130 @@ func Load() error {
 	viper.BindEnv("waha.api_key")
 	viper.BindEnv("waha.timeout")
 
+	// Slack
+	viper.BindEnv("slack.webhook_url")
+	viper.BindEnv("slack.bot_token")
+	viper.BindEnv("slack.default_channel")
+	viper.BindEnv("slack.username")
+	viper.BindEnv("slack.icon_emoji")
+	viper.BindEnv("slack.api_url")
+	viper.BindEnv("slack.timeout")
+
+	// Microsoft Teams
+	viper.BindEnv("teams.webhook_url")
+	viper.BindEnv("teams.timeout")
+
+	// Discord
+	viper.BindEnv("discord.webhook_url")
+	viper.BindEnv("discord.username")
+	viper.BindEnv("discord.avatar_url")
+	viper.BindEnv("discord.timeout")
+
+	// Webhook HTTP (endpoints são configurados apenas no arquivo)
+	viper.BindEnv("webhook.timeout")
+
+	// ntfy
+	viper.BindEnv("ntfy.server_url")
+	viper.BindEnv("ntfy.default_topic")
+	viper.BindEnv("ntfy.token")
+	viper.BindEnv("ntfy.timeout")
+
+	// Gotify
+	viper.BindEnv("gotify.server_url")
+	viper.BindEnv("gotify.app_token")
+	viper.BindEnv("gotify.default_priority")
+	viper.BindEnv("gotify.markdown")
+	viper.BindEnv("gotify.timeout")
+
+	// Matrix
+	viper.BindEnv("matrix.homeserver_url")
+	viper.BindEnv("matrix.access_token")
+	viper.BindEnv("matrix.default_room")
+	viper.BindEnv("matrix.timeout")
+
+	// Mattermost
+	viper.BindEnv("mattermost.webhook_url")
+	viper.BindEnv("mattermost.server_url")
+	viper.BindEnv("mattermost.access_token")
+	viper.BindEnv("mattermost.default_channel")
+	viper.BindEnv("mattermost.username")
+	viper.BindEnv("mattermost.icon_url")
+	viper.BindEnv("mattermost.timeout")
+
+	// Rocket.Chat
+	viper.BindEnv("rocketchat.webhook_url")
+	viper.BindEnv("rocketchat.server_url")
+	viper.BindEnv("rocketchat.user_id")
+	viper.BindEnv("rocketchat.auth_token")
+	viper.BindEnv("rocketchat.default_channel")
+	viper.BindEnv("rocketchat.username")
+	viper.BindEnv("rocketchat.avatar_url")
+	viper.BindEnv("rocketchat.timeout")
+
+	// SMS
+	viper.BindEnv("sms.base_url")
+	viper.BindEnv("sms.account_sid")
+	viper.BindEnv("sms.auth_token")
+	viper.BindEnv("sms.from_number")
+	viper.BindEnv("sms.messaging_service_sid")
+	viper.BindEnv("sms.default_to")
+	viper.BindEnv("sms.timeout")
+
+	// Signal
+	viper.BindEnv("signal.api_url")
+	viper.BindEnv("signal.number")
+	viper.BindEnv("signal.default_recipient")
+	viper.BindEnv("signal.timeout")
+
+	// PagerDuty
+	viper.BindEnv("pagerduty.events_url")
+	viper.BindEnv("pagerduty.routing_key")
+	viper.BindEnv("pagerduty.default_severity")
+	viper.BindEnv("pagerduty.source")
+	viper.BindEnv("pagerduty.timeout")
+
+	// Opsgenie
+	viper.BindEnv("opsgenie.api_url")
+	viper.BindEnv("opsgenie.api_key")
+	viper.BindEnv("opsgenie.default_priority")
+	viper.BindEnv("opsgenie.default_responders")
+	viper.BindEnv("opsgenie.source")
+	viper.BindEnv("opsgenie.timeout")
+
+	// MQTT
+	viper.BindEnv("mqtt.broker_url")
+	viper.BindEnv("mqtt.client_id")
+	viper.BindEnv("mqtt.username")
+	viper.BindEnv("mqtt.password")
+	viper.BindEnv("mqtt.default_topic")
+	viper.BindEnv("mqtt.qos")
+	viper.BindEnv("mqtt.timeout")
+
+	// NATS
+	viper.BindEnv("nats.server_url")
+	viper.BindEnv("nats.token")
+	viper.BindEnv("nats.username")
+	viper.BindEnv("nats.password")
+	viper.BindEnv("nats.default_subject")
+	viper.BindEnv("nats.timeout")
+
+	// Syslog
+	viper.BindEnv("syslog.address")
+	viper.BindEnv("syslog.facility")
+	viper.BindEnv("syslog.default_severity")
+	viper.BindEnv("syslog.app_name")
+	viper.BindEnv("syslog.hostname")
+	viper.BindEnv("syslog.timeout")
+
+	// Journald
+	viper.BindEnv("journald.socket_path")
+	viper.BindEnv("journald.identifier")
+	viper.BindEnv("journald.default_severity")
+
+	// File
+	viper.BindEnv("file.path")
+	viper.BindEnv("file.format")
+
 	// Busca arquivo de configuração de forma transparente:
 	// 1. Primeiro procura no diretório atual (onde o usuário está executando)
 	// 2. Se não encontrar, procura no diretório do executável (fallback)
@@ -295,6 +657,35 @@ func applyEnvOverrides(cfg *Config) {
 	if envVal := viper.GetInt("email.timeout"); envVal > 0 {
 		cfg.Email.Timeout = envVal
 	}
+	if envVal := viper.GetInt("email.smtp_max_messages"); envVal > 0 {
+		cfg.Email.SMTPMaxMessages = envVal
+	}
+	// DKIM
+	if envVal := viper.GetString("email.dkim_domain"); envVal != "" {
+		cfg.Email.DKIMDomain = envVal
+	}
+	if envVal := viper.GetString("email.dkim_selector"); envVal != "" {
+		cfg.Email.DKIMSelector = envVal
+	}
+	if envVal := viper.GetString("email.dkim_private_key"); envVal != "" {
+		cfg.Email.DKIMPrivateKey = envVal
+	}
+	// OAuth2
+	if envVal := viper.GetString("email.oauth2_token_url"); envVal != "" {
+		cfg.Email.OAuth2TokenURL = envVal
+	}
+	if envVal := viper.GetString("email.oauth2_client_id"); envVal != "" {
+		cfg.Email.OAuth2ClientID = envVal
+	}
+	if envVal := viper.GetString("email.oauth2_client_secret"); envVal != "" {
+		cfg.Email.OAuth2ClientSecret = envVal
+	}
+	if envVal := viper.GetString("email.oauth2_refresh_token"); envVal != "" {
+		cfg.Email.OAuth2RefreshToken = envVal
+	}
+	if envVal := viper.GetString("email.oauth2_scope"); envVal != "" {
+		cfg.Email.OAuth2Scope = envVal
+	}
 	// IMAP
 	if envVal := viper.GetString("email.imap_host"); envVal != "" {
 		cfg.Email.IMAPHost = envVal
@@ -358,6 +749,321 @@ func applyEnvOverrides(cfg *Config) {
 	if envVal := viper.GetInt("waha.timeout"); envVal > 0 {
 		cfg.WAHA.Timeout = envVal
 	}
+
+	// Slack
+	if envVal := viper.GetString("slack.webhook_url"); envVal != "" {
+		cfg.Slack.WebhookURL = envVal
+	}
+	if envVal := viper.GetString("slack.bot_token"); envVal != "" {
+		cfg.Slack.BotToken = envVal
+	}
+	if envVal := viper.GetString("slack.default_channel"); envVal != "" {
+		cfg.Slack.DefaultChannel = envVal
+	}
+	if envVal := viper.GetString("slack.username"); envVal != "" {
+		cfg.Slack.Username = envVal
+	}
+	if envVal := viper.GetString("slack.icon_emoji"); envVal != "" {
+		cfg.Slack.IconEmoji = envVal
+	}
+	if envVal := viper.GetString("slack.api_url"); envVal != "" {
+		cfg.Slack.APIURL = envVal
+	}
+	if envVal := viper.GetInt("slack.timeout"); envVal > 0 {
+		cfg.Slack.Timeout = envVal
+	}
+
+	// Microsoft Teams
+	if envVal := viper.GetString("teams.webhook_url"); envVal != "" {
+		cfg.Teams.WebhookURL = envVal
+	}
+	if envVal := viper.GetInt("teams.timeout"); envVal > 0 {
+		cfg.Teams.Timeout = envVal
+	}
+
+	// Discord
+	if envVal := viper.GetString("discord.webhook_url"); envVal != "" {
+		cfg.Discord.WebhookURL = envVal
+	}
+	if envVal := viper.GetString("discord.username"); envVal != "" {
+		cfg.Discord.Username = envVal
+	}
+	if envVal := viper.GetString("discord.avatar_url"); envVal != "" {
+		cfg.Discord.AvatarURL = envVal
+	}
+	if envVal := viper.GetInt("discord.timeout"); envVal > 0 {
+		cfg.Discord.Timeout = envVal
+	}
+
+	// Webhook HTTP
+	if envVal := viper.GetInt("webhook.timeout"); envVal > 0 {
+		cfg.Webhook.Timeout = envVal
+	}
+
+	// ntfy
+	if envVal := viper.GetString("ntfy.server_url"); envVal != "" {
+		cfg.Ntfy.ServerURL = envVal
+	}
+	if envVal := viper.GetString("ntfy.default_topic"); envVal != "" {
+		cfg.Ntfy.DefaultTopic = envVal
+	}
+	if envVal := viper.GetString("ntfy.token"); envVal != "" {
+		cfg.Ntfy.Token = envVal
+	}
+	if envVal := viper.GetInt("ntfy.timeout"); envVal > 0 {
+		cfg.Ntfy.Timeout = envVal
+	}
+
+	// Gotify
+	if envVal := viper.GetString("gotify.server_url"); envVal != "" {
+		cfg.Gotify.ServerURL = envVal
+	}
+	if envVal := viper.GetString("gotify.app_token"); envVal != "" {
+		cfg.Gotify.AppToken = envVal
+	}
+	if envVal := viper.GetInt("gotify.default_priority"); envVal > 0 {
+		cfg.Gotify.DefaultPriority = envVal
+	}
+	if viper.IsSet("gotify.markdown") {
+		cfg.Gotify.Markdown = viper.GetBool("gotify.markdown")
+	}
+	if envVal := viper.GetInt("gotify.timeout"); envVal > 0 {
+		cfg.Gotify.Timeout = envVal
+	}
+
+	// Matrix
+	if envVal := viper.GetString("matrix.homeserver_url"); envVal != "" {
+		cfg.Matrix.HomeserverURL = envVal
+	}
+	if envVal := viper.GetString("matrix.access_token"); envVal != "" {
+		cfg.Matrix.AccessToken = envVal
+	}
+	if envVal := viper.GetString("matrix.default_room"); envVal != "" {
+		cfg.Matrix.DefaultRoom = envVal
+	}
+	if envVal := viper.GetInt("matrix.timeout"); envVal > 0 {
+		cfg.Matrix.Timeout = envVal
+	}
+
+	// Mattermost
+	if envVal := viper.GetString("mattermost.webhook_url"); envVal != "" {
+		cfg.Mattermost.WebhookURL = envVal
+	}
+	if envVal := viper.GetString("mattermost.server_url"); envVal != "" {
+		cfg.Mattermost.ServerURL = envVal
+	}
+	if envVal := viper.GetString("mattermost.access_token"); envVal != "" {
+		cfg.Mattermost.AccessToken = envVal
+	}
+	if envVal := viper.GetString("mattermost.default_channel"); envVal != "" {
+		cfg.Mattermost.DefaultChannel = envVal
+	}
+	if envVal := viper.GetString("mattermost.username"); envVal != "" {
+		cfg.Mattermost.Username = envVal
+	}
+	if envVal := viper.GetString("mattermost.icon_url"); envVal != "" {
+		cfg.Mattermost.IconURL = envVal
+	}
+	if envVal := viper.GetInt("mattermost.timeout"); envVal > 0 {
+		cfg.Mattermost.Timeout = envVal
+	}
+
+	// Rocket.Chat
+	if envVal := viper.GetString("rocketchat.webhook_url"); envVal != "" {
+		cfg.RocketChat.WebhookURL = envVal
+	}
+	if envVal := viper.GetString("rocketchat.server_url"); envVal != "" {
+		cfg.RocketChat.ServerURL = envVal
+	}
+	if envVal := viper.GetString("rocketchat.user_id"); envVal != "" {
+		cfg.RocketChat.UserID = envVal
+	}
+	if envVal := viper.GetString("rocketchat.auth_token"); envVal != "" {
+		cfg.RocketChat.AuthToken = envVal
+	}
+	if envVal := viper.GetString("rocketchat.default_channel"); envVal != "" {
+		cfg.RocketChat.DefaultChannel = envVal
+	}
+	if envVal := viper.GetString("rocketchat.username"); envVal != "" {
+		cfg.RocketChat.Username = envVal
+	}
+	if envVal := viper.GetString("rocketchat.avatar_url"); envVal != "" {
+		cfg.RocketChat.AvatarURL = envVal
+	}
+	if envVal := viper.GetInt("rocketchat.timeout"); envVal > 0 {
+		cfg.RocketChat.Timeout = envVal
+	}
+
+	// SMS
+	if envVal := viper.GetString("sms.base_url"); envVal != "" {
+		cfg.SMS.BaseURL = envVal
+	}
+	if envVal := viper.GetString("sms.account_sid"); envVal != "" {
+		cfg.SMS.AccountSID = envVal
+	}
+	if envVal := viper.GetString("sms.auth_token"); envVal != "" {
+		cfg.SMS.AuthToken = envVal
+	}
+	if envVal := viper.GetString("sms.from_number"); envVal != "" {
+		cfg.SMS.FromNumber = envVal
+	}
+	if envVal := viper.GetString("sms.messaging_service_sid"); envVal != "" {
+		cfg.SMS.MessagingServiceSID = envVal
+	}
+	if envVal := viper.GetString("sms.default_to"); envVal != "" {
+		cfg.SMS.DefaultTo = envVal
+	}
+	if envVal := viper.GetInt("sms.timeout"); envVal > 0 {
+		cfg.SMS.Timeout = envVal
+	}
+
+	// Signal
+	if envVal := viper.GetString("signal.api_url"); envVal != "" {
+		cfg.Signal.APIURL = envVal
+	}
+	if envVal := viper.GetString("signal.number"); envVal != "" {
+		cfg.Signal.Number = envVal
+	}
+	if envVal := viper.GetString("signal.default_recipient"); envVal != "" {
+		cfg.Signal.DefaultRecipient = envVal
+	}
+	if envVal := viper.GetInt("signal.timeout"); envVal > 0 {
+		cfg.Signal.Timeout = envVal
+	}
+
+	// PagerDuty
+	if envVal := viper.GetString("pagerduty.events_url"); envVal != "" {
+		cfg.PagerDuty.EventsURL = envVal
+	}
+	if envVal := viper.GetString("pagerduty.routing_key"); envVal != "" {
+		cfg.PagerDuty.RoutingKey = envVal
+	}
+	if envVal := viper.GetString("pagerduty.default_severity"); envVal != "" {
+		cfg.PagerDuty.DefaultSeverity = envVal
+	}
+	if envVal := viper.GetString("pagerduty.source"); envVal != "" {
+		cfg.PagerDuty.Source = envVal
+	}
+	if envVal := viper.GetInt("pagerduty.timeout"); envVal > 0 {
+		cfg.PagerDuty.Timeout = envVal
+	}
+
+	// Opsgenie
+	if envVal := viper.GetString("opsgenie.api_url"); envVal != "" {
+		cfg.Opsgenie.APIURL = envVal
+	}
+	if envVal := viper.GetString("opsgenie.api_key"); envVal != "" {
+		cfg.Opsgenie.APIKey = envVal
+	}
+	if envVal := viper.GetString("opsgenie.default_priority"); envVal != "" {
+		cfg.Opsgenie.DefaultPriority = envVal
+	}
+	if envVal := viper.GetString("opsgenie.default_responders"); envVal != "" {
+		cfg.Opsgenie.DefaultResponders = envVal
+	}
+	if envVal := viper.GetString("opsgenie.source"); envVal != "" {
+		cfg.Opsgenie.Source = envVal
+	}
+	if envVal := viper.GetInt("opsgenie.timeout"); envVal > 0 {
+		cfg.Opsgenie.Timeout = envVal
+	}
+
+	// MQTT
+	if envVal := viper.GetString("mqtt.broker_url"); envVal != "" {
+		cfg.MQTT.BrokerURL = envVal
+	}
+	if envVal := viper.GetString("mqtt.client_id"); envVal != "" {
+		cfg.MQTT.ClientID = envVal
+	}
+	if envVal := viper.GetString("mqtt.username"); envVal != "" {
+		cfg.MQTT.Username = envVal
+	}
+	if envVal := viper.GetString("mqtt.password"); envVal != "" {
+		cfg.MQTT.Password = envVal
+	}
+	if envVal := viper.GetString("mqtt.default_topic"); envVal != "" {
+		cfg.MQTT.DefaultTopic = envVal
+	}
+	if envVal := viper.GetInt("mqtt.qos"); envVal > 0 {
+		cfg.MQTT.QoS = envVal
+	}
+	if envVal := viper.GetInt("mqtt.timeout"); envVal > 0 {
+		cfg.MQTT.Timeout = envVal
+	}
+
+	// NATS
+	if envVal := viper.GetString("nats.server_url"); envVal != "" {
+		cfg.NATS.ServerURL = envVal
+	}
+	if envVal := viper.GetString("nats.token"); envVal != "" {
+		cfg.NATS.Token = envVal
+	}
+	if envVal := viper.GetString("nats.username"); envVal != "" {
+		cfg.NATS.Username = envVal
+	}
+	if envVal := viper.GetString("nats.password"); envVal != "" {
+		cfg.NATS.Password = envVal
+	}
+	if envVal := viper.GetString("nats.default_subject"); envVal != "" {
+		cfg.NATS.DefaultSubject = envVal
+	}
+	if envVal := viper.GetInt("nats.timeout"); envVal > 0 {
+		cfg.NATS.Timeout = envVal
+	}
+
+	// Syslog
+	if envVal := viper.GetString("syslog.address"); envVal != "" {
+		cfg.Syslog.Address = envVal
+	}
+	if envVal := viper.GetString("syslog.facility"); envVal != "" {
+		cfg.Syslog.Facility = envVal
+	}
+	if envVal := viper.GetString("syslog.default_severity"); envVal != "" {
+		cfg.Syslog.DefaultSeverity = envVal
+	}
+	if envVal := viper.GetString("syslog.app_name"); envVal != "" {
+		cfg.Syslog.AppName = envVal
+	}
+	if envVal := viper.GetString("syslog.hostname"); envVal != "" {
+		cfg.Syslog.Hostname = envVal
+	}
+	if envVal := viper.GetInt("syslog.timeout"); envVal > 0 {
+		cfg.Syslog.Timeout = envVal
+	}
+
+	// Journald
+	if envVal := viper.GetString("journald.socket_path"); envVal != "" {
+		cfg.Journald.SocketPath = envVal
+	}
+	if envVal := viper.GetString("journald.identifier"); envVal != "" {
+		cfg.Journald.Identifier = envVal
+	}
+	if envVal := viper.GetString("journald.default_severity"); envVal != "" {
+		cfg.Journald.DefaultSeverity = envVal
+	}
+
+	// File
+	if envVal := viper.GetString("file.path"); envVal != "" {
+		cfg.File.Path = envVal
+	}
+	if envVal := viper.GetString("file.format"); envVal != "" {
+		cfg.File.Format = envVal
+	}
+}
+
+// ApplyDefaults aplica os valores padrão. Usado em configurações montadas fora do
+// LoadConfig (ex: a configuração efêmera de "cast send url").
+func (c *Config) ApplyDefaults() {
+	c.applyDefaults()
+}
+
+// ApplyURLDefaults aplica os padrões a uma configuração montada a partir de uma URL de
+// provider. O modo TLS/SSL do email é definido pela própria URL (mailto:// sem
+// criptografia), então o padrão STARTTLS do cast.yaml não é aplicado.
+func (c *Config) ApplyURLDefaults() {
+	useTLS, useSSL := c.Email.UseTLS, c.Email.UseSSL
+	c.applyDefaults()
+	c.Email.UseTLS, c.Email.UseSSL = useTLS, useSSL
 }
 
 // applyDefaults aplica valores padrão para campos opcionais.
@@ -441,6 +1147,141 @@ func (c *Config) applyDefaults() {
 	if c.WAHA.Timeout == 0 {
 		c.WAHA.Timeout = 30
 	}
+
+	// Slack defaults
+	if c.Slack.APIURL == "" {
+		c.Slack.APIURL = "https://slack.com/api"
+	}
+	if c.Slack.Timeout == 0 {
+		c.Slack.Timeout = 30
+	}
+
+	// Microsoft Teams defaults
+	if c.Teams.Timeout == 0 {
+		c.Teams.Timeout = 30
+	}
+
+	// Discord defaults
+	if c.Discord.Timeout == 0 {
+		c.Discord.Timeout = 30
+	}
+
+	// Webhook HTTP defaults
+	if c.Webhook.Timeout == 0 {
+		c.Webhook.Timeout = 30
+	}
+
+	// ntfy defaults
+	if c.Ntfy.ServerURL == "" {
+		c.Ntfy.ServerURL = "https://ntfy.sh"
+	}
+	if c.Ntfy.Timeout == 0 {
+		c.Ntfy.Timeout = 30
+	}
+
+	// Gotify defaults
+	if c.Gotify.DefaultPriority == 0 {
+		c.Gotify.DefaultPriority = 5
+	}
+	if c.Gotify.Timeout == 0 {
+		c.Gotify.Timeout = 30
+	}
+
+	// Matrix defaults
+	if c.Matrix.Timeout == 0 {
+		c.Matrix.Timeout = 30
+	}
+
+	// Mattermost defaults
+	if c.Mattermost.Timeout == 0 {
+		c.Mattermost.Timeout = 30
+	}
+
+	// Rocket.Chat defaults
+	if c.RocketChat.Timeout == 0 {
+		c.RocketChat.Timeout = 30
+	}
+
+	// SMS defaults
+	if c.SMS.BaseURL == "" {
+		c.SMS.BaseURL = "https://api.twilio.com"
+	}
+	if c.SMS.Timeout == 0 {
+		c.SMS.Timeout = 30
+	}
+
+	// Signal defaults
+	if c.Signal.Timeout == 0 {
+		c.Signal.Timeout = 30
+	}
+
+	// PagerDuty defaults
+	if c.PagerDuty.EventsURL == "" {
+		c.PagerDuty.EventsURL = "https://events.pagerduty.com"
+	}
+	if c.PagerDuty.DefaultSeverity == "" {
+		c.PagerDuty.DefaultSeverity = "error"
+	}
+	if c.PagerDuty.Timeout == 0 {
+		c.PagerDuty.Timeout = 30
+	}
+
+	// Opsgenie defaults
+	if c.Opsgenie.APIURL == "" {
+		c.Opsgenie.APIURL = "https://api.opsgenie.com"
+	}
+	if c.Opsgenie.DefaultPriority == "" {
+		c.Opsgenie.DefaultPriority = "P3"
+	}
+	if c.Opsgenie.Timeout == 0 {
+		c.Opsgenie.Timeout = 30
+	}
+
+	// MQTT defaults
+	if c.MQTT.Timeout == 0 {
+		c.MQTT.Timeout = 30
+	}
+
+	// NATS defaults
+	if c.NATS.Timeout == 0 {
+		c.NATS.Timeout = 30
+	}
+
+	// Syslog defaults
+	if c.Syslog.Facility == "" {
+		c.Syslog.Facility = "user"
+	}
+	if c.Syslog.DefaultSeverity == "" {
+		c.Syslog.DefaultSeverity = "notice"
+	}
+	if c.Syslog.AppName == "" {
+		c.Syslog.AppName = "cast"
+	}
+	if c.Syslog.Timeout == 0 {
+		c.Syslog.Timeout = 30
+	}
+
+	// Journald defaults
+	if c.Journald.SocketPath == "" {
+		c.Journald.SocketPath = "/run/systemd/journal/socket"
+	}
+	if c.Journald.Facility == "" {
+		c.Journald.Facility = "user"
+	}
+	if c.Journald.DefaultSeverity == "" {
+		c.Journald.DefaultSeverity = "info"
+	}
+	if c.Journald.Timeout == 0 {
+		c.Journald.Timeout = 30
+	}
+
+	// File defaults
+	if c.File.Format == "" {
+		c.File.Format = "jsonl"
+	}
+	if c.File.Timeout == 0 {
+		c.File.Timeout = 30
+	}
 }
 
 // Validate valida a configuração obrigatória.
@@ -455,12 +1296,91 @@ func (c *Config) Validate() error {
 	if c.Email.Timeout < 5 || c.Email.Timeout > 300 {
 		return fmt.Errorf("email.timeout deve estar entre 5 e 300 segundos")
 	}
+	if dkim := c.Email; dkim.DKIMDomain != "" || dkim.DKIMSelector != "" || dkim.DKIMPrivateKey != "" {
+		if dkim.DKIMDomain == "" || dkim.DKIMSelector == "" || dkim.DKIMPrivateKey == "" {
+			return fmt.Errorf("email: dkim_domain, dkim_selector e dkim_private_key devem ser configurados juntos")
+		}
+	}
+	if c.Email.SMTPMaxMessages < 0 {
+		return fmt.Errorf("email.smtp_max_messages não pode ser negativo")
+	}
+	if c.Email.OAuth2TokenURL != "" && c.Email.OAuth2ClientID == "" {
+		return fmt.Errorf("email.oauth2_token_url requer email.oauth2_client_id configurado")
+	}
 	if c.GoogleChat.Timeout < 5 || c.GoogleChat.Timeout > 300 {
 		return fmt.Errorf("google_chat.timeout deve estar entre 5 e 300 segundos")
 	}
 	if c.WAHA.Timeout < 5 || c.WAHA.Timeout > 300 {
 		return fmt.Errorf("waha.timeout deve estar entre 5 e 300 segundos")
 	}
+	if c.Slack.Timeout < 5 || c.Slack.Timeout > 300 {
+		return fmt.Errorf("slack.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Teams.Timeout < 5 || c.Teams.Timeout > 300 {
+		return fmt.Errorf("teams.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Discord.Timeout < 5 || c.Discord.Timeout > 300 {
+		return fmt.Errorf("discord.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Webhook.Timeout < 5 || c.Webhook.Timeout > 300 {
+		return fmt.Errorf("webhook.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Ntfy.Timeout < 5 || c.Ntfy.Timeout > 300 {
+		return fmt.Errorf("ntfy.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Gotify.Timeout < 5 || c.Gotify.Timeout > 300 {
+		return fmt.Errorf("gotify.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Matrix.Timeout < 5 || c.Matrix.Timeout > 300 {
+		return fmt.Errorf("matrix.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Mattermost.Timeout < 5 || c.Mattermost.Timeout > 300 {
+		return fmt.Errorf("mattermost.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.RocketChat.Timeout < 5 || c.RocketChat.Timeout > 300 {
+		return fmt.Errorf("rocketchat.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.SMS.Timeout < 5 || c.SMS.Timeout > 300 {
+		return fmt.Errorf("sms.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Signal.Timeout < 5 || c.Signal.Timeout > 300 {
+		return fmt.Errorf("signal.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.PagerDuty.Timeout < 5 || c.PagerDuty.Timeout > 300 {
+		return fmt.Errorf("pagerduty.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Opsgenie.Timeout < 5 || c.Opsgenie.Timeout > 300 {
+		return fmt.Errorf("opsgenie.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.MQTT.Timeout < 5 || c.MQTT.Timeout > 300 {
+		return fmt.Errorf("mqtt.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.NATS.Timeout < 5 || c.NATS.Timeout > 300 {
+		return fmt.Errorf("nats.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Syslog.Timeout < 5 || c.Syslog.Timeout > 300 {
+		return fmt.Errorf("syslog.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.Journald.Timeout < 5 || c.Journald.Timeout > 300 {
+		return fmt.Errorf("journald.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.File.Timeout < 5 || c.File.Timeout > 300 {
+		return fmt.Errorf("file.timeout deve estar entre 5 e 300 segundos")
+	}
+	if c.File.Format != "jsonl" && c.File.Format != "text" {
+		return fmt.Errorf("file.format deve ser jsonl ou text")
+	}
+	if c.MQTT.QoS < 0 || c.MQTT.QoS > 2 {
+		return fmt.Errorf("mqtt.qos deve ser 0, 1 ou 2")
+	}
+	if c.Gotify.DefaultPriority < 0 || c.Gotify.DefaultPriority > 10 {
+		return fmt.Errorf("gotify.default_priority deve estar entre 0 e 10")
+	}
+	for name, endpoint := range c.Webhook.Endpoints {
+		if endpoint.Timeout != 0 && (endpoint.Timeout < 5 || endpoint.Timeout > 300) {
+			return fmt.Errorf("webhook.endpoints.%s.timeout deve estar entre 5 e 300 segundos", name)
+		}
+	}
 
 	// Validação de Email: TLS e SSL são mutuamente exclusivos
 	if c.Email.UseTLS && c.Email.UseSSL {
@@ -478,8 +1398,8 @@ func (c *Config) Validate() error {
 		if c.Email.IMAPUsername == "" {
 			return fmt.Errorf("email.wait_for_response_default_minutes > 0 requer email.imap_username configurado")
 		}
-		if c.Email.IMAPPassword == "" {
-			return fmt.Errorf("email.wait_for_response_default_minutes > 0 requer email.imap_password configurado")
+		if c.Email.IMAPPassword == "" && c.Email.OAuth2TokenURL == "" {
+			return fmt.Errorf("email.wait_for_response_default_minutes > 0 requer email.imap_password (ou email.oauth2_token_url) configurado")
 		}
 	}
 
@@ -491,6 +1411,9 @@ func (c *Config) Validate() error {
 		if alias.Target == "" {
 			return fmt.Errorf("alias '%s': target não pode estar vazio", aliasName)
 		}
+		if (alias.Cc != "" || alias.Bcc != "") && alias.Provider != "email" && alias.Provider != "mail" && alias.Provider != "url" {
+			return fmt.Errorf("alias '%s': cc e bcc são suportados apenas em aliases de email", aliasName)
+		}
 	}
 
 	return nil
@@ -207,6 +207,28 @@ func TestConfigDefaults(t *testing.T) {
 	}
 }
 
+func TestSetField_StringMap(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.SetField("pagerduty", "services", "database=CHAVE1; api = CHAVE2"); err != nil {
+		t.Fatalf("Erro ao definir mapa: %v", err)
+	}
+	if cfg.PagerDuty.Services["database"] != "CHAVE1" || cfg.PagerDuty.Services["api"] != "CHAVE2" {
+		t.Errorf("Mapa inesperado: %v", cfg.PagerDuty.Services)
+	}
+
+	value, err := cfg.GetField("pagerduty", "services")
+	if err != nil || value != "api=CHAVE2,database=CHAVE1" {
+		t.Errorf("GetField: esperado 'api=CHAVE2,database=CHAVE1', obtido '%s' (erro: %v)", value, err)
+	}
+
+	if err := cfg.SetField("pagerduty", "services", "database"); err == nil {
+		t.Error("Esperado erro para entrada sem '='")
+	}
+	if err := cfg.SetField("pagerduty", "services", ""); err != nil || cfg.PagerDuty.Services != nil {
+		t.Errorf("Valor vazio deveria remover o mapa, obtido %v (erro: %v)", cfg.PagerDuty.Services, err)
+	}
+}
+
 func TestConfigValidation(t *testing.T) {
 	tests := []struct {
 		name    string
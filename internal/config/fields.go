@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sectionValue retorna o valor endereçável da seção do Config cuja tag yaml é igual a section.
+func (c *Config) sectionValue(section string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if yamlTagName(t.Field(i)) == section && t.Field(i).Type.Kind() == reflect.Struct {
+			return v.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("seção de configuração desconhecida: %s", section)
+}
+
+// fieldValue retorna o valor endereçável do campo key dentro da seção section.
+func (c *Config) fieldValue(section, key string) (reflect.Value, error) {
+	sv, err := c.sectionValue(section)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		if yamlTagName(st.Field(i)) == key {
+			return sv.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("campo desconhecido: %s.%s", section, key)
+}
+
+// GetField retorna o valor de section.key formatado como string (ex: "telegram", "token").
+func (c *Config) GetField(section, key string) (string, error) {
+	fv, err := c.fieldValue(section, key)
+	if err != nil {
+		return "", err
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), nil
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.String {
+			return strings.Join(fv.Interface().([]string), ","), nil
+		}
+	case reflect.Map:
+		if m, ok := fv.Interface().(map[string]string); ok {
+			return formatStringMap(m), nil
+		}
+	}
+	return "", fmt.Errorf("tipo não suportado para %s.%s", section, key)
+}
+
+// SetField converte value para o tipo do campo section.key e o atribui.
+func (c *Config) SetField(section, key, value string) error {
+	fv, err := c.fieldValue(section, key)
+	if err != nil {
+		return err
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Int, reflect.Int64:
+		if value == "" {
+			fv.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s.%s deve ser um número: %s", section, key, value)
+		}
+		fv.SetInt(n)
+	case reflect.Bool:
+		if value == "" {
+			fv.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s.%s deve ser true ou false: %s", section, key, value)
+		}
+		fv.SetBool(b)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("tipo não suportado para %s.%s", section, key)
+		}
+		fv.Set(reflect.ValueOf(ParseTargets(value)))
+	case reflect.Map:
+		if fv.Type() != reflect.TypeOf(map[string]string{}) {
+			return fmt.Errorf("tipo não suportado para %s.%s", section, key)
+		}
+		m, err := parseStringMap(value)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", section, key, err)
+		}
+		fv.Set(reflect.ValueOf(m))
+	default:
+		return fmt.Errorf("tipo não suportado para %s.%s", section, key)
+	}
+	return nil
+}
+
+// formatStringMap formata um mapa como "chave=valor" separados por vírgula, ordenados pela chave.
+func formatStringMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+m[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseStringMap converte "chave=valor" separados por vírgula ou ponto-e-vírgula em mapa.
+// Valor vazio retorna nil (remove o mapa).
+func parseStringMap(value string) (map[string]string, error) {
+	entries := ParseTargets(value)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		k, v, ok := strings.Cut(entry, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("formato inválido '%s' (use nome=valor)", entry)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// ResetSection zera todos os campos da seção informada (usado por "gateway remove").
+func (c *Config) ResetSection(section string) error {
+	sv, err := c.sectionValue(section)
+	if err != nil {
+		return err
+	}
+	sv.Set(reflect.Zero(sv.Type()))
+	return nil
+}
+
+// mergeSection copia para dest os campos não vazios da seção section de source.
+func mergeSection(dest, source *Config, section string) {
+	dv, err := dest.sectionValue(section)
+	if err != nil {
+		return
+	}
+	sv, err := source.sectionValue(section)
+	if err != nil {
+		return
+	}
+	for i := 0; i < sv.NumField(); i++ {
+		if !sv.Field(i).IsZero() {
+			dv.Field(i).Set(sv.Field(i))
+		}
+	}
+}
+
+// yamlTagName retorna o nome da tag yaml de um campo (sem opções como omitempty).
+func yamlTagName(f reflect.StructField) string {
+	tag := f.Tag.Get("yaml")
+	if idx := strings.Index(tag, ","); idx >= 0 {
+		tag = tag[:idx]
+	}
+	return tag
+}
@@ -199,6 +199,36 @@ func MergeConfig(source, dest *Config) {
 	if source.Email.Timeout > 0 {
 		dest.Email.Timeout = source.Email.Timeout
 	}
+	if source.Email.SMTPMaxMessages > 0 {
+		dest.Email.SMTPMaxMessages = source.Email.SMTPMaxMessages
+	}
+	if source.Email.DKIMDomain != "" {
+		dest.Email.DKIMDomain = source.Email.DKIMDomain
+	}
+	if source.Email.DKIMSelector != "" {
+		dest.Email.DKIMSelector = source.Email.DKIMSelector
+	}
+	if source.Email.DKIMPrivateKey != "" {
+		dest.Email.DKIMPrivateKey = source.Email.DKIMPrivateKey
+	}
+	if len(source.Email.DKIMHeaders) > 0 {
+		dest.Email.DKIMHeaders = source.Email.DKIMHeaders
+	}
+	if source.Email.OAuth2TokenURL != "" {
+		dest.Email.OAuth2TokenURL = source.Email.OAuth2TokenURL
+	}
+	if source.Email.OAuth2ClientID != "" {
+		dest.Email.OAuth2ClientID = source.Email.OAuth2ClientID
+	}
+	if source.Email.OAuth2ClientSecret != "" {
+		dest.Email.OAuth2ClientSecret = source.Email.OAuth2ClientSecret
+	}
+	if source.Email.OAuth2RefreshToken != "" {
+		dest.Email.OAuth2RefreshToken = source.Email.OAuth2RefreshToken
+	}
+	if source.Email.OAuth2Scope != "" {
+		dest.Email.OAuth2Scope = source.Email.OAuth2Scope
+	}
 
 	// Merge Google Chat
 	if source.GoogleChat.WebhookURL != "" {
@@ -208,6 +238,70 @@ func MergeConfig(source, dest *Config) {
 		dest.GoogleChat.Timeout = source.GoogleChat.Timeout
 	}
 
+	// Merge Slack
+	mergeSection(dest, source, "slack")
+
+	// Merge Microsoft Teams
+	mergeSection(dest, source, "teams")
+
+	// Merge Discord
+	mergeSection(dest, source, "discord")
+
+	// Merge Webhook HTTP: endpoints novos adicionam, existentes atualizam
+	if source.Webhook.Timeout > 0 {
+		dest.Webhook.Timeout = source.Webhook.Timeout
+	}
+	if source.Webhook.Endpoints != nil {
+		if dest.Webhook.Endpoints == nil {
+			dest.Webhook.Endpoints = make(map[string]WebhookEndpoint)
+		}
+		for name, endpoint := range source.Webhook.Endpoints {
+			dest.Webhook.Endpoints[name] = endpoint
+		}
+	}
+
+	// Merge ntfy
+	mergeSection(dest, source, "ntfy")
+
+	// Merge Gotify
+	mergeSection(dest, source, "gotify")
+
+	// Merge Matrix
+	mergeSection(dest, source, "matrix")
+
+	// Merge Mattermost
+	mergeSection(dest, source, "mattermost")
+
+	// Merge Rocket.Chat
+	mergeSection(dest, source, "rocketchat")
+
+	// Merge SMS
+	mergeSection(dest, source, "sms")
+
+	// Merge Signal
+	mergeSection(dest, source, "signal")
+
+	// Merge PagerDuty
+	mergeSection(dest, source, "pagerduty")
+
+	// Merge Opsgenie
+	mergeSection(dest, source, "opsgenie")
+
+	// Merge MQTT
+	mergeSection(dest, source, "mqtt")
+
+	// Merge NATS
+	mergeSection(dest, source, "nats")
+
+	// Merge Syslog
+	mergeSection(dest, source, "syslog")
+
+	// Merge Journald
+	mergeSection(dest, source, "journald")
+
+	// Merge File
+	mergeSection(dest, source, "file")
+
 	// Merge Aliases: novos adicionam, existentes atualizam
 	if source.Aliases != nil {
 		if dest.Aliases == nil {
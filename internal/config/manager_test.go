@@ -164,3 +164,63 @@ func TestSave_EmptyAliases(t *testing.T) {
 		t.Error("Aliases deveria ser inicializado (não nil)")
 	}
 }
+
+func TestMergeConfig_EmailDKIM(t *testing.T) {
+	source := &Config{Email: EmailConfig{
+		DKIMDomain:     "empresa.com",
+		DKIMSelector:   "cast",
+		DKIMPrivateKey: "/etc/cast/dkim.pem",
+		DKIMHeaders:    []string{"From", "Subject"},
+	}}
+	dest := &Config{Email: EmailConfig{SMTPHost: "smtp.empresa.com", DKIMSelector: "antigo"}}
+
+	MergeConfig(source, dest)
+
+	if dest.Email.SMTPHost != "smtp.empresa.com" {
+		t.Errorf("SMTPHost deveria ser mantido, obtido '%s'", dest.Email.SMTPHost)
+	}
+	if dest.Email.DKIMDomain != "empresa.com" || dest.Email.DKIMSelector != "cast" || dest.Email.DKIMPrivateKey != "/etc/cast/dkim.pem" {
+		t.Errorf("Configuração DKIM não foi mesclada: %+v", dest.Email)
+	}
+	if len(dest.Email.DKIMHeaders) != 2 || dest.Email.DKIMHeaders[1] != "Subject" {
+		t.Errorf("DKIMHeaders não foi mesclado: %v", dest.Email.DKIMHeaders)
+	}
+}
+
+func TestMergeConfig_EmailOAuth2(t *testing.T) {
+	source := &Config{Email: EmailConfig{
+		OAuth2TokenURL:     "https://oauth2.googleapis.com/token",
+		OAuth2ClientID:     "123.apps.googleusercontent.com",
+		OAuth2ClientSecret: "${env:CAST_OAUTH2_SECRET}",
+		OAuth2RefreshToken: "${file:/etc/cast/refresh_token}",
+		OAuth2Scope:        "https://mail.google.com/",
+	}}
+	dest := &Config{Email: EmailConfig{SMTPHost: "smtp.gmail.com", Password: "senha"}}
+
+	MergeConfig(source, dest)
+
+	expected := source.Email
+	expected.SMTPHost, expected.Password = "smtp.gmail.com", "senha"
+	if dest.Email.OAuth2TokenURL != expected.OAuth2TokenURL || dest.Email.OAuth2ClientID != expected.OAuth2ClientID ||
+		dest.Email.OAuth2ClientSecret != expected.OAuth2ClientSecret || dest.Email.OAuth2RefreshToken != expected.OAuth2RefreshToken ||
+		dest.Email.OAuth2Scope != expected.OAuth2Scope {
+		t.Errorf("Configuração OAuth2 não foi mesclada: %+v", dest.Email)
+	}
+	if dest.Email.SMTPHost != expected.SMTPHost || dest.Email.Password != expected.Password {
+		t.Errorf("Campos ausentes na origem deveriam ser mantidos: %+v", dest.Email)
+	}
+}
+
+func TestMergeConfig_EmailSMTPMaxMessages(t *testing.T) {
+	dest := &Config{Email: EmailConfig{SMTPHost: "smtp.empresa.com"}}
+
+	MergeConfig(&Config{Email: EmailConfig{SMTPMaxMessages: 50}}, dest)
+	if dest.Email.SMTPMaxMessages != 50 {
+		t.Errorf("SMTPMaxMessages deveria ser 50, obtido %d", dest.Email.SMTPMaxMessages)
+	}
+
+	MergeConfig(&Config{}, dest)
+	if dest.Email.SMTPMaxMessages != 50 {
+		t.Errorf("SMTPMaxMessages ausente na origem deveria ser mantido, obtido %d", dest.Email.SMTPMaxMessages)
+	}
+}
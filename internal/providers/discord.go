@@ -0,0 +1,446 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "discord",
+		DisplayName: "Discord",
+		Order:       80,
+		TargetHint:  "webhook_url ou 'default'",
+		Fields: []ConfigField{
+			{Key: "webhook_url", Flag: "webhook-url", Label: "Webhook URL", Required: true, Secret: true, Validate: validateDiscordWebhook},
+			{Key: "username", Flag: "username", Label: "Username"},
+			{Key: "avatar_url", Flag: "avatar-url", Label: "Avatar URL", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "color", Usage: "Cor do embed: #RRGGBB, decimal ou nome (red, green, blue...) (Discord)"},
+			{Name: "field", Usage: "Campo do embed no formato Nome=Valor (Discord, pode ser repetido)", Repeatable: true},
+			sharedSendFlag("username"),
+			sharedSendFlag("avatar"),
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Discord.WebhookURL != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewDiscordProvider(&conf.Discord), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testDiscordConnection(&conf.Discord)
+		},
+		URLSchemes: []string{"discord"},
+		FromURL:    discordFromURL,
+	})
+}
+
+// discordFromURL interpreta discord://WEBHOOK_ID/WEBHOOK_TOKEN.
+func discordFromURL(u *ProviderURL, conf *config.Config) (string, error) {
+	if u.Host == "" || len(u.Path) != 1 {
+		return "", fmt.Errorf("use discord://WEBHOOK_ID/WEBHOOK_TOKEN")
+	}
+	conf.Discord.WebhookURL = "https://discord.com/api/webhooks/" + u.Host + "/" + u.Path[0]
+	return "default", nil
+}
+
+const (
+	discordMaxContent     = 2000 // Limite de caracteres do campo content
+	discordMaxDescription = 4096 // Limite de caracteres da descrição do embed
+	discordMaxRetries     = 3    // Tentativas em caso de HTTP 429
+)
+
+// discordColors mapeia nomes de cores comuns para o valor decimal usado nos embeds.
+var discordColors = map[string]int{
+	"red":    0xED4245,
+	"green":  0x57F287,
+	"blue":   0x3498DB,
+	"yellow": 0xFEE75C,
+	"orange": 0xE67E22,
+	"purple": 0x9B59B6,
+	"grey":   0x95A5A6,
+	"gray":   0x95A5A6,
+}
+
+// validateDiscordWebhook valida se a URL parece um webhook do Discord.
+func validateDiscordWebhook(value string) error {
+	if err := ValidateHTTPURL(value); err != nil {
+		return err
+	}
+	if value != "" && !strings.Contains(value, "/api/webhooks/") {
+		return fmt.Errorf("webhook URL deve ter o formato https://discord.com/api/webhooks/<id>/<token>")
+	}
+	return nil
+}
+
+// discordProvider implementa o Provider para Discord (Webhooks).
+type discordProvider struct {
+	config *config.DiscordConfig
+	client *http.Client
+
+	// Controle de rate limit: próxima requisição só após nextAllowed
+	nextAllowed time.Time
+	sleep       func(time.Duration)
+}
+
+// discordEmbed representa um embed do Discord.
+type discordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+}
+
+// discordEmbedField representa um campo de embed.
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// discordPayload representa o corpo enviado ao webhook.
+type discordPayload struct {
+	Content     string              `json:"content,omitempty"`
+	Username    string              `json:"username,omitempty"`
+	AvatarURL   string              `json:"avatar_url,omitempty"`
+	Embeds      []discordEmbed      `json:"embeds,omitempty"`
+	Attachments []map[string]string `json:"attachments,omitempty"`
+}
+
+// NewDiscordProvider cria uma nova instância do DiscordProvider.
+func NewDiscordProvider(cfg *config.DiscordConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &discordProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+		sleep:  time.Sleep,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *discordProvider) Name() string {
+	return "discord"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *discordProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem de texto via Discord.
+func (p *discordProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem via webhook do Discord.
+// Se houver assunto (--subject), cor ou campos, a mensagem é enviada como embed
+// (assunto = título, mensagem = descrição). Anexos são enviados via multipart.
+// Lógica de Target:
+// - URL completa (https://...): usa essa URL
+// - "default" ou vazio: usa a URL configurada no cast.yaml
+// - Suporta múltiplos webhooks separados por vírgula ou ponto-e-vírgula
+func (p *discordProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	payload, err := p.buildPayload(message, opts)
+	if err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		webhookURL := t
+		if !strings.HasPrefix(t, "https://") && !strings.HasPrefix(t, "http://") {
+			if p.config.WebhookURL == "" {
+				return fmt.Errorf("target '%s' requer webhook_url configurado", t)
+			}
+			webhookURL = p.config.WebhookURL
+		}
+
+		if err := p.execute(webhookURL, payload, opts.Attachments); err != nil {
+			return fmt.Errorf("erro ao enviar para webhook (target %d/%d): %w", i+1, len(targets), err)
+		}
+	}
+
+	return nil
+}
+
+// buildPayload monta o payload a partir da mensagem e das opções de envio.
+func (p *discordProvider) buildPayload(message string, opts SendOptions) (*discordPayload, error) {
+	payload := &discordPayload{
+		Username:  p.config.Username,
+		AvatarURL: p.config.AvatarURL,
+	}
+	if username := opts.Get("username"); username != "" {
+		payload.Username = username
+	}
+	if avatar := opts.Get("avatar"); avatar != "" {
+		payload.AvatarURL = avatar
+	}
+
+	fields := opts.GetAll("field")
+	colorValue := opts.Get("color")
+
+	// Sem opções de embed: mensagem simples
+	if opts.Subject == "" && colorValue == "" && len(fields) == 0 {
+		if len([]rune(message)) > discordMaxContent {
+			return nil, fmt.Errorf("mensagem excede o limite de %d caracteres do Discord (use --subject para enviar como embed)", discordMaxContent)
+		}
+		payload.Content = message
+		return payload, nil
+	}
+
+	if len([]rune(message)) > discordMaxDescription {
+		return nil, fmt.Errorf("mensagem excede o limite de %d caracteres do embed do Discord", discordMaxDescription)
+	}
+
+	embed := discordEmbed{
+		Title:       opts.Subject,
+		Description: message,
+	}
+	if colorValue != "" {
+		color, err := parseDiscordColor(colorValue)
+		if err != nil {
+			return nil, err
+		}
+		embed.Color = color
+	}
+	for _, field := range fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("campo inválido: '%s' (use Nome=Valor)", field)
+		}
+		embed.Fields = append(embed.Fields, discordEmbedField{
+			Name:   strings.TrimSpace(name),
+			Value:  strings.TrimSpace(value),
+			Inline: true,
+		})
+	}
+	payload.Embeds = []discordEmbed{embed}
+
+	return payload, nil
+}
+
+// parseDiscordColor converte #RRGGBB, 0xRRGGBB, decimal ou nome de cor para inteiro.
+func parseDiscordColor(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if color, ok := discordColors[value]; ok {
+		return color, nil
+	}
+
+	var (
+		n   int64
+		err error
+	)
+	switch {
+	case strings.HasPrefix(value, "#"):
+		n, err = strconv.ParseInt(value[1:], 16, 32)
+	case strings.HasPrefix(value, "0x"):
+		n, err = strconv.ParseInt(value[2:], 16, 32)
+	default:
+		n, err = strconv.ParseInt(value, 10, 32)
+	}
+	if err != nil || n < 0 || n > 0xFFFFFF {
+		return 0, fmt.Errorf("cor inválida: '%s' (use #RRGGBB, decimal ou nome)", value)
+	}
+	return int(n), nil
+}
+
+// execute envia o payload ao webhook, respeitando os headers de rate limit do Discord.
+func (p *discordProvider) execute(webhookURL string, payload *discordPayload, files []string) error {
+	for attempt := 0; ; attempt++ {
+		// Aguarda janela de rate limit informada pela resposta anterior
+		if wait := time.Until(p.nextAllowed); wait > 0 {
+			p.sleep(wait)
+		}
+
+		req, err := p.newRequest(webhookURL, payload, files)
+		if err != nil {
+			return err
+		}
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("erro ao enviar requisição: %w", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		p.updateRateLimit(resp.Header)
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if attempt >= discordMaxRetries {
+				return fmt.Errorf("rate limit do Discord excedido após %d tentativas", attempt+1)
+			}
+			p.nextAllowed = time.Now().Add(discordRetryAfter(resp.Header, body))
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return discordError(resp.StatusCode, body)
+		}
+		return nil
+	}
+}
+
+// newRequest cria a requisição JSON ou multipart (quando há anexos).
+func (p *discordProvider) newRequest(webhookURL string, payload *discordPayload, files []string) (*http.Request, error) {
+	// wait=true faz o Discord confirmar a criação da mensagem (erros síncronos)
+	sep := "?"
+	if strings.Contains(webhookURL, "?") {
+		sep = "&"
+	}
+	webhookURL += sep + "wait=true"
+
+	if len(files) == 0 {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao serializar payload: %w", err)
+		}
+		req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}
+
+	// Multipart: payload_json + files[n]
+	withFiles := *payload
+	withFiles.Attachments = nil
+	for i, file := range files {
+		withFiles.Attachments = append(withFiles.Attachments, map[string]string{
+			"id":       strconv.Itoa(i),
+			"filename": filepath.Base(file),
+		})
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	jsonData, err := json.Marshal(withFiles)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+	if err := writer.WriteField("payload_json", string(jsonData)); err != nil {
+		return nil, fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler anexo %s: %w", file, err)
+		}
+		part, err := writer.CreateFormFile(fmt.Sprintf("files[%d]", i), filepath.Base(file))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao montar multipart: %w", err)
+		}
+		part.Write(data)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", webhookURL, &buf)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req, nil
+}
+
+// updateRateLimit agenda a próxima requisição quando o bucket atual se esgota.
+func (p *discordProvider) updateRateLimit(header http.Header) {
+	if header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+	resetAfter, err := strconv.ParseFloat(header.Get("X-RateLimit-Reset-After"), 64)
+	if err != nil {
+		return
+	}
+	p.nextAllowed = time.Now().Add(time.Duration(resetAfter * float64(time.Second)))
+}
+
+// discordRetryAfter obtém o tempo de espera de uma resposta 429 (header ou corpo JSON).
+func discordRetryAfter(header http.Header, body []byte) time.Duration {
+	if seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64); err == nil {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	var rateLimit struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if json.Unmarshal(body, &rateLimit) == nil && rateLimit.RetryAfter > 0 {
+		return time.Duration(rateLimit.RetryAfter * float64(time.Second))
+	}
+	return time.Second
+}
+
+// discordError converte respostas de erro do Discord em mensagens amigáveis.
+func discordError(statusCode int, body []byte) error {
+	var errorResp struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	}
+	json.Unmarshal(body, &errorResp)
+
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("webhook sem permissão (%d): verifique o token do webhook", statusCode)
+	case http.StatusNotFound:
+		return fmt.Errorf("webhook não encontrado: a URL pode ter sido removida ou regenerada")
+	}
+	if errorResp.Message != "" {
+		return fmt.Errorf("Discord retornou erro %d: %s", statusCode, errorResp.Message)
+	}
+	return fmt.Errorf("Discord retornou status %d: %s", statusCode, strings.TrimSpace(string(body)))
+}
+
+// testDiscordConnection consulta o webhook (GET), que retorna seus dados sem publicar mensagens.
+func testDiscordConnection(cfg *config.DiscordConfig) error {
+	p := NewDiscordProvider(cfg).(*discordProvider)
+
+	resp, err := p.client.Get(cfg.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar com o Discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return discordError(resp.StatusCode, body)
+	}
+
+	var webhook struct {
+		Name      string `json:"name"`
+		ChannelID string `json:"channel_id"`
+	}
+	if err := json.Unmarshal(body, &webhook); err != nil || webhook.ChannelID == "" {
+		return fmt.Errorf("resposta inesperada do webhook: %s", strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// newTestDiscordProvider cria um provider apontando para o servidor de teste,
+// registrando as esperas de rate limit em vez de dormir.
+func newTestDiscordProvider(webhookURL string, sleeps *[]time.Duration) *discordProvider {
+	p := NewDiscordProvider(&config.DiscordConfig{WebhookURL: webhookURL, Timeout: 30}).(*discordProvider)
+	p.sleep = func(d time.Duration) {
+		*sleeps = append(*sleeps, d)
+	}
+	return p
+}
+
+func TestDiscordProvider_Name(t *testing.T) {
+	provider := NewDiscordProvider(&config.DiscordConfig{})
+	if provider.Name() != "discord" {
+		t.Errorf("Esperado 'discord', obtido '%s'", provider.Name())
+	}
+}
+
+func TestDiscordProvider_Send_Content(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("wait") != "true" {
+			t.Errorf("Esperado query wait=true, obtido '%s'", r.URL.RawQuery)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload["content"] != "Teste de mensagem" {
+			t.Errorf("Esperado content 'Teste de mensagem', obtido '%v'", payload["content"])
+		}
+		if payload["username"] != "Deploy Bot" {
+			t.Errorf("Esperado username 'Deploy Bot', obtido '%v'", payload["username"])
+		}
+		if _, ok := payload["embeds"]; ok {
+			t.Error("Mensagem simples não deveria conter embeds")
+		}
+
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+	opts := SendOptions{Values: map[string][]string{"username": {"Deploy Bot"}}}
+	if err := provider.SendWithOptions("default", "Teste de mensagem", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestDiscordProvider_Send_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload discordPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload.Content != "" {
+			t.Errorf("Embed não deveria ter content, obtido '%s'", payload.Content)
+		}
+		if len(payload.Embeds) != 1 {
+			t.Fatalf("Esperado 1 embed, obtido %d", len(payload.Embeds))
+		}
+		embed := payload.Embeds[0]
+		if embed.Title != "Deploy" || embed.Description != "Versão publicada" {
+			t.Errorf("Embed inesperado: %+v", embed)
+		}
+		if embed.Color != 0xFF0000 {
+			t.Errorf("Esperado color %d, obtido %d", 0xFF0000, embed.Color)
+		}
+		if len(embed.Fields) != 1 || embed.Fields[0].Name != "Versão" || embed.Fields[0].Value != "1.2.3" {
+			t.Errorf("Campos inesperados: %+v", embed.Fields)
+		}
+		if payload.AvatarURL != "https://exemplo.com/avatar.png" {
+			t.Errorf("Esperado avatar_url sobrescrito, obtido '%s'", payload.AvatarURL)
+		}
+
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+	opts := SendOptions{
+		Subject: "Deploy",
+		Values: map[string][]string{
+			"color":  {"#FF0000"},
+			"field":  {"Versão=1.2.3"},
+			"avatar": {"https://exemplo.com/avatar.png"},
+		},
+	}
+	if err := provider.SendWithOptions("default", "Versão publicada", opts); err != nil {
+		t.Fatalf("Erro ao enviar embed: %v", err)
+	}
+}
+
+func TestDiscordProvider_Send_Attachment(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "log.txt")
+	if err := os.WriteFile(filePath, []byte("linha de log"), 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			t.Errorf("Esperado multipart/form-data, obtido '%s'", r.Header.Get("Content-Type"))
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("Erro ao ler multipart: %v", err)
+		}
+
+		var payload discordPayload
+		if err := json.Unmarshal([]byte(r.FormValue("payload_json")), &payload); err != nil {
+			t.Fatalf("payload_json inválido: %v", err)
+		}
+		if payload.Content != "Segue o log" {
+			t.Errorf("Esperado content 'Segue o log', obtido '%s'", payload.Content)
+		}
+		if len(payload.Attachments) != 1 || payload.Attachments[0]["filename"] != "log.txt" {
+			t.Errorf("Attachments inesperados: %v", payload.Attachments)
+		}
+
+		file, header, err := r.FormFile("files[0]")
+		if err != nil {
+			t.Fatalf("Arquivo files[0] ausente: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if header.Filename != "log.txt" || string(data) != "linha de log" {
+			t.Errorf("Arquivo inesperado: %s = %s", header.Filename, string(data))
+		}
+
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+	if err := provider.SendWithOptions("default", "Segue o log", SendOptions{Attachments: []string{filePath}}); err != nil {
+		t.Fatalf("Erro ao enviar anexo: %v", err)
+	}
+}
+
+func TestDiscordProvider_RateLimit_Retry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"You are being rate limited.","retry_after":2}`))
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+	if err := provider.Send("default", "Teste"); err != nil {
+		t.Fatalf("Erro após retry: %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Esperado 2 tentativas, obtido %d", attempts)
+	}
+	if len(sleeps) != 1 || sleeps[0] < time.Second || sleeps[0] > 2*time.Second {
+		t.Errorf("Esperado uma espera de ~2s, obtido %v", sleeps)
+	}
+}
+
+func TestDiscordProvider_RateLimit_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Bucket esgotado: próxima requisição deve aguardar o reset
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "1.5")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+
+	// Dois targets: o segundo envio deve respeitar o reset do primeiro
+	if err := provider.Send(server.URL+";"+server.URL, "Teste"); err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	if len(sleeps) != 1 || sleeps[0] < time.Second || sleeps[0] > 1500*time.Millisecond {
+		t.Errorf("Esperado uma espera de ~1.5s, obtido %v", sleeps)
+	}
+}
+
+func TestDiscordProvider_RateLimit_GiveUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0.01")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+	err := provider.Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Errorf("Esperado erro de rate limit, obtido: %v", err)
+	}
+}
+
+func TestDiscordProvider_Send_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Unknown Webhook","code":10015}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestDiscordProvider(server.URL, &sleeps)
+	err := provider.Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "webhook não encontrado") {
+		t.Errorf("Esperado erro de webhook não encontrado, obtido: %v", err)
+	}
+
+	// Mensagem acima do limite de content
+	err = provider.Send("default", strings.Repeat("a", discordMaxContent+1))
+	if err == nil || !strings.Contains(err.Error(), "limite") {
+		t.Errorf("Esperado erro de limite de caracteres, obtido: %v", err)
+	}
+}
+
+func TestParseDiscordColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{"#5865F2", 0x5865F2, false},
+		{"0x00ff00", 0x00FF00, false},
+		{"16711680", 0xFF0000, false},
+		{"red", discordColors["red"], false},
+		{"roxo-claro", 0, true},
+		{"#GGGGGG", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDiscordColor(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDiscordColor(%q) erro = %v, esperado erro = %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("parseDiscordColor(%q) = %d, esperado %d", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestDiscordConnection_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Teste de conexão não deveria publicar mensagens (método %s)", r.Method)
+		}
+		w.Write([]byte(`{"id":"1","name":"cast","channel_id":"123"}`))
+	}))
+	defer server.Close()
+
+	if err := testDiscordConnection(&config.DiscordConfig{WebhookURL: server.URL}); err != nil {
+		t.Errorf("Webhook válido não deveria retornar erro: %v", err)
+	}
+}
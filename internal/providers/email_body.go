@@ -0,0 +1,195 @@
+package providers
+
+import (
+	"fmt"
+	"html"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// emailBody é o conteúdo do email: o texto plano sempre presente e, opcionalmente,
+// a alternativa em HTML (enviadas juntas em multipart/alternative) e as imagens
+// embutidas que ela referencia (multipart/related).
+type emailBody struct {
+	Text   string
+	HTML   string
+	Inline []emailInline
+}
+
+// emailInline é uma imagem embutida no HTML, referenciada como cid:ContentID.
+type emailInline struct {
+	Path      string
+	ContentID string
+}
+
+// contentIDPattern reconhece os caracteres que não podem fazer parte de um Content-ID.
+var contentIDPattern = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// addInline embute a imagem e retorna o Content-ID, derivado do nome do arquivo
+// (chart.png vira cid:chart.png). A mesma imagem é embutida uma única vez.
+func (b *emailBody) addInline(path string) (string, error) {
+	for _, inline := range b.Inline {
+		if inline.Path == path {
+			return inline.ContentID, nil
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", fmt.Errorf("imagem embutida não encontrada: %s", path)
+	}
+	if mediaType := mime.TypeByExtension(filepath.Ext(path)); !strings.HasPrefix(mediaType, "image/") {
+		return "", fmt.Errorf("--inline aceita apenas imagens (png, jpg, gif, svg...): %s", path)
+	}
+
+	id := contentIDPattern.ReplaceAllString(filepath.Base(path), "_")
+	base := id
+	for n := 2; b.hasContentID(id); n++ {
+		id = fmt.Sprintf("%d-%s", n, base)
+	}
+	b.Inline = append(b.Inline, emailInline{Path: path, ContentID: id})
+	return id, nil
+}
+
+// hasContentID verifica se o Content-ID já está em uso por outra imagem.
+func (b *emailBody) hasContentID(id string) bool {
+	for _, inline := range b.Inline {
+		if inline.ContentID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalImageSrc verifica se o endereço de uma imagem no Markdown aponta para um
+// arquivo local (e não para uma URL, data: ou cid:).
+func isLocalImageSrc(src string) bool {
+	lower := strings.ToLower(src)
+	for _, prefix := range []string{"http://", "https://", "data:", "cid:", "//"} {
+		if strings.HasPrefix(lower, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildEmailBody monta o corpo do email a partir da mensagem e das flags de envio:
+// - --html-file: o arquivo é o HTML; a mensagem (ou o texto extraído do HTML) é o texto plano
+// - --html: a mensagem é HTML; o texto plano é gerado removendo as tags
+// - --markdown: a mensagem é Markdown, convertida em HTML; o próprio Markdown é o texto plano
+// - --inline: imagens embutidas, referenciadas no HTML como cid:<nome do arquivo>
+// Fragmentos HTML (sem <html>) são envolvidos no layout padrão do CAST. No Markdown,
+// imagens que apontam para arquivos locais (![gráfico](chart.png)) são embutidas
+// automaticamente; com --inline e sem HTML, o texto é convertido em HTML e as
+// imagens são exibidas abaixo dele.
+func buildEmailBody(message string, subject string, opts SendOptions) (emailBody, error) {
+	var body emailBody
+	for _, path := range opts.GetAll("inline") {
+		if _, err := body.addInline(path); err != nil {
+			return emailBody{}, err
+		}
+	}
+	explicit := len(body.Inline)
+
+	switch {
+	case opts.Get("html-file") != "":
+		path := opts.Get("html-file")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return emailBody{}, fmt.Errorf("erro ao ler arquivo HTML %s: %w", path, err)
+		}
+		body.Text, body.HTML = message, emailHTMLDocument(subject, string(data))
+		if strings.TrimSpace(body.Text) == "" {
+			body.Text = htmlToPlain(string(data))
+		}
+
+	case opts.Get("html") == "true":
+		body.Text, body.HTML = htmlToPlain(message), emailHTMLDocument(subject, message)
+
+	case opts.Get("markdown") == "true":
+		var inlineErr error
+		renderer := &markdownRenderer{ImageSrc: func(src string) string {
+			if !isLocalImageSrc(src) || inlineErr != nil {
+				return src
+			}
+			id, err := body.addInline(src)
+			if err != nil {
+				inlineErr = err
+				return src
+			}
+			return "cid:" + id
+		}}
+		content := renderer.render(message)
+		if inlineErr != nil {
+			return emailBody{}, inlineErr
+		}
+		body.Text, body.HTML = message, emailHTMLDocument(subject, content)
+
+	case explicit > 0:
+		// Imagens sem corpo HTML: o texto vira HTML e as imagens são exibidas abaixo dele
+		content := "<p>" + strings.ReplaceAll(html.EscapeString(message), "\n", "<br>\n") + "</p>"
+		for _, inline := range body.Inline {
+			content += "\n<p><img src=\"cid:" + inline.ContentID + "\" alt=\"" + html.EscapeString(filepath.Base(inline.Path)) + "\"></p>"
+		}
+		body.Text, body.HTML = message, emailHTMLDocument(subject, content)
+
+	default:
+		body.Text = message
+	}
+	return body, nil
+}
+
+// htmlDocumentPattern reconhece documentos HTML completos, enviados sem alteração.
+var htmlDocumentPattern = regexp.MustCompile(`(?i)^\s*(<!doctype\s+html|<html[\s>])`)
+
+// emailHTMLDocument retorna o HTML final do email: documentos completos são mantidos
+// como estão; fragmentos recebem estilos inline e o layout padrão.
+func emailHTMLDocument(subject string, content string) string {
+	if htmlDocumentPattern.MatchString(content) {
+		return content
+	}
+	return emailHTMLLayout(subject, emailInlineStyles.Replace(content))
+}
+
+// emailInlineStyles aplica estilos inline às tags sem atributos, já que muitos clientes
+// de email (Gmail, Outlook) ignoram blocos <style>.
+var emailInlineStyles = strings.NewReplacer(
+	"<h1>", `<h1 style="margin:0 0 16px;font-size:22px;line-height:1.3;color:#111827;">`,
+	"<h2>", `<h2 style="margin:24px 0 12px;font-size:18px;line-height:1.3;color:#111827;">`,
+	"<h3>", `<h3 style="margin:20px 0 8px;font-size:16px;line-height:1.3;color:#111827;">`,
+	"<p>", `<p style="margin:0 0 12px;">`,
+	"<ul>", `<ul style="margin:0 0 12px;padding-left:24px;">`,
+	"<ol>", `<ol style="margin:0 0 12px;padding-left:24px;">`,
+	"<blockquote>", `<blockquote style="margin:0 0 12px;padding:4px 12px;border-left:4px solid #d1d5db;color:#4b5563;">`,
+	"<pre>", `<pre style="margin:0 0 12px;padding:12px;background-color:#f3f4f6;border-radius:4px;overflow-x:auto;">`,
+	"<code>", `<code style="font-family:Consolas,Menlo,monospace;font-size:13px;background-color:#f3f4f6;">`,
+	"<hr>", `<hr style="border:none;border-top:1px solid #e5e7eb;margin:20px 0;">`,
+	`<a href=`, `<a style="color:#2563eb;" href=`,
+	`<img src=`, `<img style="max-width:100%;height:auto;" src=`,
+)
+
+// emailHTMLLayout envolve o conteúdo em um layout de tabela com estilos inline,
+// compatível com os principais clientes de email.
+func emailHTMLLayout(subject string, content string) string {
+	return `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>` + html.EscapeString(subject) + `</title>
+</head>
+<body style="margin:0;padding:0;background-color:#f4f5f7;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="background-color:#f4f5f7;">
+<tr><td align="center" style="padding:24px 12px;">
+<table role="presentation" width="600" cellpadding="0" cellspacing="0" border="0" style="max-width:600px;width:100%;background-color:#ffffff;border-radius:6px;">
+<tr><td style="padding:24px 32px;font-family:Arial,Helvetica,sans-serif;font-size:15px;line-height:1.6;color:#1f2933;">
+` + content + `
+</td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>`
+}
@@ -0,0 +1,418 @@
+package providers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// dkimDefaultHeaders são os headers assinados quando dkim_headers não é configurado.
+// Apenas os presentes na mensagem entram na assinatura.
+var dkimDefaultHeaders = []string{
+	"From", "To", "Cc", "Subject", "Date", "Message-ID", "Reply-To",
+	"MIME-Version", "Content-Type", "Content-Transfer-Encoding",
+	"List-Unsubscribe", "List-Unsubscribe-Post",
+}
+
+// dkimWSPPattern reconhece sequências de espaços e tabs (WSP), reduzidas a um espaço
+// na canonicalização relaxed.
+var dkimWSPPattern = regexp.MustCompile(`[ \t]+`)
+
+// dkimSignatureValuePattern reconhece o valor da tag b= (a assinatura), removido do
+// próprio header DKIM-Signature ao calcular e verificar a assinatura.
+var dkimSignatureValuePattern = regexp.MustCompile(`(;\s*b=)[^;]*`)
+
+// dkimSigner assina mensagens com DKIM (RFC 6376), canonicalização relaxed/relaxed.
+type dkimSigner struct {
+	Domain   string
+	Selector string
+	Key      crypto.Signer // *rsa.PrivateKey (rsa-sha256) ou ed25519.PrivateKey (ed25519-sha256)
+	Headers  []string
+}
+
+// newDKIMSigner cria o assinador a partir da configuração do email.
+// Retorna nil (sem erro) quando o DKIM não está configurado.
+func newDKIMSigner(cfg *config.EmailConfig) (*dkimSigner, error) {
+	if cfg == nil || (cfg.DKIMDomain == "" && cfg.DKIMSelector == "" && cfg.DKIMPrivateKey == "") {
+		return nil, nil
+	}
+	if cfg.DKIMDomain == "" || cfg.DKIMSelector == "" || cfg.DKIMPrivateKey == "" {
+		return nil, fmt.Errorf("DKIM incompleto: configure dkim_domain, dkim_selector e dkim_private_key")
+	}
+	key, err := loadDKIMKey(cfg.DKIMPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := cfg.DKIMHeaders
+	if len(headers) == 0 {
+		headers = dkimDefaultHeaders
+	}
+	// From é obrigatório na assinatura (RFC 6376, seção 5.4)
+	hasFrom := false
+	for _, name := range headers {
+		hasFrom = hasFrom || strings.EqualFold(strings.TrimSpace(name), "from")
+	}
+	if !hasFrom {
+		headers = append([]string{"From"}, headers...)
+	}
+
+	return &dkimSigner{Domain: cfg.DKIMDomain, Selector: cfg.DKIMSelector, Key: key, Headers: headers}, nil
+}
+
+// loadDKIMKey carrega a chave privada (PKCS#1 ou PKCS#8, RSA ou Ed25519) de um arquivo
+// PEM, do próprio PEM ou de uma referência ${env:NOME} / ${file:/caminho}.
+func loadDKIMKey(value string) (crypto.Signer, error) {
+	data := []byte(value)
+	switch {
+	case IsSecretRef(value):
+		resolved, err := ResolveSecretRefs(value)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao resolver chave DKIM: %w", err)
+		}
+		data = []byte(resolved)
+	case !strings.Contains(value, "-----BEGIN"):
+		content, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler chave DKIM %s: %w", value, err)
+		}
+		data = content
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("chave DKIM inválida: bloco PEM não encontrado")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("chave DKIM inválida: %w", err)
+	}
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("chave DKIM inválida: use uma chave RSA ou Ed25519")
+}
+
+// Algorithm retorna o algoritmo da tag a= conforme o tipo da chave.
+func (s *dkimSigner) Algorithm() string {
+	if _, ok := s.Key.(ed25519.PrivateKey); ok {
+		return "ed25519-sha256"
+	}
+	return "rsa-sha256"
+}
+
+// Sign assina a mensagem e retorna-a com o header DKIM-Signature no início.
+func (s *dkimSigner) Sign(raw []byte) ([]byte, error) {
+	headers, body := splitEmailMessage(raw)
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+
+	// Cada ocorrência de um header configurado entra na tag h=
+	var signed []string
+	for _, name := range s.Headers {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for _, header := range headers {
+			if strings.EqualFold(emailHeaderName(header), name) {
+				signed = append(signed, name)
+			}
+		}
+	}
+
+	value := fmt.Sprintf("v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s; t=%d; h=%s; bh=%s; b=",
+		s.Algorithm(), s.Domain, s.Selector, time.Now().Unix(), strings.Join(signed, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]))
+	data := dkimHeaderData(headers, signed) + dkimRelaxedHeader("DKIM-Signature: "+value)
+	digest := sha256.Sum256([]byte(data))
+
+	var signature []byte
+	var err error
+	switch key := s.Key.(type) {
+	case ed25519.PrivateKey:
+		// RFC 8463: o Ed25519 assina o hash SHA-256 dos dados
+		signature = ed25519.Sign(key, digest[:])
+	default:
+		signature, err = s.Key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao assinar DKIM: %w", err)
+	}
+
+	// A assinatura é quebrada em blocos para que o header possa ser dobrado (FWS é ignorado em b=)
+	encoded := base64.StdEncoding.EncodeToString(signature)
+	var chunks []string
+	for len(encoded) > 64 {
+		chunks, encoded = append(chunks, encoded[:64]), encoded[64:]
+	}
+	chunks = append(chunks, encoded)
+
+	var out bytes.Buffer
+	writeHeader(&out, "DKIM-Signature", value+strings.Join(chunks, " "))
+	out.Write(raw)
+	return out.Bytes(), nil
+}
+
+// verifyDKIM verifica o primeiro header DKIM-Signature da mensagem com a chave pública
+// informada e retorna as tags da assinatura.
+func verifyDKIM(raw []byte, publicKey crypto.PublicKey) (map[string]string, error) {
+	headers, body := splitEmailMessage(raw)
+	var signatureHeader string
+	for _, header := range headers {
+		if strings.EqualFold(emailHeaderName(header), "dkim-signature") {
+			signatureHeader = header
+			break
+		}
+	}
+	if signatureHeader == "" {
+		return nil, fmt.Errorf("mensagem sem header DKIM-Signature")
+	}
+
+	canonical := dkimRelaxedHeader(signatureHeader)
+	tags := parseDKIMTags(canonical[strings.Index(canonical, ":")+1:])
+	if tags["c"] != "relaxed/relaxed" {
+		return nil, fmt.Errorf("canonicalização não suportada: '%s'", tags["c"])
+	}
+
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+	if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+		return nil, fmt.Errorf("hash do corpo (bh) não confere: a mensagem foi alterada")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		return nil, fmt.Errorf("assinatura (b) inválida: %w", err)
+	}
+	var signed []string
+	for _, name := range strings.Split(tags["h"], ":") {
+		signed = append(signed, strings.ToLower(strings.TrimSpace(name)))
+	}
+	data := dkimHeaderData(headers, signed) + dkimSignatureValuePattern.ReplaceAllString(canonical, "${1}")
+	digest := sha256.Sum256([]byte(data))
+
+	var valid bool
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if tags["a"] != "rsa-sha256" {
+			return nil, fmt.Errorf("algoritmo '%s' não corresponde à chave RSA", tags["a"])
+		}
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		if tags["a"] != "ed25519-sha256" {
+			return nil, fmt.Errorf("algoritmo '%s' não corresponde à chave Ed25519", tags["a"])
+		}
+		valid = ed25519.Verify(key, digest[:], signature)
+	default:
+		return nil, fmt.Errorf("tipo de chave pública não suportado")
+	}
+	if !valid {
+		return nil, fmt.Errorf("assinatura DKIM inválida: os headers assinados foram alterados ou a chave não confere")
+	}
+	return tags, nil
+}
+
+// parseDKIMTags interpreta uma lista de tags "nome=valor; ..." (DKIM-Signature ou
+// registro DNS). Espaços são removidos dos valores.
+func parseDKIMTags(value string) map[string]string {
+	tags := map[string]string{}
+	for _, tag := range strings.Split(value, ";") {
+		name, tagValue, ok := strings.Cut(tag, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = dkimWSPPattern.ReplaceAllString(strings.TrimSpace(tagValue), "")
+	}
+	return tags
+}
+
+// splitEmailMessage separa os headers (com as dobras preservadas) e o corpo da mensagem.
+func splitEmailMessage(raw []byte) ([]string, []byte) {
+	head, body, _ := bytes.Cut(raw, []byte("\r\n\r\n"))
+	var headers []string
+	for _, line := range strings.Split(string(head), "\r\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(headers) > 0 {
+			headers[len(headers)-1] += "\r\n" + line
+			continue
+		}
+		headers = append(headers, line)
+	}
+	return headers, body
+}
+
+// emailHeaderName retorna o nome de um header "Nome: valor".
+func emailHeaderName(header string) string {
+	name, _, _ := strings.Cut(header, ":")
+	return strings.TrimSpace(name)
+}
+
+// dkimHeaderData concatena os headers assinados, canonicalizados. Headers repetidos
+// são usados de baixo para cima; nomes sem ocorrência não contribuem (RFC 6376, 5.4.2).
+func dkimHeaderData(headers []string, signed []string) string {
+	used := map[int]bool{}
+	var data strings.Builder
+	for _, name := range signed {
+		for i := len(headers) - 1; i >= 0; i-- {
+			if !used[i] && strings.EqualFold(emailHeaderName(headers[i]), name) {
+				used[i] = true
+				data.WriteString(dkimRelaxedHeader(headers[i]) + "\r\n")
+				break
+			}
+		}
+	}
+	return data.String()
+}
+
+// dkimRelaxedHeader aplica a canonicalização relaxed a um header: nome em minúsculas,
+// dobras removidas, espaços reduzidos a um e removidos nas pontas do valor.
+func dkimRelaxedHeader(header string) string {
+	name, value, _ := strings.Cut(header, ":")
+	value = dkimWSPPattern.ReplaceAllString(strings.ReplaceAll(value, "\r\n", ""), " ")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.TrimSpace(value)
+}
+
+// dkimRelaxedBody aplica a canonicalização relaxed ao corpo: espaços reduzidos a um,
+// removidos no fim das linhas, e linhas vazias no final descartadas.
+func dkimRelaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(dkimWSPPattern.ReplaceAllString(line, " "), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// dkimDNSRecord retorna o registro TXT a publicar em <seletor>._domainkey.<domínio>.
+func dkimDNSRecord(publicKey crypto.PublicKey) (string, error) {
+	if key, ok := publicKey.(ed25519.PublicKey); ok {
+		return "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(key), nil
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(der), nil
+}
+
+// parseDKIMRecord extrai a chave pública de um registro TXT DKIM.
+func parseDKIMRecord(record string) (crypto.PublicKey, error) {
+	tags := parseDKIMTags(record)
+	data, err := base64.StdEncoding.DecodeString(tags["p"])
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("registro DKIM sem chave pública (p=) válida")
+	}
+	if tags["k"] == "ed25519" {
+		if len(data) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("chave Ed25519 inválida no registro DKIM")
+		}
+		return ed25519.PublicKey(data), nil
+	}
+	if key, err := x509.ParsePKIXPublicKey(data); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKCS1PublicKey(data)
+}
+
+// DKIMCheck é o resultado da verificação da configuração DKIM.
+type DKIMCheck struct {
+	Domain    string
+	Selector  string
+	Algorithm string
+	Headers   []string // Headers assinados na mensagem de teste
+	DNSName   string   // <seletor>._domainkey.<domínio>
+	Record    string   // Registro TXT correspondente à chave privada
+	DNSErr    error    // Nil se a chave publicada no DNS confere com a chave privada
+}
+
+// CheckDKIM assina uma mensagem de teste com a configuração do email e verifica a
+// assinatura localmente, com a chave pública derivada da chave privada. Se lookupTXT
+// for informado, também verifica a assinatura com a chave publicada no DNS.
+func CheckDKIM(cfg *config.EmailConfig, lookupTXT func(name string) ([]string, error)) (*DKIMCheck, error) {
+	signer, err := newDKIMSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("DKIM não configurado (dkim_domain, dkim_selector e dkim_private_key)")
+	}
+
+	fromEmail := cfg.FromEmail
+	if fromEmail == "" {
+		fromEmail = "noreply@" + signer.Domain
+	}
+	provider := &emailProvider{config: cfg}
+	raw, err := provider.buildMessage(&emailMessage{
+		FromName:  cfg.FromName,
+		FromEmail: fromEmail,
+		To:        []string{fromEmail},
+		Subject:   "Teste DKIM do CAST",
+		MessageID: generateMessageID(signer.Domain),
+		Body:      emailBody{Text: "Mensagem de teste da assinatura DKIM.\n"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags, err := verifyDKIM(raw, signer.Key.Public())
+	if err != nil {
+		return nil, err
+	}
+	record, err := dkimDNSRecord(signer.Key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar registro DKIM: %w", err)
+	}
+
+	check := &DKIMCheck{
+		Domain:    signer.Domain,
+		Selector:  signer.Selector,
+		Algorithm: tags["a"],
+		Headers:   strings.Split(tags["h"], ":"),
+		DNSName:   signer.Selector + "._domainkey." + signer.Domain,
+		Record:    record,
+	}
+	if lookupTXT != nil {
+		check.DNSErr = verifyDKIMRecord(raw, check.DNSName, lookupTXT)
+	}
+	return check, nil
+}
+
+// verifyDKIMRecord verifica a mensagem assinada com a chave publicada no DNS.
+func verifyDKIMRecord(raw []byte, name string, lookupTXT func(name string) ([]string, error)) error {
+	records, err := lookupTXT(name)
+	if err != nil {
+		return fmt.Errorf("registro TXT %s não encontrado: %w", name, err)
+	}
+	for _, record := range records {
+		if !strings.Contains(record, "p=") {
+			continue
+		}
+		publicKey, err := parseDKIMRecord(record)
+		if err != nil {
+			return err
+		}
+		if _, err := verifyDKIM(raw, publicKey); err != nil {
+			return fmt.Errorf("a chave publicada em %s não confere com a chave privada configurada", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("registro TXT %s não contém uma chave DKIM (p=)", name)
+}
@@ -0,0 +1,144 @@
+package providers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// writeDKIMKey gera uma chave RSA e grava em PEM (PKCS#1) no diretório temporário.
+func writeDKIMKey(t *testing.T) (string, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "dkim.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, key
+}
+
+func TestEmailProvider_Send_DKIMSigned(t *testing.T) {
+	keyPath, key := writeDKIMKey(t)
+	server, cfg := newFakeSMTPServer(t)
+	cfg.DKIMDomain = "empresa.com"
+	cfg.DKIMSelector = "cast"
+	cfg.DKIMPrivateKey = keyPath
+
+	provider := NewEmailProviderExtended(cfg)
+	_, err := provider.SendEmailWithOptions("admin@empresa.com", "Relatório   diário\n\n\n", SendOptions{
+		Subject: "Relatório de vendas com um assunto bem longo para forçar a dobra do header",
+		Values:  map[string][]string{"bcc": {"oculto@empresa.com"}, "header": {"X-Ticket=42"}},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+	// O servidor entrega os dados com quebras LF (ReadDotBytes); no fio são CRLF
+	raw := []byte(strings.ReplaceAll(string(server.Messages()[0].Data), "\n", "\r\n"))
+	if !strings.HasPrefix(string(raw), "DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=empresa.com; s=cast;") {
+		t.Fatalf("A mensagem deveria começar com o header DKIM-Signature:\n%s", raw[:200])
+	}
+
+	tags, err := verifyDKIM(raw, &key.PublicKey)
+	if err != nil {
+		t.Fatalf("Assinatura deveria ser válida: %v", err)
+	}
+	if tags["h"] != "from:to:subject:date:message-id:mime-version:content-type:content-transfer-encoding" {
+		t.Errorf("Headers assinados inesperados: %s", tags["h"])
+	}
+
+	// Relaxed: espaços e dobras alterados em trânsito não invalidam a assinatura
+	refolded := strings.Replace(string(raw), "Subject: ", "Subject:   ", 1)
+	refolded = strings.Replace(refolded, "di=C3=A1rio", "\t di=C3=A1rio  ", 1) + "\r\n\r\n"
+	if _, err := verifyDKIM([]byte(refolded), &key.PublicKey); err != nil {
+		t.Errorf("Espaços extras não deveriam invalidar a assinatura relaxed: %v", err)
+	}
+
+	tampered := map[string]string{
+		"bh":         strings.Replace(string(raw), "di=C3=A1rio", "diario", 1),
+		"assinatura": strings.Replace(string(raw), "Message-ID: <", "Message-ID: <x", 1),
+	}
+	for contains, message := range tampered {
+		if _, err := verifyDKIM([]byte(message), &key.PublicKey); err == nil || !strings.Contains(err.Error(), contains) {
+			t.Errorf("Alteração deveria invalidar (%s), obtido: %v", contains, err)
+		}
+	}
+}
+
+func TestCheckDKIM(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CAST_TEST_DKIM_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+
+	cfg := &config.EmailConfig{
+		FromEmail:      "cast@empresa.com",
+		DKIMDomain:     "empresa.com",
+		DKIMSelector:   "2026",
+		DKIMPrivateKey: "${env:CAST_TEST_DKIM_KEY}",
+		DKIMHeaders:    []string{"Subject", "Date"},
+	}
+	record, _ := dkimDNSRecord(edKey.Public())
+	var lookedUp string
+	check, err := CheckDKIM(cfg, func(name string) ([]string, error) {
+		lookedUp = name
+		return []string{"v=spf1 -all", record}, nil
+	})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if check.Algorithm != "ed25519-sha256" || strings.Join(check.Headers, ":") != "from:subject:date" {
+		t.Errorf("Verificação inesperada: %+v", check)
+	}
+	if lookedUp != "2026._domainkey.empresa.com" || check.DNSErr != nil {
+		t.Errorf("DNS deveria conferir em 2026._domainkey.empresa.com: %s (%v)", lookedUp, check.DNSErr)
+	}
+
+	// Chave publicada diferente da configurada
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	otherRecord, _ := dkimDNSRecord(otherKey)
+	check, err = CheckDKIM(cfg, func(string) ([]string, error) { return []string{otherRecord}, nil })
+	if err != nil || check.DNSErr == nil || !strings.Contains(check.DNSErr.Error(), "não confere") {
+		t.Errorf("Chave publicada diferente deveria ser apontada: %v / %+v", err, check)
+	}
+}
+
+func TestNewDKIMSigner_Errors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalida.pem")
+	os.WriteFile(invalid, []byte("não é uma chave"), 0600)
+
+	tests := []struct {
+		cfg      config.EmailConfig
+		contains string
+	}{
+		{config.EmailConfig{DKIMDomain: "empresa.com"}, "DKIM incompleto"},
+		{config.EmailConfig{DKIMDomain: "empresa.com", DKIMSelector: "cast", DKIMPrivateKey: filepath.Join(dir, "ausente.pem")}, "erro ao ler chave DKIM"},
+		{config.EmailConfig{DKIMDomain: "empresa.com", DKIMSelector: "cast", DKIMPrivateKey: invalid}, "PEM não encontrado"},
+		{config.EmailConfig{DKIMDomain: "empresa.com", DKIMSelector: "cast", DKIMPrivateKey: "${env:CAST_TEST_DKIM_AUSENTE}"}, "não definida"},
+	}
+	for _, tt := range tests {
+		if _, err := newDKIMSigner(&tt.cfg); err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("%+v: esperado erro contendo '%s', obtido: %v", tt.cfg, tt.contains, err)
+		}
+	}
+
+	if signer, err := newDKIMSigner(&config.EmailConfig{}); signer != nil || err != nil {
+		t.Error("Sem configuração DKIM, nenhum assinador deveria ser criado")
+	}
+}
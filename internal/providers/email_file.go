@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+)
+
+// mboxFromLine casa as linhas do corpo que precisam de escape no formato mboxrd.
+var mboxFromLine = regexp.MustCompile(`(?m)^(>*From )`)
+
+// writeEmailFiles grava as mensagens em vez de enviá-las: --to-file com exatamente os bytes
+// que seriam transmitidos ao servidor SMTP (.eml) e --mbox acrescentando-as a um arquivo
+// mbox. "-" grava na saída padrão. Retorna false se nenhuma das flags foi usada.
+func writeEmailFiles(msgs []*emailMessage, raws [][]byte, opts SendOptions) (bool, error) {
+	emlPath, mboxPath := opts.Get("to-file"), opts.Get("mbox")
+	if emlPath == "" && mboxPath == "" {
+		return false, nil
+	}
+	if emlPath == "-" && mboxPath == "-" {
+		return true, fmt.Errorf("--to-file - e --mbox - não podem ser usados juntos: a saída padrão misturaria os dois formatos")
+	}
+
+	if emlPath != "" {
+		if len(raws) > 1 {
+			return true, fmt.Errorf("--to-file grava um único email; para as %d mensagens do lote use --mbox", len(raws))
+		}
+		if err := writeEmailOutput(emlPath, false, raws[0]); err != nil {
+			return true, fmt.Errorf("erro ao gravar %s: %w", emlPath, err)
+		}
+	}
+	if mboxPath != "" {
+		var mbox bytes.Buffer
+		for i, msg := range msgs {
+			writeMboxMessage(&mbox, msg.FromEmail, raws[i])
+		}
+		if err := writeEmailOutput(mboxPath, true, mbox.Bytes()); err != nil {
+			return true, fmt.Errorf("erro ao gravar %s: %w", mboxPath, err)
+		}
+	}
+	return true, nil
+}
+
+// writeEmailOutput grava os dados no arquivo (sobrescrevendo ou, com appendTo, acrescentando)
+// ou na saída padrão com "-".
+func writeEmailOutput(path string, appendTo bool, data []byte) error {
+	if path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendTo {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0640)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeMboxMessage escreve a mensagem no formato mboxrd: linha separadora "From remetente
+// data", quebras de linha LF, linhas iniciadas por "From " (com ou sem ">") escapadas
+// com ">" e uma linha em branco ao final.
+func writeMboxMessage(w io.Writer, from string, raw []byte) {
+	body := bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))
+	body = mboxFromLine.ReplaceAll(body, []byte(">$1"))
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		body = append(body, '\n')
+	}
+	fmt.Fprintf(w, "From %s %s\n", from, time.Now().UTC().Format(time.ANSIC))
+	w.Write(body)
+	w.Write([]byte("\n"))
+}
@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmailProvider_Send_ToFile(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+	path := filepath.Join(t.TempDir(), "saida.eml")
+
+	messageID, err := provider.SendEmailWithOptions("admin@empresa.com", "Relatório pronto", SendOptions{
+		Subject: "Relatório",
+		Values:  map[string][]string{"to-file": {path}},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao gravar: %v", err)
+	}
+	if server.Conns() != 0 {
+		t.Errorf("Com --to-file nada deveria ser enviado, obtido %d conexões", server.Conns())
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Os bytes do arquivo são os transmitidos no DATA: CRLF e quoted-printable
+	if !bytes.Contains(raw, []byte("\r\nMessage-ID: "+messageID+"\r\n")) || !bytes.Contains(raw, []byte("Relat=C3=B3rio pronto")) {
+		t.Errorf("Conteúdo inesperado:\n%s", raw)
+	}
+	msg, parts := readMIMEMessage(t, raw)
+	if decodedHeader(t, msg, "Subject") != "Relatório" || parts["text/plain"] != "Relatório pronto" {
+		t.Errorf("Mensagem gravada inválida: %v", parts)
+	}
+}
+
+func TestEmailProvider_Send_ToFileAndMboxStdout(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+
+	_, err := provider.SendEmailWithOptions("admin@empresa.com", "Relatório pronto", SendOptions{
+		Values: map[string][]string{"to-file": {"-"}, "mbox": {"-"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "não podem ser usados juntos") {
+		t.Errorf("Esperado erro com --to-file - e --mbox -, obtido: %v", err)
+	}
+	if server.Conns() != 0 {
+		t.Errorf("Nada deveria ser enviado, obtido %d conexões", server.Conns())
+	}
+}
+
+func TestEmailProvider_Send_Mbox(t *testing.T) {
+	_, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+	path := filepath.Join(t.TempDir(), "arquivo.mbox")
+	opts := SendOptions{Values: map[string][]string{"mbox": {path}, "individual": {"true"}}}
+
+	if _, err := provider.SendEmailWithOptions("a@empresa.com;b@empresa.com", "Olá\nFrom here\n>From there", opts); err != nil {
+		t.Fatalf("Erro ao gravar: %v", err)
+	}
+	// O arquivo é acrescentado, não sobrescrito
+	if _, err := provider.SendEmailWithOptions("c@empresa.com", "Terceira", opts); err != nil {
+		t.Fatalf("Erro ao gravar: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	if separators := regexp.MustCompile(`(?m)^From cast@empresa\.com `).FindAllString(content, -1); len(separators) != 3 {
+		t.Errorf("Esperadas 3 mensagens no mbox:\n%s", content)
+	}
+	if strings.Contains(content, "\r\n") {
+		t.Error("O mbox deveria usar quebras de linha LF")
+	}
+	if !strings.Contains(content, "\n>From here\n") || !strings.Contains(content, "\n>>From there\n") {
+		t.Errorf("Linhas iniciadas por From deveriam ser escapadas (mboxrd):\n%s", content)
+	}
+
+	// --to-file não acomoda um lote
+	opts.Values = map[string][]string{"to-file": {path + ".eml"}, "individual": {"true"}}
+	if _, err := provider.SendEmailWithOptions("a@empresa.com;b@empresa.com", "Olá", opts); err == nil || !strings.Contains(err.Error(), "use --mbox") {
+		t.Errorf("Esperado erro sugerindo --mbox, obtido: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package providers
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// emailMessage reúne os dados usados para montar a mensagem MIME.
+type emailMessage struct {
+	FromName    string
+	FromEmail   string
+	To          []string
+	Cc          []string
+	Bcc         []string // Apenas no envelope SMTP, nunca nos headers
+	Subject     string
+	Date        time.Time // Zero: horário do envio
+	MessageID   string
+	Headers     [][2]string // Headers adicionais (Reply-To, prioridade, List-Unsubscribe, --header)
+	Body        emailBody
+	Attachments []string
+}
+
+// Recipients retorna os destinatários do envelope SMTP (To, Cc e Bcc), sem repetições.
+// Endereços no formato "Nome <email>" entram no envelope apenas com o email.
+func (m *emailMessage) Recipients() []string {
+	seen := map[string]bool{}
+	var recipients []string
+	for _, list := range [][]string{m.To, m.Cc, m.Bcc} {
+		for _, address := range list {
+			if parsed, err := mail.ParseAddress(address); err == nil {
+				address = parsed.Address
+			}
+			key := strings.ToLower(address)
+			if !seen[key] {
+				seen[key] = true
+				recipients = append(recipients, address)
+			}
+		}
+	}
+	return recipients
+}
+
+// emailReservedHeaders são os headers montados pelo próprio provider, que não podem
+// ser definidos com --header.
+var emailReservedHeaders = map[string]bool{
+	"from":                      true,
+	"to":                        true,
+	"cc":                        true,
+	"bcc":                       true,
+	"subject":                   true,
+	"message-id":                true,
+	"date":                      true,
+	"mime-version":              true,
+	"content-type":              true,
+	"content-transfer-encoding": true,
+}
+
+// emailAddressHeaders são os headers adicionais que contêm listas de endereços. Os
+// valores são formatados com formatAddressList, que já codifica os nomes, e não passam
+// por encodeHeaderValue, que codificaria também os endereços.
+var emailAddressHeaders = map[string]bool{
+	"reply-to": true,
+}
+
+// emailPriorityHeaders mapeia --priority para os headers X-Priority, Importance e Priority
+// (Outlook, Thunderbird e RFC 2156). "default" não gera headers.
+var emailPriorityHeaders = map[string][][2]string{
+	"min":    {{"X-Priority", "5 (Lowest)"}, {"Importance", "low"}, {"Priority", "non-urgent"}},
+	"low":    {{"X-Priority", "4 (Low)"}, {"Importance", "low"}, {"Priority", "non-urgent"}},
+	"high":   {{"X-Priority", "2 (High)"}, {"Importance", "high"}, {"Priority", "urgent"}},
+	"urgent": {{"X-Priority", "1 (Highest)"}, {"Importance", "high"}, {"Priority", "urgent"}},
+}
+
+// parseEmailAddresses interpreta listas de endereços (--cc, --bcc), separados por
+// vírgula ou ponto-e-vírgula e opcionalmente repetidos, validando cada endereço.
+func parseEmailAddresses(flag string, values []string) ([]string, error) {
+	var addresses []string
+	for _, value := range values {
+		for _, address := range config.ParseTargets(value) {
+			if _, err := mail.ParseAddress(address); err != nil {
+				return nil, fmt.Errorf("--%s: endereço inválido '%s'", flag, address)
+			}
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+// buildEmailHeaders monta os headers adicionais a partir das flags de envio:
+// --reply-to, --priority, --list-unsubscribe e --header Nome=valor.
+func buildEmailHeaders(opts SendOptions) ([][2]string, error) {
+	var headers [][2]string
+
+	if replyTo := opts.Get("reply-to"); replyTo != "" {
+		addresses, err := parseEmailAddresses("reply-to", []string{replyTo})
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, [2]string{"Reply-To", formatAddressList(addresses)})
+	}
+
+	if priority := strings.ToLower(strings.TrimSpace(opts.Get("priority"))); priority != "" && priority != "default" {
+		priorityHeaders, ok := emailPriorityHeaders[priority]
+		if !ok {
+			return nil, fmt.Errorf("prioridade inválida para email: '%s' (use min, low, default, high ou urgent)", priority)
+		}
+		headers = append(headers, priorityHeaders...)
+	}
+
+	if unsubscribe := opts.GetAll("list-unsubscribe"); len(unsubscribe) > 0 {
+		var targets []string
+		oneClick := false
+		for _, value := range unsubscribe {
+			value = strings.Trim(strings.TrimSpace(value), "<>")
+			lower := strings.ToLower(value)
+			if !strings.HasPrefix(lower, "mailto:") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "http://") {
+				return nil, fmt.Errorf("--list-unsubscribe inválido: '%s' (use mailto:endereço ou URL http(s))", value)
+			}
+			oneClick = oneClick || strings.HasPrefix(lower, "https://")
+			targets = append(targets, "<"+value+">")
+		}
+		headers = append(headers, [2]string{"List-Unsubscribe", strings.Join(targets, ", ")})
+		if oneClick {
+			// RFC 8058: permite o descadastro com um clique (exigido por Gmail e Yahoo para envios em massa)
+			headers = append(headers, [2]string{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"})
+		}
+	}
+
+	for _, header := range opts.GetAll("header") {
+		name, value, ok := strings.Cut(header, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("--header inválido: '%s' (use Nome=valor)", header)
+		}
+		if !validEmailHeaderName(name) {
+			return nil, fmt.Errorf("--header inválido: nome '%s' contém caracteres não permitidos", name)
+		}
+		if emailReservedHeaders[strings.ToLower(name)] {
+			return nil, fmt.Errorf("--header: '%s' é definido pelo CAST e não pode ser sobrescrito", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("--header inválido: o valor de '%s' não pode conter quebras de linha", name)
+		}
+		value = strings.TrimSpace(value)
+		if emailAddressHeaders[strings.ToLower(name)] {
+			addresses, err := parseEmailAddresses("header "+name, []string{value})
+			if err != nil {
+				return nil, err
+			}
+			value = formatAddressList(addresses)
+		}
+		headers = append(headers, [2]string{name, value})
+	}
+
+	return headers, nil
+}
+
+// validEmailHeaderName verifica se o nome contém apenas caracteres imprimíveis
+// ASCII, sem espaço e sem ':' (RFC 5322, seção 2.2).
+func validEmailHeaderName(name string) bool {
+	for _, r := range name {
+		if r <= ' ' || r > '~' || r == ':' {
+			return false
+		}
+	}
+	return true
+}
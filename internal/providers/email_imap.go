@@ -26,16 +26,19 @@ var (
 
 // WaitForEmailResponse aguarda por uma resposta de email via IMAP.
 // Retorna nil se uma resposta for encontrada, ou um erro específico caso contrário.
+// Com rules, apenas respostas dos remetentes aceitos são consideradas e o texto da resposta
+// decide o resultado: nil (aprovada), ErrEmailRejected ou ErrEmailUndecided.
 func WaitForEmailResponse(
 	cfg config.EmailConfig,
 	messageID string,
 	subject string,
 	waitMinutes int,
 	fullLayout bool,
+	rules *EmailReplyRules,
 	verbose bool,
 ) error {
 	// Validação de configuração IMAP
-	if cfg.IMAPHost == "" || cfg.IMAPPort == 0 || cfg.IMAPUsername == "" || cfg.IMAPPassword == "" {
+	if cfg.IMAPHost == "" || cfg.IMAPPort == 0 || cfg.IMAPUsername == "" || (cfg.IMAPPassword == "" && cfg.OAuth2TokenURL == "") {
 		return fmt.Errorf("%w: para usar --wait-for-response é necessário configurar email.imap_* no cast.yaml", ErrIMAPConfigMissing)
 	}
 
@@ -76,7 +79,23 @@ func WaitForEmailResponse(
 		cyan := color.New(color.FgCyan)
 		cyan.Printf("[DEBUG] Message-ID sendo buscado: %s\n", messageID)
 		cyan.Printf("[DEBUG] Subject original: %s\n", subject)
-		cyan.Printf("[DEBUG] Intervalo de polling: %v (entre cada ciclo de busca)\n", pollInterval)
+		cyan.Printf("[DEBUG] Intervalo de polling: %v (entre cada ciclo de busca, sem IDLE)\n", pollInterval)
+	}
+
+	// Usa fullLayout da configuração se não foi especificado via flag
+	fullLayoutToUse := fullLayout || cfg.WaitForResponseFullLayout
+	if rules == nil {
+		rules = &EmailReplyRules{}
+	}
+	report := func(response *EmailResponse) error {
+		// Resposta encontrada! Retorna IMEDIATAMENTE (sem sleep)
+		elapsed := time.Since(startTime)
+		green := color.New(color.FgGreen, color.Bold)
+		green.Printf("✓ Resposta recebida em %s\n", formatDuration(elapsed))
+
+		// Exibe resposta
+		printEmailResponse(response, cfg.WaitForResponseMaxLines, verbose)
+		return rules.result(response)
 	}
 
 	cycle := 0
@@ -109,9 +128,31 @@ func WaitForEmailResponse(
 		// Com polling de 5s, 1 ciclo = ~5s (tempo mínimo para resposta chegar e ser indexada)
 		// Se In-Reply-To/References não funcionarem, tenta Subject no próximo ciclo
 		useSubjectFallback := cycle >= 1
-		// Usa fullLayout da configuração se não foi especificado via flag
-		fullLayoutToUse := fullLayout || cfg.WaitForResponseFullLayout
-		found, response, err := searchEmailResponse(imapClient, cfg.IMAPFolder, messageID, subject, useSubjectFallback, fullLayoutToUse, verbose)
+
+		// Com IDLE (RFC 2177), a mesma conexão autenticada é notificada das novas mensagens
+		// (EXISTS) assim que chegam. Se a conexão cair, reconecta no próximo ciclo; servidores
+		// sem IDLE seguem no polling abaixo
+		if ok, _ := imapClient.Support("IDLE"); ok {
+			found, response, err := waitWithIdle(imapClient, cfg.IMAPFolder, messageID, subject, rules.Senders, useSubjectFallback, fullLayoutToUse, deadline, verbose)
+			if found {
+				return report(response)
+			}
+			if err == nil {
+				break // Deadline atingido
+			}
+			if verbose {
+				red := color.New(color.FgRed)
+				red.Printf("[DEBUG] IDLE interrompido: %v, reconectando em %v...\n", err, pollInterval)
+			}
+			time.Sleep(pollInterval)
+			continue
+		}
+		if cycle == 1 && verbose {
+			cyan := color.New(color.FgCyan)
+			cyan.Printf("[DEBUG] Servidor sem suporte a IDLE, usando polling\n")
+		}
+
+		found, response, err := searchEmailResponse(imapClient, cfg.IMAPFolder, messageID, subject, rules.Senders, useSubjectFallback, fullLayoutToUse, verbose)
 		if err != nil {
 			imapClient.Logout()
 			if verbose {
@@ -126,14 +167,7 @@ func WaitForEmailResponse(
 		imapClient.Logout()
 
 		if found {
-			// Resposta encontrada! Retorna IMEDIATAMENTE (sem sleep)
-			elapsed := time.Since(startTime)
-			green := color.New(color.FgGreen, color.Bold)
-			green.Printf("✓ Resposta recebida em %s\n", formatDuration(elapsed))
-
-			// Exibe resposta
-			printEmailResponse(response, cfg.WaitForResponseMaxLines, verbose)
-			return nil
+			return report(response)
 		}
 
 		// Calcula tempo restante
@@ -161,6 +195,104 @@ func WaitForEmailResponse(
 	return ErrNoEmailResponse
 }
 
+// imapIdleRefresh é o intervalo de renovação do IDLE: o servidor pode encerrar a conexão
+// após 30 minutos sem comandos, e a RFC 2177 recomenda reiniciar o IDLE antes de 29 minutos.
+var imapIdleRefresh = 25 * time.Minute
+
+// waitWithIdle aguarda a resposta em uma única conexão IMAP com IDLE: a pasta é consultada
+// ao entrar e a cada notificação de nova mensagem (EXISTS), e o IDLE é renovado a cada
+// imapIdleRefresh. Retorna sem resposta e sem erro no deadline, e com erro se a conexão
+// ou o IDLE falharem. A conexão é encerrada ao retornar.
+func waitWithIdle(
+	c *client.Client,
+	folder string,
+	messageID string,
+	subject string,
+	senders []string,
+	useSubjectFallback bool,
+	fullLayout bool,
+	deadline time.Time,
+	verbose bool,
+) (bool, *EmailResponse, error) {
+	// As notificações chegam por c.Updates; um canal bloqueado trava o cliente, então elas
+	// são consumidas em uma goroutine que apenas sinaliza a chegada de novas mensagens
+	updates := make(chan client.Update, 16)
+	newMail := make(chan struct{}, 1)
+	quit := make(chan struct{})
+	c.Updates = updates
+	go func() {
+		for {
+			select {
+			case update := <-updates:
+				if _, ok := update.(*client.MailboxUpdate); ok {
+					select {
+					case newMail <- struct{}{}:
+					default:
+					}
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	defer close(quit)
+	defer c.Logout()
+
+	timeout := c.Timeout
+	for {
+		found, response, err := searchEmailResponse(c, folder, messageID, subject, senders, useSubjectFallback, fullLayout, verbose)
+		if found || err != nil {
+			return found, response, err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil, nil
+		}
+
+		// O SELECT da busca também notifica EXISTS; só contam as mensagens que chegarem depois
+		select {
+		case <-newMail:
+		default:
+		}
+
+		if verbose {
+			cyan := color.New(color.FgCyan)
+			cyan.Printf("[DEBUG] IDLE: aguardando novas mensagens na pasta %s...\n", folder)
+		}
+
+		// O timeout de comando do cliente valeria para o IDLE inteiro
+		c.Timeout = 0
+		stop := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- c.Idle(stop, &client.IdleOptions{LogoutTimeout: imapIdleRefresh, PollInterval: -1})
+		}()
+
+		// Encerrado pelo servidor sem erro, o IDLE volta após uma nova busca
+		idling := true
+		timer := time.NewTimer(remaining)
+		select {
+		case <-newMail:
+			if verbose {
+				cyan := color.New(color.FgCyan)
+				cyan.Printf("[DEBUG] IDLE: nova mensagem na pasta, verificando...\n")
+			}
+		case <-timer.C:
+		case err = <-done:
+			idling = false
+		}
+		timer.Stop()
+		if idling {
+			close(stop)
+			err = <-done
+		}
+		c.Timeout = timeout
+		if err != nil {
+			return false, nil, fmt.Errorf("erro no IDLE: %w", err)
+		}
+	}
+}
+
 // connectIMAP conecta ao servidor IMAP e autentica.
 func connectIMAP(cfg config.EmailConfig, verbose bool) (*client.Client, error) {
 	addr := fmt.Sprintf("%s:%d", cfg.IMAPHost, cfg.IMAPPort)
@@ -200,8 +332,8 @@ func connectIMAP(cfg config.EmailConfig, verbose bool) (*client.Client, error) {
 		c.Timeout = time.Duration(cfg.IMAPTimeout) * time.Second
 	}
 
-	// Autenticação
-	if err := c.Login(cfg.IMAPUsername, cfg.IMAPPassword); err != nil {
+	// Autenticação: XOAUTH2 com OAuth2 configurado, senão LOGIN com usuário e senha
+	if err := authenticateIMAP(c, &cfg); err != nil {
 		c.Logout()
 		return nil, fmt.Errorf("falha na autenticação IMAP: %w", err)
 	}
@@ -216,6 +348,7 @@ func connectIMAP(cfg config.EmailConfig, verbose bool) (*client.Client, error) {
 
 // searchEmailResponse busca uma resposta de email usando Message-ID ou Subject como fallback.
 // messageID é o Message-ID da mensagem enviada que estamos aguardando resposta.
+// senders: se não vazio, apenas mensagens desses remetentes são consideradas
 // useSubjectFallback: se true, tenta fallback por Subject (só após alguns ciclos)
 // fullLayout: se true, inclui HTML; se false, apenas texto
 func searchEmailResponse(
@@ -223,6 +356,7 @@ func searchEmailResponse(
 	folder string,
 	messageID string,
 	subject string,
+	senders []string,
 	useSubjectFallback bool,
 	fullLayout bool,
 	verbose bool,
@@ -252,6 +386,9 @@ func searchEmailResponse(
 		criteria.Header.Add("In-Reply-To", messageIDClean)
 	}
 	uids, err := c.Search(criteria)
+	if err == nil {
+		uids, err = filterSenders(c, uids, senders, verbose)
+	}
 	if err != nil {
 		if verbose {
 			cyan := color.New(color.FgCyan)
@@ -281,6 +418,9 @@ func searchEmailResponse(
 		criteria.Header.Add("References", messageIDClean)
 	}
 	uids, err = c.Search(criteria)
+	if err == nil {
+		uids, err = filterSenders(c, uids, senders, verbose)
+	}
 	if err != nil {
 		if verbose {
 			cyan := color.New(color.FgCyan)
@@ -319,6 +459,9 @@ func searchEmailResponse(
 			criteria.Header.Add("Subject", reSubject)
 		}
 		uids, err = c.Search(criteria)
+		if err == nil {
+			uids, err = filterSenders(c, uids, senders, verbose)
+		}
 		if err == nil && len(uids) > 0 {
 			if verbose {
 				cyan := color.New(color.FgCyan)
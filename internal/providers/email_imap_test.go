@@ -1,7 +1,15 @@
 package providers
 
 import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -74,7 +82,7 @@ func TestWaitForEmailResponse_ConfigValidation(t *testing.T) {
 		IMAPPassword: "",
 	}
 
-	err := WaitForEmailResponse(cfg, "<test@exemplo.com>", "Test", 15, false)
+	err := WaitForEmailResponse(cfg, "<test@exemplo.com>", "Test", 15, false, nil, false)
 	if err == nil {
 		t.Error("Esperado erro quando IMAP não está configurado")
 	}
@@ -92,7 +100,7 @@ func TestWaitForEmailResponse_ZeroMinutes(t *testing.T) {
 	}
 
 	// waitMinutes = 0 deve retornar nil imediatamente
-	err := WaitForEmailResponse(cfg, "<test@exemplo.com>", "Test", 0, false)
+	err := WaitForEmailResponse(cfg, "<test@exemplo.com>", "Test", 0, false, nil, false)
 	if err != nil {
 		t.Errorf("Esperado nil quando waitMinutes=0, obteve: %v", err)
 	}
@@ -109,7 +117,7 @@ func TestWaitForEmailResponse_MaxMinutesExceeded(t *testing.T) {
 	}
 
 	// waitMinutes > max deve retornar erro
-	err := WaitForEmailResponse(cfg, "<test@exemplo.com>", "Test", 60, false)
+	err := WaitForEmailResponse(cfg, "<test@exemplo.com>", "Test", 60, false, nil, false)
 	if err == nil {
 		t.Error("Esperado erro quando waitMinutes excede o máximo")
 	}
@@ -117,3 +125,412 @@ func TestWaitForEmailResponse_MaxMinutesExceeded(t *testing.T) {
 		t.Errorf("Esperado erro sobre exceder máximo, obteve: %v", err)
 	}
 }
+
+// fakeIMAPServer é um servidor IMAP mínimo: aceita AUTHENTICATE XOAUTH2 com o token
+// definido em SetToken e LOGIN com a senha definida em SetPassword, e atende SELECT,
+// SEARCH por In-Reply-To/References, FETCH do corpo e, com EnableIdle, IDLE.
+type fakeIMAPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	token    string
+	password string
+	auths    []string // Tokens apresentados no AUTHENTICATE XOAUTH2
+	logins   int
+	idle     bool
+	idles    int
+	idling   map[*fakeIMAPConn]bool
+	messages []string
+}
+
+// fakeIMAPConn serializa as escritas em uma conexão, feitas também por Deliver durante o IDLE.
+type fakeIMAPConn struct {
+	mu     sync.Mutex
+	writer *bufio.Writer
+}
+
+// reply escreve uma linha de resposta.
+func (c *fakeIMAPConn) reply(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(c.writer, format+"\r\n", args...)
+	c.writer.Flush()
+}
+
+// newFakeIMAPServer inicia o servidor falso e retorna a configuração para usá-lo.
+func newFakeIMAPServer(t *testing.T) (*fakeIMAPServer, *config.EmailConfig) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao abrir servidor IMAP falso: %v", err)
+	}
+	server := &fakeIMAPServer{listener: listener, idling: map[*fakeIMAPConn]bool{}}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+
+	return server, &config.EmailConfig{
+		IMAPHost:     "127.0.0.1",
+		IMAPPort:     listener.Addr().(*net.TCPAddr).Port,
+		IMAPUsername: "cast@empresa.com",
+		IMAPFolder:   "INBOX",
+		IMAPTimeout:  5,
+	}
+}
+
+// SetPassword define a senha aceita no LOGIN.
+func (s *fakeIMAPServer) SetPassword(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.password = password
+}
+
+// EnableIdle anuncia e atende o IDLE.
+func (s *fakeIMAPServer) EnableIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idle = true
+}
+
+// Deliver adiciona uma mensagem à pasta e notifica (EXISTS) as conexões em IDLE.
+func (s *fakeIMAPServer) Deliver(raw string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, strings.ReplaceAll(raw, "\n", "\r\n"))
+	for conn := range s.idling {
+		conn.reply("* %d EXISTS", len(s.messages))
+	}
+}
+
+// Logins retorna o número de LOGIN recebidos.
+func (s *fakeIMAPServer) Logins() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins
+}
+
+// Idles retorna o número de comandos IDLE recebidos.
+func (s *fakeIMAPServer) Idles() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.idles
+}
+
+// capabilities retorna as extensões anunciadas.
+func (s *fakeIMAPServer) capabilities() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idle {
+		return "IMAP4rev1 SASL-IR AUTH=XOAUTH2 IDLE"
+	}
+	return "IMAP4rev1 SASL-IR AUTH=XOAUTH2"
+}
+
+// search retorna os números das mensagens cujo In-Reply-To ou References aparece nos
+// critérios do SEARCH. Buscas por outros headers não encontram nada.
+func (s *fakeIMAPServer) search(criteria string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []string
+	for i, raw := range s.messages {
+		msg, err := mail.ReadMessage(strings.NewReader(raw))
+		if err != nil {
+			continue
+		}
+		for _, header := range []string{"In-Reply-To", "References"} {
+			value := strings.Trim(msg.Header.Get(header), "<>")
+			if value != "" && strings.Contains(criteria, header) && strings.Contains(criteria, value) {
+				found = append(found, strconv.Itoa(i+1))
+				break
+			}
+		}
+	}
+	return found
+}
+
+// SetToken define o token XOAUTH2 aceito.
+func (s *fakeIMAPServer) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// Auths retorna os tokens apresentados nas autenticações.
+func (s *fakeIMAPServer) Auths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.auths...)
+}
+
+// parseFakeSeqSet interpreta um conjunto de sequência simples ("1", "1,3" ou "1:3").
+func parseFakeSeqSet(seqset string) []int {
+	var nums []int
+	for _, part := range strings.Split(seqset, ",") {
+		first, last, isRange := strings.Cut(part, ":")
+		start, _ := strconv.Atoi(first)
+		end := start
+		if isRange {
+			end, _ = strconv.Atoi(last)
+		}
+		for n := start; n <= end; n++ {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
+
+// fakeEnvelope monta o ENVELOPE da mensagem com assunto, remetente e In-Reply-To.
+func fakeEnvelope(raw string) string {
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		return "ENVELOPE (NIL NIL NIL NIL NIL NIL NIL NIL NIL NIL)"
+	}
+	from := "NIL"
+	if address, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		mailbox, host, _ := strings.Cut(address.Address, "@")
+		from = fmt.Sprintf("((NIL NIL %q %q))", mailbox, host)
+	}
+	return fmt.Sprintf("ENVELOPE (NIL %q %s NIL NIL NIL NIL NIL %q NIL)",
+		msg.Header.Get("Subject"), from, msg.Header.Get("In-Reply-To"))
+}
+
+// serve atende uma conexão IMAP.
+func (s *fakeIMAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	c := &fakeIMAPConn{writer: bufio.NewWriter(conn)}
+	reply := c.reply
+	reply("* OK [CAPABILITY %s] fake.local pronto", s.capabilities())
+
+	for {
+		line, err := reader.ReadLine()
+		if err != nil {
+			return
+		}
+		tag, rest, _ := strings.Cut(line, " ")
+		command, args, _ := strings.Cut(rest, " ")
+		switch strings.ToUpper(command) {
+		case "CAPABILITY":
+			reply("* CAPABILITY %s", s.capabilities())
+			reply("%s OK CAPABILITY concluído", tag)
+		case "LOGIN":
+			_, password, _ := strings.Cut(args, " ")
+			s.mu.Lock()
+			s.logins++
+			accepted := s.password != "" && strings.Trim(password, `"`) == s.password
+			s.mu.Unlock()
+			if accepted {
+				reply("%s OK Autenticado", tag)
+			} else {
+				reply("%s NO [AUTHENTICATIONFAILED] Invalid credentials", tag)
+			}
+		case "SELECT":
+			s.mu.Lock()
+			exists := len(s.messages)
+			s.mu.Unlock()
+			reply("* %d EXISTS", exists)
+			reply("* 0 RECENT")
+			reply("%s OK [READ-WRITE] SELECT concluído", tag)
+		case "SEARCH":
+			reply("* SEARCH %s", strings.Join(s.search(args), " "))
+			reply("%s OK SEARCH concluído", tag)
+		case "FETCH":
+			seqset, items, _ := strings.Cut(args, " ")
+			s.mu.Lock()
+			for _, n := range parseFakeSeqSet(seqset) {
+				if n < 1 || n > len(s.messages) {
+					continue
+				}
+				raw := s.messages[n-1]
+				var fields []string
+				if strings.Contains(items, "ENVELOPE") {
+					fields = append(fields, fakeEnvelope(raw))
+				}
+				if strings.Contains(items, "BODY[") {
+					fields = append(fields, fmt.Sprintf("BODY[] {%d}\r\n%s", len(raw), raw))
+				}
+				reply("* %d FETCH (%s)", n, strings.Join(fields, " "))
+			}
+			s.mu.Unlock()
+			reply("%s OK FETCH concluído", tag)
+		case "IDLE":
+			s.mu.Lock()
+			s.idles++
+			s.idling[c] = true
+			s.mu.Unlock()
+			reply("+ aguardando")
+			_, err := reader.ReadLine() // DONE
+			s.mu.Lock()
+			delete(s.idling, c)
+			s.mu.Unlock()
+			if err != nil {
+				return
+			}
+			reply("%s OK IDLE concluído", tag)
+		case "AUTHENTICATE":
+			_, initialResponse, _ := strings.Cut(args, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(initialResponse)
+			_, token, _ := strings.Cut(string(decoded), "auth=Bearer ")
+			token = strings.TrimRight(token, "\x01")
+			s.mu.Lock()
+			s.auths = append(s.auths, token)
+			accepted := token == s.token
+			s.mu.Unlock()
+			if accepted {
+				reply("%s OK Autenticado", tag)
+				continue
+			}
+			reply("+ %s", base64.StdEncoding.EncodeToString([]byte(`{"status":"400"}`)))
+			reader.ReadLine()
+			reply("%s NO [AUTHENTICATIONFAILED] Invalid credentials", tag)
+		case "NOOP":
+			reply("%s OK NOOP concluído", tag)
+		case "LOGOUT":
+			reply("* BYE fake.local encerrando")
+			reply("%s OK LOGOUT concluído", tag)
+			return
+		default:
+			reply("%s BAD Comando não suportado", tag)
+		}
+	}
+}
+
+// imapReply é a resposta do destinatário a uma mensagem enviada pelo CAST.
+const imapReply = `From: Ana <ana@empresa.com>
+To: cast@empresa.com
+Subject: Re: Deploy
+In-Reply-To: <cast-123@empresa.com>
+Content-Type: text/plain; charset=utf-8
+
+Aprovado
+`
+
+func TestWaitForEmailResponse_Idle(t *testing.T) {
+	server, cfg := newFakeIMAPServer(t)
+	server.SetPassword("senha")
+	server.EnableIdle()
+	cfg.IMAPPassword = "senha"
+	cfg.IMAPPollInterval = 60 // Com polling, a resposta só seria vista após 60s
+
+	// Renovação curta para que o teste passe por ela antes da resposta chegar
+	original := imapIdleRefresh
+	imapIdleRefresh = 100 * time.Millisecond
+	t.Cleanup(func() { imapIdleRefresh = original })
+
+	result := make(chan error, 1)
+	go func() {
+		result <- WaitForEmailResponse(*cfg, "<cast-123@empresa.com>", "Deploy", 1, false, nil, false)
+	}()
+
+	for start := time.Now(); server.Idles() < 2; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("IDLE não foi renovado: %d comandos IDLE recebidos", server.Idles())
+		}
+	}
+	server.Deliver(imapReply)
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("Erro ao aguardar resposta: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Resposta não detectada pela notificação do IDLE")
+	}
+	if logins := server.Logins(); logins != 1 {
+		t.Errorf("Esperada uma única conexão autenticada, obtidos %d LOGIN", logins)
+	}
+}
+
+func TestWaitForEmailResponse_PollingWithoutIdle(t *testing.T) {
+	server, cfg := newFakeIMAPServer(t)
+	server.SetPassword("senha")
+	server.Deliver(imapReply)
+	cfg.IMAPPassword = "senha"
+
+	if err := WaitForEmailResponse(*cfg, "<cast-123@empresa.com>", "Deploy", 1, false, nil, false); err != nil {
+		t.Fatalf("Erro ao aguardar resposta: %v", err)
+	}
+	if idles := server.Idles(); idles != 0 {
+		t.Errorf("Servidor sem IDLE não deveria receber IDLE, recebeu %d", idles)
+	}
+}
+
+func TestEmailReplyRules_Decide(t *testing.T) {
+	tests := []struct {
+		expect string
+		reject string
+		text   string
+		want   emailDecision
+	}{
+		{"aprovado|sim", "negado|não", "Aprovado, pode seguir", emailApproved},
+		{"aprovado|sim", "negado|não", "SIM", emailApproved},
+		{"aprovado|sim", "negado|não", "Negado por enquanto", emailRejected},
+		{"aprovado|sim", "negado|não", "Não aprovado", emailRejected},
+		{"aprovado|sim", "negado|não", "Vou verificar amanhã", emailUndecided},
+		{"aprovado", "", "Talvez", emailUndecided},
+		{"", "negado", "Pode seguir", emailApproved},
+		{"", "negado", "Negado", emailRejected},
+		{"", "", "Qualquer resposta", emailApproved},
+	}
+
+	for _, tt := range tests {
+		rules, err := NewEmailReplyRules(tt.expect, tt.reject)
+		if err != nil {
+			t.Fatalf("Erro ao compilar padrões (%q, %q): %v", tt.expect, tt.reject, err)
+		}
+		if got := rules.decide(tt.text); got != tt.want {
+			t.Errorf("decide(%q) com expect=%q reject=%q = %d, esperado %d", tt.text, tt.expect, tt.reject, got, tt.want)
+		}
+	}
+
+	if _, err := NewEmailReplyRules("aprovado(", ""); err == nil || !strings.Contains(err.Error(), "--expect") {
+		t.Errorf("Esperado erro de padrão --expect inválido, obteve: %v", err)
+	}
+}
+
+func TestWaitForEmailResponse_Decision(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{"Aprovado", nil},
+		{"Negado, falta o changelog", ErrEmailRejected},
+		{"Vou olhar depois", ErrEmailUndecided},
+	}
+
+	for _, tt := range tests {
+		server, cfg := newFakeIMAPServer(t)
+		server.SetPassword("senha")
+		server.Deliver(strings.Replace(imapReply, "Aprovado", tt.body, 1))
+		cfg.IMAPPassword = "senha"
+
+		rules, _ := NewEmailReplyRules("aprovado|sim", "negado|não")
+		err := WaitForEmailResponse(*cfg, "<cast-123@empresa.com>", "Deploy", 1, false, rules, false)
+		if err != tt.want {
+			t.Errorf("Resposta %q: esperado %v, obteve %v", tt.body, tt.want, err)
+		}
+	}
+}
+
+func TestWaitForEmailResponse_OnlyRecipients(t *testing.T) {
+	server, cfg := newFakeIMAPServer(t)
+	server.SetPassword("senha")
+	cfg.IMAPPassword = "senha"
+
+	// A aprovação forjada é a mais recente, mas não vem de um destinatário
+	server.Deliver(strings.Replace(imapReply, "Aprovado", "Negado", 1))
+	server.Deliver(strings.Replace(imapReply, "Ana <ana@empresa.com>", "Mallory <mallory@externo.com>", 1))
+
+	rules, _ := NewEmailReplyRules("aprovado", "negado")
+	rules.Senders = []string{"ANA@empresa.com"}
+	err := WaitForEmailResponse(*cfg, "<cast-123@empresa.com>", "Deploy", 1, false, rules, false)
+	if err != ErrEmailRejected {
+		t.Errorf("Esperada a recusa do destinatário (ErrEmailRejected), obteve: %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// emailBatchItem é uma mensagem do envio em lote, com destinatários, assunto e texto próprios.
+type emailBatchItem struct {
+	Target  string
+	Subject string
+	Message string
+}
+
+// buildEmailBatch divide o envio em mensagens. Com --merge, cada linha do CSV preenche
+// os templates do destino, do assunto e da mensagem; com --individual, cada destinatário
+// recebe uma mensagem separada (combinado com --merge, vale para cada linha).
+func buildEmailBatch(target string, message string, opts SendOptions) ([]emailBatchItem, error) {
+	items := []emailBatchItem{{Target: target, Subject: opts.Subject, Message: message}}
+
+	if path := opts.Get("merge"); path != "" {
+		rows, err := readMergeCSV(path)
+		if err != nil {
+			return nil, err
+		}
+		items = nil
+		for i, row := range rows {
+			item, err := renderMergeItem(target, message, opts.Subject, row)
+			if err != nil {
+				// Linha do arquivo: o cabeçalho é a linha 1
+				return nil, fmt.Errorf("mala direta, linha %d: %w", i+2, err)
+			}
+			items = append(items, item)
+		}
+	}
+
+	if opts.Get("individual") == "true" {
+		var split []emailBatchItem
+		for _, item := range items {
+			for _, to := range config.ParseTargets(item.Target) {
+				split = append(split, emailBatchItem{Target: to, Subject: item.Subject, Message: item.Message})
+			}
+		}
+		items = split
+	}
+	return items, nil
+}
+
+// readMergeCSV lê o CSV da mala direta: a primeira linha nomeia as colunas e cada linha
+// seguinte vira um mapa coluna → valor. O separador (vírgula ou ponto e vírgula, comum em
+// planilhas exportadas em português) é detectado pelo cabeçalho.
+func readMergeCSV(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler CSV da mala direta: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM gravado pelo Excel
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV da mala direta inválido: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("CSV da mala direta sem linhas de dados (a primeira linha deve conter os nomes das colunas)")
+	}
+
+	columns := records[0]
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+		if columns[i] == "" {
+			return nil, fmt.Errorf("CSV da mala direta: coluna %d sem nome no cabeçalho", i+1)
+		}
+	}
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			row[column] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// renderMergeItem preenche os templates ({{.coluna}}) do destino, do assunto e da
+// mensagem com uma linha do CSV.
+func renderMergeItem(target string, message string, subject string, row map[string]string) (emailBatchItem, error) {
+	var item emailBatchItem
+	var err error
+	if item.Target, err = renderMergeTemplate("destino", target, row); err != nil {
+		return item, err
+	}
+	if item.Subject, err = renderMergeTemplate("assunto", subject, row); err != nil {
+		return item, err
+	}
+	if item.Message, err = renderMergeTemplate("mensagem", message, row); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// renderMergeTemplate aplica um template do text/template. Colunas inexistentes são erro,
+// para que um erro de digitação não envie "<no value>" aos destinatários.
+func renderMergeTemplate(name string, text string, row map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("template inválido (%s): %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, row); err != nil {
+		return "", fmt.Errorf("erro ao preencher %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// sendBatch envia uma mensagem separada por destinatário (--individual) ou por linha do
+// CSV (--merge), cada uma com seu Message-ID, reutilizando a conexão SMTP (smtpPool). As mensagens
+// são montadas antes de conectar, para que um erro de template não interrompa o lote no
+// meio. Uma mensagem recusada pelo servidor não impede o envio das demais. As cópias
+// (--cc, --bcc e as do alias) vão em todas as mensagens: com N mensagens, cada cópia
+// recebe N emails.
+func (p *emailProvider) sendBatch(target string, message string, opts SendOptions) (string, error) {
+	items, err := buildEmailBatch(target, message, opts)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", fmt.Errorf("nenhum destinatário especificado")
+	}
+
+	msgs := make([]*emailMessage, len(items))
+	raws := make([][]byte, len(items))
+	for i, item := range items {
+		itemOpts := opts
+		itemOpts.Subject = item.Subject
+		if msgs[i], err = p.newMessage(item.Target, item.Message, itemOpts); err != nil {
+			return "", fmt.Errorf("mensagem %d: %w", i+1, err)
+		}
+		if raws[i], err = p.buildMessage(msgs[i]); err != nil {
+			return "", fmt.Errorf("erro ao montar mensagem %d: %w", i+1, err)
+		}
+	}
+
+	p.lastMessageID = ""
+	p.lastMessageIDs = nil
+	if written, err := writeEmailFiles(msgs, raws, opts); written {
+		if err != nil {
+			return "", err
+		}
+		for _, msg := range msgs {
+			p.lastMessageIDs = append(p.lastMessageIDs, msg.MessageID)
+		}
+		p.lastMessageID = msgs[len(msgs)-1].MessageID
+		return p.lastMessageID, nil
+	}
+
+	pool := p.newSMTPPool()
+	defer pool.Close()
+
+	var failures []string
+	for i, msg := range msgs {
+		err := pool.Send(msg.FromEmail, msg.Recipients(), raws[i])
+		if err == nil {
+			p.lastMessageID = msg.MessageID
+			p.lastMessageIDs = append(p.lastMessageIDs, msg.MessageID)
+			continue
+		}
+		if i == 0 && !pool.Connected() {
+			// Falha ao conectar ou autenticar: nenhuma mensagem foi enviada
+			return "", fmt.Errorf("erro ao enviar email: %w", err)
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", strings.Join(msg.To, ","), err))
+
+		// Uma recusa do servidor (ex: destinatário inexistente) afeta só a mensagem; sem
+		// conexão, mesmo após a nova tentativa, as mensagens restantes não são enviadas
+		if !pool.Connected() {
+			failures = append(failures, fmt.Sprintf("envio interrompido, %d mensagens não enviadas", len(msgs)-i-1))
+			break
+		}
+	}
+
+	if len(p.lastMessageIDs) < len(msgs) {
+		return p.lastMessageID, fmt.Errorf("falha no envio de %d de %d mensagens: %s",
+			len(msgs)-len(p.lastMessageIDs), len(msgs), strings.Join(failures, "; "))
+	}
+	return p.lastMessageID, nil
+}
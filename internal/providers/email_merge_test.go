@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmailProvider_Send_Individual(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+
+	messageID, err := provider.SendEmailWithOptions("a@empresa.com;b@empresa.com;c@empresa.com", "Manutenção às 22h", SendOptions{
+		Subject: "Aviso",
+		Values:  map[string][]string{"individual": {"true"}},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 3 {
+		t.Fatalf("Esperado 3 mensagens, obtido %d", len(messages))
+	}
+	if server.Conns() != 1 {
+		t.Errorf("O lote deveria usar uma única conexão SMTP, obtido %d", server.Conns())
+	}
+
+	ids := map[string]bool{}
+	for i, expected := range []string{"a@empresa.com", "b@empresa.com", "c@empresa.com"} {
+		if strings.Join(messages[i].To, ",") != expected {
+			t.Errorf("Mensagem %d: envelope deveria conter apenas %s, obtido %v", i+1, expected, messages[i].To)
+		}
+		msg, leaves := readMIMEMessage(t, messages[i].Data)
+		if got := decodedHeader(t, msg, "To"); got != expected {
+			t.Errorf("Mensagem %d: header To = %q, esperado %q", i+1, got, expected)
+		}
+		// O servidor entrega os dados com a quebra de linha que antecede o "." final do DATA
+		if leaves["text/plain"] != "Manutenção às 22h\n" {
+			t.Errorf("Mensagem %d: corpo inesperado: %q", i+1, leaves["text/plain"])
+		}
+		ids[msg.Header.Get("Message-Id")] = true
+	}
+	if len(ids) != 3 {
+		t.Errorf("Cada mensagem deveria ter seu próprio Message-ID: %v", ids)
+	}
+	if got := provider.GetLastMessageIDs(); len(got) != 3 || got[2] != messageID {
+		t.Errorf("Message-IDs do lote inesperados: %v (último: %s)", got, messageID)
+	}
+}
+
+func TestEmailProvider_Send_IndividualCopies(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+
+	_, err := provider.SendEmailWithOptions("a@empresa.com;b@empresa.com", "Aviso", SendOptions{
+		Values: map[string][]string{"individual": {"true"}, "cc": {"gerente@empresa.com"}, "bcc": {"arquivo@empresa.com"}},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	// Cada mensagem do lote leva as cópias: os endereços em Cc e Bcc recebem 2 emails
+	messages := server.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("Esperado 2 mensagens, obtido %d", len(messages))
+	}
+	for i, to := range []string{"a@empresa.com", "b@empresa.com"} {
+		expected := to + ",gerente@empresa.com,arquivo@empresa.com"
+		if strings.Join(messages[i].To, ",") != expected {
+			t.Errorf("Mensagem %d: envelope = %v, esperado %s", i+1, messages[i].To, expected)
+		}
+		msg, _ := readMIMEMessage(t, messages[i].Data)
+		if got := decodedHeader(t, msg, "Cc"); got != "gerente@empresa.com" {
+			t.Errorf("Mensagem %d: header Cc = %q, esperado gerente@empresa.com", i+1, got)
+		}
+		if _, ok := msg.Header["Bcc"]; ok {
+			t.Errorf("Mensagem %d: Bcc não pode aparecer nos headers", i+1)
+		}
+	}
+}
+
+func TestEmailProvider_Send_Merge(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	server.Reject("bruno@empresa.com")
+	provider := NewEmailProviderExtended(cfg)
+
+	// Separador ponto e vírgula e BOM, como nas planilhas exportadas pelo Excel
+	csvPath := filepath.Join(t.TempDir(), "clientes.csv")
+	os.WriteFile(csvPath, []byte("\xef\xbb\xbfemail;nome;valor\r\nana@empresa.com;Ana;R$ 10,00\r\nbruno@empresa.com;Bruno;R$ 20,00\r\ncarla@empresa.com; Carla ;R$ 30,00\r\n"), 0600)
+
+	_, err := provider.SendEmailWithOptions("{{.email}}", "Olá, {{.nome}}! Sua fatura é de {{.valor}}.", SendOptions{
+		Subject: "Fatura de {{.nome}}",
+		Values:  map[string][]string{"merge": {csvPath}},
+	})
+	// O destinatário recusado não impede o envio dos demais
+	if err == nil || !strings.Contains(err.Error(), "falha no envio de 1 de 3 mensagens") || !strings.Contains(err.Error(), "bruno@empresa.com") {
+		t.Fatalf("Esperado erro apontando a mensagem recusada, obtido: %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 2 || server.Conns() != 1 {
+		t.Fatalf("Esperado 2 mensagens em uma conexão, obtido %d em %d", len(messages), server.Conns())
+	}
+	expected := []struct{ to, subject, body string }{
+		{"ana@empresa.com", "Fatura de Ana", "Olá, Ana! Sua fatura é de R$ 10,00."},
+		{"carla@empresa.com", "Fatura de Carla", "Olá, Carla! Sua fatura é de R$ 30,00."},
+	}
+	for i, want := range expected {
+		msg, leaves := readMIMEMessage(t, messages[i].Data)
+		if got := decodedHeader(t, msg, "To"); got != want.to {
+			t.Errorf("Mensagem %d: To = %q, esperado %q", i+1, got, want.to)
+		}
+		if got := decodedHeader(t, msg, "Subject"); got != want.subject {
+			t.Errorf("Mensagem %d: Subject = %q, esperado %q", i+1, got, want.subject)
+		}
+		if leaves["text/plain"] != want.body+"\n" {
+			t.Errorf("Mensagem %d: corpo = %q, esperado %q", i+1, leaves["text/plain"], want.body)
+		}
+	}
+	if len(provider.GetLastMessageIDs()) != 2 {
+		t.Errorf("Deveriam ser registrados os Message-IDs das 2 mensagens enviadas: %v", provider.GetLastMessageIDs())
+	}
+}
+
+func TestEmailProvider_Send_MergeErrors(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0600)
+		return path
+	}
+	valid := write("valido.csv", "email,nome\nana@empresa.com,Ana\nbruno@empresa.com\n")
+
+	tests := []struct {
+		message  string
+		csv      string
+		contains string
+	}{
+		{"Olá, {{.nome}}", filepath.Join(dir, "ausente.csv"), "erro ao ler CSV"},
+		{"Olá, {{.nome}}", write("vazio.csv", "email,nome\n"), "sem linhas de dados"},
+		{"Olá, {{.nome}}", valid, "CSV da mala direta inválido"},
+		{"Olá, {{.nome}}", write("sem_nome.csv", "email,\na@empresa.com,x\n"), "coluna 2 sem nome"},
+		{"Olá, {{.sobrenome}}", write("ok.csv", "email,nome\na@empresa.com,Ana\n"), "linha 2"},
+		{"Olá, {{.nome", write("ok2.csv", "email,nome\na@empresa.com,Ana\n"), "template inválido (mensagem)"},
+	}
+	for _, tt := range tests {
+		_, err := provider.SendEmailWithOptions("{{.email}}", tt.message, SendOptions{Values: map[string][]string{"merge": {tt.csv}}})
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("%s: esperado erro contendo '%s', obtido: %v", filepath.Base(tt.csv), tt.contains, err)
+		}
+	}
+	// Erros de CSV e de template são detectados antes de qualquer envio
+	if server.Conns() != 0 {
+		t.Errorf("Nenhuma conexão deveria ser aberta, obtido %d", server.Conns())
+	}
+}
@@ -0,0 +1,184 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// mimeMaxLineLength é o tamanho máximo recomendado de linha (RFC 5322, seção 2.1.1).
+const mimeMaxLineLength = 78
+
+// mimePart é uma parte MIME: uma folha (Header e Body já codificado) ou um
+// multipart (Subtype e Children), renderizado com boundary aleatório.
+type mimePart struct {
+	Header   textproto.MIMEHeader
+	Body     []byte
+	Subtype  string // mixed, alternative ou related
+	Children []*mimePart
+}
+
+// render retorna os headers de conteúdo e o corpo da parte. Cada multipart recebe
+// um boundary aleatório (multipart.Writer), que não colide com o conteúdo.
+func (p *mimePart) render() (textproto.MIMEHeader, []byte, error) {
+	if p.Subtype == "" {
+		return p.Header, p.Body, nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, child := range p.Children {
+		header, content, err := child.render()
+		if err != nil {
+			return nil, nil, err
+		}
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType("multipart/"+p.Subtype, map[string]string{"boundary": writer.Boundary()}))
+	return header, body.Bytes(), nil
+}
+
+// newTextPart cria uma parte de texto (text/plain ou text/html) em UTF-8,
+// codificada em quoted-printable (linhas de até 76 caracteres, seguro para 7bit).
+func newTextPart(mediaType string, text string) (*mimePart, error) {
+	var body bytes.Buffer
+	writer := quotedprintable.NewWriter(&body)
+	if _, err := writer.Write([]byte(text)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(mediaType, map[string]string{"charset": "UTF-8"}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return &mimePart{Header: header, Body: body.Bytes()}, nil
+}
+
+// newAttachmentPart cria a parte de um anexo em base64. Nomes com acentos usam
+// a codificação da RFC 2231 (parâmetro filename* em UTF-8), aceita pelos clientes atuais.
+func newAttachmentPart(path string) (*mimePart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo %s: %w", path, err)
+	}
+	fileName := filepath.Base(path)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", attachmentContentType(fileName))
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	return &mimePart{Header: header, Body: encodeBase64Lines(data)}, nil
+}
+
+// newInlinePart cria a parte de uma imagem embutida, identificada pelo Content-ID
+// referenciado no HTML (cid:ContentID).
+func newInlinePart(inline emailInline) (*mimePart, error) {
+	part, err := newAttachmentPart(inline.Path)
+	if err != nil {
+		return nil, err
+	}
+	part.Header.Set("Content-ID", "<"+inline.ContentID+">")
+	part.Header.Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": filepath.Base(inline.Path)}))
+	return part, nil
+}
+
+// attachmentContentType detecta o tipo MIME pela extensão e inclui o parâmetro name.
+func attachmentContentType(fileName string) string {
+	mediaType, params, err := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(fileName)))
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+	params["name"] = fileName
+	return mime.FormatMediaType(mediaType, params)
+}
+
+// encodeBase64Lines codifica em base64 com linhas de 76 caracteres (RFC 2045).
+func encodeBase64Lines(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var out bytes.Buffer
+	for i := 0; i < len(encoded); i += 76 {
+		end := i + 76
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		out.WriteString(encoded[i:end] + "\r\n")
+	}
+	return out.Bytes()
+}
+
+// encodeHeaderValue codifica valores com caracteres não ASCII como encoded-words
+// da RFC 2047 (=?UTF-8?q?...?=); valores ASCII são mantidos como estão.
+func encodeHeaderValue(value string) string {
+	if isASCII(value) {
+		return value
+	}
+	return mime.QEncoding.Encode("UTF-8", value)
+}
+
+// formatAddress formata um endereço com nome opcional; nomes com acentos ou
+// caracteres especiais são codificados/escapados por net/mail.
+func formatAddress(name string, address string) string {
+	if name == "" {
+		return address
+	}
+	return (&mail.Address{Name: name, Address: address}).String()
+}
+
+// formatAddressList formata uma lista de endereços para os headers To, Cc e Reply-To.
+// Endereços no formato "Nome <email>" mantêm o nome (codificado se necessário).
+func formatAddressList(addresses []string) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address
+		if parsed, err := mail.ParseAddress(address); err == nil {
+			formatted[i] = formatAddress(parsed.Name, parsed.Address)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// writeHeader escreve um header, dobrando a linha em espaços quando passa de
+// 78 caracteres (as linhas seguintes começam com espaço, RFC 5322 seção 2.2.3).
+func writeHeader(buf *bytes.Buffer, name string, value string) {
+	line := name + ":"
+	for _, word := range strings.Split(value, " ") {
+		if len(line)+1+len(word) > mimeMaxLineLength && line != "" {
+			buf.WriteString(line + "\r\n")
+			line = ""
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\r\n")
+}
+
+// isASCII verifica se o texto contém apenas caracteres ASCII imprimíveis ou espaços.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf || (s[i] < ' ' && s[i] != '\t') {
+			return false
+		}
+	}
+	return true
+}
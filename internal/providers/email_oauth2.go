@@ -0,0 +1,292 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap/client"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// oauth2ExpiryMargin antecipa a renovação para que o token não expire durante o envio.
+const oauth2ExpiryMargin = time.Minute
+
+// oauth2Token é o token de acesso obtido no token endpoint, gravado em cache.
+type oauth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"` // Refresh token rotacionado pelo servidor
+	Expiry       time.Time `json:"expiry"`
+}
+
+// valid informa se o token pode ser usado (existe e não está perto de expirar).
+func (t *oauth2Token) valid() bool {
+	return t != nil && t.AccessToken != "" && time.Now().Add(oauth2ExpiryMargin).Before(t.Expiry)
+}
+
+// oauth2Source obtém tokens de acesso para XOAUTH2 pelo fluxo refresh_token (com refresh
+// token) ou client_credentials. Os tokens ficam em cache em disco até expirarem.
+type oauth2Source struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	Scope        string
+	CachePath    string // Vazio = sem cache em disco
+
+	token *oauth2Token
+}
+
+// newOAuth2Source cria a fonte de tokens a partir da configuração do email.
+// Retorna nil (sem erro) quando o OAuth2 não está configurado.
+func newOAuth2Source(cfg *config.EmailConfig, username string) (*oauth2Source, error) {
+	if cfg == nil || cfg.OAuth2TokenURL == "" {
+		return nil, nil
+	}
+	if cfg.OAuth2ClientID == "" {
+		return nil, fmt.Errorf("OAuth2 incompleto: configure oauth2_client_id")
+	}
+	secret, err := ResolveSecretRefs(cfg.OAuth2ClientSecret)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao resolver oauth2_client_secret: %w", err)
+	}
+	refreshToken, err := ResolveSecretRefs(cfg.OAuth2RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao resolver oauth2_refresh_token: %w", err)
+	}
+
+	source := &oauth2Source{
+		TokenURL:     cfg.OAuth2TokenURL,
+		ClientID:     cfg.OAuth2ClientID,
+		ClientSecret: secret,
+		RefreshToken: refreshToken,
+		Scope:        cfg.OAuth2Scope,
+	}
+	// O cache é separado por endpoint, cliente, usuário e escopo
+	if dir, err := os.UserCacheDir(); err == nil {
+		key := sha256.Sum256([]byte(strings.Join([]string{cfg.OAuth2TokenURL, cfg.OAuth2ClientID, username, cfg.OAuth2Scope}, "\n")))
+		source.CachePath = filepath.Join(dir, "cast", "oauth2", hex.EncodeToString(key[:12])+".json")
+	}
+	return source, nil
+}
+
+// Token retorna um token de acesso válido, do cache ou do token endpoint.
+// Com refresh, ignora o token de acesso em cache (ex: o servidor o recusou).
+func (s *oauth2Source) Token(refresh bool) (string, error) {
+	if s.token == nil {
+		s.token = s.readCache() // Também guarda o refresh token rotacionado
+	}
+	if !refresh && s.token.valid() {
+		return s.token.AccessToken, nil
+	}
+
+	token, err := s.fetch()
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.writeCache(token)
+	return token.AccessToken, nil
+}
+
+// fetch solicita um novo token de acesso ao token endpoint.
+func (s *oauth2Source) fetch() (*oauth2Token, error) {
+	form := url.Values{"client_id": {s.ClientID}}
+	if s.ClientSecret != "" {
+		form.Set("client_secret", s.ClientSecret)
+	}
+	if s.Scope != "" {
+		form.Set("scope", s.Scope)
+	}
+	refreshToken := s.RefreshToken
+	if s.token != nil && s.token.RefreshToken != "" {
+		refreshToken = s.token.RefreshToken
+	}
+	if refreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", refreshToken)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.PostForm(s.TokenURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter token OAuth2: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	json.Unmarshal(body, &result)
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		detail := strings.TrimSpace(result.Error + ": " + result.ErrorDescription)
+		if result.Error == "" {
+			detail = strings.TrimSpace(string(body))
+		}
+		return nil, fmt.Errorf("token endpoint OAuth2 retornou status %d: %s", resp.StatusCode, detail)
+	}
+
+	token := &oauth2Token{AccessToken: result.AccessToken, RefreshToken: result.RefreshToken}
+	if token.RefreshToken == "" && s.token != nil {
+		token.RefreshToken = s.token.RefreshToken
+	}
+	expiresIn := time.Duration(result.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = time.Hour
+	}
+	token.Expiry = time.Now().Add(expiresIn)
+	return token, nil
+}
+
+// readCache lê o token em cache (nil se ausente ou ilegível).
+func (s *oauth2Source) readCache() *oauth2Token {
+	if s.CachePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.CachePath)
+	if err != nil {
+		return nil
+	}
+	var token oauth2Token
+	if json.Unmarshal(data, &token) != nil {
+		return nil
+	}
+	return &token
+}
+
+// writeCache grava o token em cache, legível apenas pelo usuário. Falhas são ignoradas:
+// sem cache, um novo token é solicitado no próximo envio.
+func (s *oauth2Source) writeCache(token *oauth2Token) {
+	if s.CachePath == "" {
+		return
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(s.CachePath), 0700) == nil {
+		os.WriteFile(s.CachePath, data, 0600)
+	}
+}
+
+// xoauth2Response monta a resposta do mecanismo SASL XOAUTH2 (Google e Microsoft).
+func xoauth2Response(username string, token string) []byte {
+	return []byte("user=" + username + "\x01auth=Bearer " + token + "\x01\x01")
+}
+
+// xoauth2Auth implementa smtp.Auth para o mecanismo XOAUTH2.
+type xoauth2Auth struct {
+	username string
+	token    string
+}
+
+// Start inicia a autenticação. Como o PLAIN do net/smtp, exige TLS exceto em localhost.
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && server.Name != "localhost" && server.Name != "127.0.0.1" && server.Name != "::1" {
+		return "", nil, fmt.Errorf("XOAUTH2 exige conexão TLS")
+	}
+	return "XOAUTH2", xoauth2Response(a.username, a.token), nil
+}
+
+// Next responde ao desafio do servidor. Em caso de falha, o servidor envia um JSON
+// com o erro e espera uma resposta vazia antes de recusar a autenticação.
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+// xoauth2SASL implementa o cliente SASL do go-imap para o mecanismo XOAUTH2.
+type xoauth2SASL struct {
+	username string
+	token    string
+}
+
+// Start retorna o mecanismo e a resposta inicial.
+func (a *xoauth2SASL) Start() (string, []byte, error) {
+	return "XOAUTH2", xoauth2Response(a.username, a.token), nil
+}
+
+// Next responde ao desafio de erro com uma resposta vazia.
+func (a *xoauth2SASL) Next(challenge []byte) ([]byte, error) {
+	return []byte{}, nil
+}
+
+// NewEmailSMTPAuth retorna a autenticação SMTP da configuração: XOAUTH2 quando o OAuth2
+// está configurado, PLAIN com usuário e senha, ou nil (servidores sem autenticação).
+func NewEmailSMTPAuth(cfg *config.EmailConfig) (smtp.Auth, error) {
+	return emailSMTPAuth(cfg, false)
+}
+
+// emailSMTPAuth é NewEmailSMTPAuth com a opção de forçar a renovação do token OAuth2.
+func emailSMTPAuth(cfg *config.EmailConfig, refresh bool) (smtp.Auth, error) {
+	source, err := newOAuth2Source(cfg, cfg.Username)
+	if err != nil {
+		return nil, err
+	}
+	if source != nil {
+		token, err := source.Token(refresh)
+		if err != nil {
+			return nil, err
+		}
+		return &xoauth2Auth{username: cfg.Username, token: token}, nil
+	}
+	if cfg.Username != "" && cfg.Password != "" {
+		return smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SMTPHost), nil
+	}
+	return nil, nil
+}
+
+// isSMTPAuthError verifica se o servidor SMTP recusou as credenciais (534/535).
+func isSMTPAuthError(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && (protoErr.Code == 534 || protoErr.Code == 535)
+}
+
+// authenticateIMAP autentica a conexão IMAP: XOAUTH2 com OAuth2 configurado (usando
+// imap_username ou, se vazio, username), senão LOGIN com usuário e senha. Se o token
+// em cache for recusado, um novo token é solicitado e a autenticação repetida.
+func authenticateIMAP(c *client.Client, cfg *config.EmailConfig) error {
+	username := cfg.IMAPUsername
+	if username == "" {
+		username = cfg.Username
+	}
+	source, err := newOAuth2Source(cfg, username)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return c.Login(cfg.IMAPUsername, cfg.IMAPPassword)
+	}
+
+	for _, refresh := range []bool{false, true} {
+		token, tokenErr := source.Token(refresh)
+		if tokenErr != nil {
+			return tokenErr
+		}
+		if err = c.Authenticate(&xoauth2SASL{username: username, token: token}); err == nil {
+			return nil
+		}
+	}
+	return err
+}
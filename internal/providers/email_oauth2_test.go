@@ -0,0 +1,175 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// fakeTokenEndpoint é um token endpoint OAuth2 que emite token-1, token-2... e
+// rotaciona o refresh token a cada renovação.
+type fakeTokenEndpoint struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []url.Values
+}
+
+// newFakeTokenEndpoint inicia o token endpoint falso. O client secret aceito é "segredo".
+func newFakeTokenEndpoint(t *testing.T) *fakeTokenEndpoint {
+	endpoint := &fakeTokenEndpoint{}
+	endpoint.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		endpoint.mu.Lock()
+		endpoint.requests = append(endpoint.requests, r.PostForm)
+		n := len(endpoint.requests)
+		endpoint.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("client_secret") != "segredo" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client","error_description":"Client secret inválido"}`))
+			return
+		}
+		response := map[string]interface{}{"access_token": fmt.Sprintf("token-%d", n), "token_type": "Bearer", "expires_in": 3600}
+		if r.PostForm.Get("grant_type") == "refresh_token" {
+			response["refresh_token"] = fmt.Sprintf("refresh-rotacionado-%d", n)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(endpoint.Close)
+	return endpoint
+}
+
+// Requests retorna os formulários recebidos pelo token endpoint.
+func (e *fakeTokenEndpoint) Requests() []url.Values {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]url.Values(nil), e.requests...)
+}
+
+// withOAuth2 configura o OAuth2 (fluxo refresh_token) e isola o cache de tokens.
+func withOAuth2(t *testing.T, cfg *config.EmailConfig, endpoint *fakeTokenEndpoint) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("CAST_TEST_OAUTH2_SECRET", "segredo")
+	cfg.Username = "cast@empresa.com"
+	cfg.OAuth2TokenURL = endpoint.URL
+	cfg.OAuth2ClientID = "cast"
+	cfg.OAuth2ClientSecret = "${env:CAST_TEST_OAUTH2_SECRET}"
+	cfg.OAuth2RefreshToken = "refresh-1"
+}
+
+func TestEmailProvider_Send_XOAUTH2(t *testing.T) {
+	endpoint := newFakeTokenEndpoint(t)
+	server, cfg := newFakeSMTPServer(t)
+	withOAuth2(t, cfg, endpoint)
+	server.SetToken("token-1")
+
+	// Dois envios (como duas execuções do CAST): o token fica em cache
+	for i := 0; i < 2; i++ {
+		if err := NewEmailProvider(cfg).Send("admin@empresa.com", "Mensagem"); err != nil {
+			t.Fatalf("Envio %d: erro inesperado: %v", i+1, err)
+		}
+	}
+	requests := endpoint.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("O token deveria ser reutilizado do cache, obtidas %d requisições", len(requests))
+	}
+	if requests[0].Get("grant_type") != "refresh_token" || requests[0].Get("refresh_token") != "refresh-1" || requests[0].Get("client_id") != "cast" {
+		t.Errorf("Requisição de token inesperada: %v", requests[0])
+	}
+
+	// Token revogado no servidor: renova (com o refresh token rotacionado) e reenvia
+	server.SetToken("token-2")
+	if err := NewEmailProvider(cfg).Send("admin@empresa.com", "Mensagem"); err != nil {
+		t.Fatalf("Envio após revogação: erro inesperado: %v", err)
+	}
+	requests = endpoint.Requests()
+	if len(requests) != 2 || requests[1].Get("refresh_token") != "refresh-rotacionado-1" {
+		t.Errorf("Deveria renovar com o refresh token rotacionado: %v", requests)
+	}
+	if auths := server.Auths(); strings.Join(auths, ",") != "token-1,token-1,token-1,token-2" {
+		t.Errorf("Tokens apresentados inesperados: %v", auths)
+	}
+	if len(server.Messages()) != 3 {
+		t.Errorf("Esperadas 3 mensagens entregues, obtidas %d", len(server.Messages()))
+	}
+
+	// Token recusado mesmo após a renovação
+	server.SetToken("outro")
+	err := NewEmailProvider(cfg).Send("admin@empresa.com", "Mensagem")
+	if err == nil || !strings.Contains(err.Error(), "535") {
+		t.Errorf("Esperado erro de autenticação, obtido: %v", err)
+	}
+}
+
+func TestOAuth2Source_ClientCredentials(t *testing.T) {
+	endpoint := newFakeTokenEndpoint(t)
+	cachePath := filepath.Join(t.TempDir(), "token.json")
+	source := &oauth2Source{
+		TokenURL:     endpoint.URL,
+		ClientID:     "cast",
+		ClientSecret: "segredo",
+		Scope:        "https://outlook.office365.com/.default",
+		CachePath:    cachePath,
+	}
+	token, err := source.Token(false)
+	if err != nil || token != "token-1" {
+		t.Fatalf("Esperado token-1, obtido '%s' (%v)", token, err)
+	}
+	request := endpoint.Requests()[0]
+	if request.Get("grant_type") != "client_credentials" || request.Get("scope") != source.Scope || request.Has("refresh_token") {
+		t.Errorf("Requisição client_credentials inesperada: %v", request)
+	}
+	if info, err := os.Stat(cachePath); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Cache deveria ser gravado com permissão 0600: %v", err)
+	}
+
+	// Token em cache expirado: solicita outro
+	expired, _ := json.Marshal(oauth2Token{AccessToken: "velho", Expiry: time.Now().Add(30 * time.Second)})
+	os.WriteFile(cachePath, expired, 0600)
+	source = &oauth2Source{TokenURL: endpoint.URL, ClientID: "cast", ClientSecret: "segredo", CachePath: cachePath}
+	if token, _ := source.Token(false); token != "token-2" {
+		t.Errorf("Token perto de expirar deveria ser renovado, obtido '%s'", token)
+	}
+
+	source.ClientSecret = "errado"
+	if _, err := source.Token(true); err == nil || !strings.Contains(err.Error(), "invalid_client: Client secret inválido") {
+		t.Errorf("Esperado erro do token endpoint, obtido: %v", err)
+	}
+	if _, err := newOAuth2Source(&config.EmailConfig{OAuth2TokenURL: endpoint.URL}, ""); err == nil {
+		t.Error("OAuth2 sem client_id deveria gerar erro")
+	}
+}
+
+func TestConnectIMAP_XOAUTH2(t *testing.T) {
+	endpoint := newFakeTokenEndpoint(t)
+	server, cfg := newFakeIMAPServer(t)
+	withOAuth2(t, cfg, endpoint)
+	cfg.IMAPUsername = ""
+	server.SetToken("token-1")
+
+	c, err := connectIMAP(*cfg, false)
+	if err != nil {
+		t.Fatalf("Erro ao conectar: %v", err)
+	}
+	c.Logout()
+
+	// Token recusado: renova uma vez e desiste
+	server.SetToken("outro")
+	if _, err := connectIMAP(*cfg, false); err == nil || !strings.Contains(err.Error(), "falha na autenticação IMAP") {
+		t.Errorf("Esperado erro de autenticação, obtido: %v", err)
+	}
+	if auths := server.Auths(); strings.Join(auths, ",") != "token-1,token-1,token-2" {
+		t.Errorf("Tokens apresentados inesperados: %v", auths)
+	}
+}
@@ -0,0 +1,145 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+)
+
+// smtpPool envia várias mensagens pela mesma sessão SMTP autenticada. A conexão é aberta
+// no primeiro envio, renovada ao atingir smtp_max_messages e reaberta quando cai (ou quando
+// o servidor a encerra com 421, comum ao exceder o limite de mensagens por conexão).
+type smtpPool struct {
+	provider *emailProvider
+	session  *smtpSession
+	max      int // Mensagens por conexão (0 = sem limite)
+	sent     int // Mensagens enviadas pela conexão atual
+}
+
+// newSMTPPool cria o pool com o limite de mensagens por conexão da configuração.
+func (p *emailProvider) newSMTPPool() *smtpPool {
+	return &smtpPool{provider: p, max: p.config.SMTPMaxMessages}
+}
+
+// Send envia a mensagem, conectando se necessário. Se a conexão cair durante o envio, a
+// mensagem é repetida uma vez em uma nova conexão; se a queda ocorrer depois do "." final,
+// o servidor pode já ter aceitado a mensagem e ela chega em duplicidade. Após uma recusa
+// do servidor (ex: destinatário inexistente), a transação é descartada com RSET e a
+// conexão continua disponível para as próximas mensagens.
+func (p *smtpPool) Send(from string, to []string, msg []byte) error {
+	for attempt := 1; ; attempt++ {
+		if p.session != nil && p.max > 0 && p.sent >= p.max {
+			p.release(true)
+		}
+		if p.session == nil {
+			session, err := p.provider.openSession()
+			if err != nil {
+				return err
+			}
+			p.session, p.sent = session, 0
+		}
+
+		err := p.session.Send(from, to, msg)
+		if err == nil {
+			p.sent++
+			return nil
+		}
+		if isSMTPConnectionLost(err) {
+			p.release(false)
+			if attempt == 1 {
+				continue
+			}
+			return err
+		}
+		if p.session.Reset() != nil {
+			p.release(false)
+		}
+		return err
+	}
+}
+
+// Connected informa se há uma conexão aberta (após uma falha, se ela pôde ser mantida).
+func (p *smtpPool) Connected() bool {
+	return p.session != nil
+}
+
+// Close encerra a conexão com QUIT.
+func (p *smtpPool) Close() {
+	p.release(true)
+}
+
+// release descarta a conexão atual, com QUIT quando ela ainda está ativa.
+func (p *smtpPool) release(quit bool) {
+	if p.session == nil {
+		return
+	}
+	if quit {
+		p.session.Quit()
+	}
+	p.session.Close()
+	p.session = nil
+}
+
+// isSMTPConnectionLost verifica se o erro encerrou a conexão: falhas de rede, conexão
+// fechada (EOF) ou o código 421 (serviço indisponível, o servidor fecha a conexão). Outros
+// códigos e erros locais (ex: endereço com quebra de linha) recusam só a mensagem.
+func isSMTPConnectionLost(err error) bool {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code == 421
+	}
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &netErr)
+}
+
+// envelopePipelined envia MAIL FROM e todos os RCPT TO de uma vez (PIPELINING, RFC 2920)
+// e só então lê as respostas, economizando uma ida e volta por comando. O DATA fica fora
+// do grupo: com um destinatário recusado, a mensagem não é enviada aos demais, como no
+// envio sem pipelining.
+func (s *smtpSession) envelopePipelined(from string, to []string) error {
+	mail := "MAIL FROM:<" + from + ">"
+	if ok, _ := s.client.Extension("8BITMIME"); ok {
+		mail += " BODY=8BITMIME"
+	}
+	if ok, _ := s.client.Extension("SMTPUTF8"); ok {
+		mail += " SMTPUTF8"
+	}
+	commands := []string{mail}
+	for _, recipient := range to {
+		commands = append(commands, "RCPT TO:<"+recipient+">")
+	}
+	for _, command := range commands {
+		if strings.ContainsAny(command, "\r\n") {
+			return fmt.Errorf("endereço inválido (contém quebra de linha): %q", command)
+		}
+	}
+
+	text := s.client.Text
+	for _, command := range commands {
+		text.W.WriteString(command + "\r\n")
+	}
+	if err := text.W.Flush(); err != nil {
+		return fmt.Errorf("erro ao enviar envelope: %w", err)
+	}
+
+	// Todas as respostas são lidas, mesmo após uma recusa, para manter a sessão sincronizada
+	var first error
+	for i := range commands {
+		_, _, err := text.ReadResponse(25)
+		if err == nil || first != nil {
+			continue
+		}
+		if i == 0 {
+			first = fmt.Errorf("erro ao definir remetente: %w", err)
+		} else {
+			first = fmt.Errorf("erro ao definir destinatário %s: %w", to[i-1], err)
+		}
+		if isSMTPConnectionLost(err) {
+			return first
+		}
+	}
+	return first
+}
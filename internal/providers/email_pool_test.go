@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+// sendIndividual envia "Aviso" separadamente para n destinatários.
+func sendIndividual(provider EmailProviderExtended, n int) error {
+	var targets []string
+	for i := 0; i < n; i++ {
+		targets = append(targets, string(rune('a'+i))+"@empresa.com")
+	}
+	_, err := provider.SendEmailWithOptions(strings.Join(targets, ";"), "Aviso", SendOptions{
+		Values: map[string][]string{"individual": {"true"}},
+	})
+	return err
+}
+
+func TestSMTPPool_MaxMessagesPerConnection(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	cfg.SMTPMaxMessages = 2
+	provider := NewEmailProviderExtended(cfg)
+
+	if err := sendIndividual(provider, 5); err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+	if len(server.Messages()) != 5 {
+		t.Errorf("Esperado 5 mensagens, obtido %d", len(server.Messages()))
+	}
+	// 2 + 2 + 1 mensagens
+	if server.Conns() != 3 {
+		t.Errorf("Com smtp_max_messages 2, esperado 3 conexões, obtido %d", server.Conns())
+	}
+}
+
+func TestSMTPPool_ReconnectsWhenServerCloses(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	server.SetMaxPerConnection(2)
+	provider := NewEmailProviderExtended(cfg)
+
+	// O servidor encerra a conexão com 421 na terceira mensagem: o pool reconecta e repete
+	if err := sendIndividual(provider, 5); err != nil {
+		t.Fatalf("O pool deveria reconectar após o 421: %v", err)
+	}
+	messages := server.Messages()
+	if len(messages) != 5 || server.Conns() != 3 {
+		t.Fatalf("Esperado 5 mensagens em 3 conexões, obtido %d em %d", len(messages), server.Conns())
+	}
+	for i, msg := range messages {
+		if expected := string(rune('a'+i)) + "@empresa.com"; msg.To[0] != expected {
+			t.Errorf("Mensagem %d: esperado %s, obtido %v", i+1, expected, msg.To)
+		}
+	}
+}
+
+func TestSMTPPool_Pipelining(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	server.SetPipelining()
+	server.Reject("b@empresa.com")
+	provider := NewEmailProviderExtended(cfg)
+
+	_, err := provider.SendEmailWithOptions("a@empresa.com;b@empresa.com", "Aviso", SendOptions{
+		Values: map[string][]string{"individual": {"true"}, "cc": {"c@empresa.com"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "erro ao definir destinatário b@empresa.com") {
+		t.Fatalf("Esperado erro no destinatário recusado, obtido: %v", err)
+	}
+
+	// A recusa não dessincroniza a sessão: a primeira mensagem chega inteira, na mesma conexão
+	messages := server.Messages()
+	if len(messages) != 1 || strings.Join(messages[0].To, ",") != "a@empresa.com,c@empresa.com" {
+		t.Fatalf("Esperada apenas a mensagem para a@empresa.com (com cópia), obtido %+v", messages)
+	}
+	if server.Conns() != 1 {
+		t.Errorf("Esperada 1 conexão, obtido %d", server.Conns())
+	}
+	if server.Pipelined() != 2 {
+		t.Errorf("Os 2 envelopes deveriam chegar em pipelining, obtido %d", server.Pipelined())
+	}
+}
+
+func TestSMTPPool_LocalErrorKeepsConnection(t *testing.T) {
+	for _, pipelining := range []bool{false, true} {
+		server, cfg := newFakeSMTPServer(t)
+		if pipelining {
+			server.SetPipelining()
+		}
+		provider := NewEmailProviderExtended(cfg).(*emailProvider)
+		pool := provider.newSMTPPool()
+		defer pool.Close()
+
+		// Erro de validação local: falha só a mensagem, sem reconectar nem repetir o envio
+		err := pool.Send("cast@empresa.com", []string{"a@empresa.com\r\nRCPT TO:<b@empresa.com>"}, []byte("Subject: Aviso\r\n\r\nOi\r\n"))
+		if err == nil {
+			t.Fatalf("pipelining=%v: esperado erro para endereço com quebra de linha", pipelining)
+		}
+		if err := pool.Send("cast@empresa.com", []string{"a@empresa.com"}, []byte("Subject: Aviso\r\n\r\nOi\r\n")); err != nil {
+			t.Fatalf("pipelining=%v: a conexão deveria continuar disponível: %v", pipelining, err)
+		}
+		if len(server.Messages()) != 1 || server.Conns() != 1 {
+			t.Errorf("pipelining=%v: esperado 1 mensagem em 1 conexão, obtido %d em %d", pipelining, len(server.Messages()), server.Conns())
+		}
+	}
+}
+
+// BenchmarkEmailSend compara uma conexão por mensagem (dial, EHLO e QUIT a cada envio)
+// com o pool, que reutiliza a sessão, com e sem PIPELINING.
+func BenchmarkEmailSend(b *testing.B) {
+	recipients := []string{"a@empresa.com", "b@empresa.com", "c@empresa.com"}
+
+	b.Run("conexao-por-mensagem", func(b *testing.B) {
+		_, cfg := newFakeSMTPServer(b)
+		provider := NewEmailProviderExtended(cfg)
+		for i := 0; i < b.N; i++ {
+			if _, err := provider.SendEmailWithOptions(strings.Join(recipients, ";"), "Aviso", SendOptions{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	for _, pipelining := range []bool{false, true} {
+		name := "pool"
+		if pipelining {
+			name = "pool-pipelining"
+		}
+		b.Run(name, func(b *testing.B) {
+			server, cfg := newFakeSMTPServer(b)
+			if pipelining {
+				server.SetPipelining()
+			}
+			provider := NewEmailProviderExtended(cfg).(*emailProvider)
+			msg, err := provider.newMessage(strings.Join(recipients, ";"), "Aviso", SendOptions{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			pool := provider.newSMTPPool()
+			defer pool.Close()
+			for i := 0; i < b.N; i++ {
+				// A montagem da mensagem faz parte do custo, como no envio por conexão
+				raw, err := provider.buildMessage(msg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := pool.Send(msg.FromEmail, recipients, raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
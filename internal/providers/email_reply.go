@@ -0,0 +1,129 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/fatih/color"
+)
+
+var (
+	// ErrEmailRejected é retornado quando a resposta casa com o padrão de recusa (--reject).
+	ErrEmailRejected = errors.New("resposta recusada")
+	// ErrEmailUndecided é retornado quando a resposta não casa com nenhum dos padrões.
+	ErrEmailUndecided = errors.New("resposta sem aprovação nem recusa")
+)
+
+// EmailReplyRules define como a resposta aguardada é interpretada: padrões de aprovação
+// (--expect) e recusa (--reject), aplicados ao texto da resposta já sem a citação da
+// mensagem original, e os remetentes aceitos.
+type EmailReplyRules struct {
+	Expect  *regexp.Regexp
+	Reject  *regexp.Regexp
+	Senders []string // Remetentes aceitos (vazio = qualquer remetente)
+}
+
+// emailDecision é o resultado da interpretação da resposta.
+type emailDecision int
+
+const (
+	emailApproved emailDecision = iota
+	emailRejected
+	emailUndecided
+)
+
+// NewEmailReplyRules compila os padrões de aprovação e recusa (expressões regulares, sem
+// diferenciar maiúsculas). Padrões vazios não são aplicados.
+func NewEmailReplyRules(expect string, reject string) (*EmailReplyRules, error) {
+	rules := &EmailReplyRules{}
+	var err error
+	if expect != "" {
+		if rules.Expect, err = regexp.Compile("(?i)" + expect); err != nil {
+			return nil, fmt.Errorf("padrão --expect inválido: %w", err)
+		}
+	}
+	if reject != "" {
+		if rules.Reject, err = regexp.Compile("(?i)" + reject); err != nil {
+			return nil, fmt.Errorf("padrão --reject inválido: %w", err)
+		}
+	}
+	return rules, nil
+}
+
+// decide interpreta o texto da resposta. A recusa é verificada primeiro, para que
+// "não aprovado" não conte como aprovação. Sem --expect, toda resposta não recusada
+// aprova; com --expect, a resposta que não casa com nenhum padrão fica sem decisão.
+func (r *EmailReplyRules) decide(text string) emailDecision {
+	switch {
+	case r.Reject != nil && r.Reject.MatchString(text):
+		return emailRejected
+	case r.Expect != nil && r.Expect.MatchString(text):
+		return emailApproved
+	case r.Expect != nil:
+		return emailUndecided
+	}
+	return emailApproved
+}
+
+// result exibe a decisão (quando há padrões) e a converte no erro retornado por
+// WaitForEmailResponse: nil para aprovação, ErrEmailRejected ou ErrEmailUndecided.
+func (r *EmailReplyRules) result(response *EmailResponse) error {
+	if r.Expect == nil && r.Reject == nil {
+		return nil
+	}
+	switch r.decide(response.Body) {
+	case emailRejected:
+		color.New(color.FgRed, color.Bold).Printf("✗ Recusado por %s\n", response.From)
+		return ErrEmailRejected
+	case emailUndecided:
+		color.New(color.FgYellow, color.Bold).Printf("⚠ Resposta de %s sem aprovação nem recusa\n", response.From)
+		return ErrEmailUndecided
+	}
+	color.New(color.FgGreen, color.Bold).Printf("✓ Aprovado por %s\n", response.From)
+	return nil
+}
+
+// filterSenders mantém apenas as mensagens cujo remetente (From) está entre os aceitos,
+// comparando o endereço sem diferenciar maiúsculas. Sem remetentes, mantém todas.
+func filterSenders(c *client.Client, seqNums []uint32, senders []string, verbose bool) ([]uint32, error) {
+	if len(senders) == 0 || len(seqNums) == 0 {
+		return seqNums, nil
+	}
+	accepted := map[string]bool{}
+	for _, sender := range senders {
+		accepted[strings.ToLower(sender)] = true
+	}
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(seqNums...)
+	messages := make(chan *imap.Message, len(seqNums))
+	if err := c.Fetch(seqset, []imap.FetchItem{imap.FetchEnvelope}, messages); err != nil {
+		return nil, fmt.Errorf("erro ao verificar remetentes: %w", err)
+	}
+
+	allowed := map[uint32]bool{}
+	for msg := range messages {
+		from := ""
+		if msg.Envelope != nil && len(msg.Envelope.From) > 0 {
+			from = msg.Envelope.From[0].Address()
+		}
+		if accepted[strings.ToLower(from)] {
+			allowed[msg.SeqNum] = true
+		} else if verbose {
+			yellow := color.New(color.FgYellow)
+			yellow.Printf("[DEBUG] Resposta ignorada: remetente %s não está entre os destinatários\n", from)
+		}
+	}
+
+	var filtered []uint32
+	for _, seqNum := range seqNums {
+		if allowed[seqNum] {
+			filtered = append(filtered, seqNum)
+		}
+	}
+	return filtered, nil
+}
@@ -1,6 +1,20 @@
 package providers
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/eduardoalcantara/cast/internal/config"
@@ -37,10 +51,10 @@ func TestEmailProvider_Send_NoTargets(t *testing.T) {
 
 func TestEmailProvider_Send_MultipleTargets(t *testing.T) {
 	cfg := &config.EmailConfig{
-		SMTPHost: "smtp.example.com",
-		SMTPPort: 587,
-		Username: "user@example.com",
-		Password: "password",
+		SMTPHost:  "smtp.example.com",
+		SMTPPort:  587,
+		Username:  "user@example.com",
+		Password:  "password",
 		FromEmail: "from@example.com",
 		FromName:  "Test Sender",
 		UseTLS:    true,
@@ -81,3 +95,650 @@ func TestEmailProvider_Send_FromEmailFallback(t *testing.T) {
 		}
 	}
 }
+
+// mimeHeader é implementado por mail.Header e textproto.MIMEHeader.
+type mimeHeader interface {
+	Get(key string) string
+}
+
+// readMIMEMessage interpreta a mensagem gerada e retorna as partes folha
+// (Content-Type sem parâmetros -> conteúdo decodificado, com quebras de linha \n),
+// percorrendo multiparts aninhados.
+func readMIMEMessage(t *testing.T, raw []byte) (*mail.Message, map[string]string) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Mensagem MIME inválida: %v", err)
+	}
+	leaves := map[string]string{}
+	var walk func(header mimeHeader, body io.Reader)
+	walk = func(header mimeHeader, body io.Reader) {
+		mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("Content-Type inválido '%s': %v", header.Get("Content-Type"), err)
+		}
+		if !strings.HasPrefix(mediaType, "multipart/") {
+			// multipart.Reader já decodifica quoted-printable nas partes
+			switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+			case "quoted-printable":
+				body = quotedprintable.NewReader(body)
+			case "base64":
+				body = base64.NewDecoder(base64.StdEncoding, body)
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("Erro ao decodificar parte %s: %v", mediaType, err)
+			}
+			leaves[mediaType] = strings.ReplaceAll(string(data), "\r\n", "\n")
+			return
+		}
+		leaves[mediaType] = params["boundary"]
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatalf("Erro ao ler parte de %s: %v", mediaType, err)
+			}
+			walk(part.Header, part)
+		}
+	}
+	walk(msg.Header, msg.Body)
+	return msg, leaves
+}
+
+// decodedHeader retorna o header decodificando encoded-words (RFC 2047).
+func decodedHeader(t *testing.T, msg *mail.Message, name string) string {
+	value, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get(name))
+	if err != nil {
+		t.Fatalf("Header %s com encoded-word inválida: %v", name, err)
+	}
+	return value
+}
+
+func TestEmailProvider_BuildMessage_PlainText(t *testing.T) {
+	provider := NewEmailProvider(&config.EmailConfig{}).(*emailProvider)
+	raw, err := provider.buildMessage(&emailMessage{
+		FromName:  "CAST",
+		FromEmail: "cast@empresa.com",
+		To:        []string{"a@x.com"},
+		Subject:   "Teste",
+		MessageID: "<id@x>",
+		Body:      emailBody{Text: "Olá"},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao montar mensagem: %v", err)
+	}
+	_, parts := readMIMEMessage(t, raw)
+	if len(parts) != 1 || parts["text/plain"] != "Olá" {
+		t.Errorf("Esperado apenas text/plain, obtido %v", parts)
+	}
+}
+
+func TestEmailProvider_BuildMessage_HTMLWithAttachment(t *testing.T) {
+	attachment := filepath.Join(t.TempDir(), "relatorio.csv")
+	if err := os.WriteFile(attachment, []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewEmailProvider(&config.EmailConfig{}).(*emailProvider)
+	body, err := buildEmailBody("# Relatório\n\nTudo **ok**", "Relatório", SendOptions{Values: map[string][]string{"markdown": {"true"}}})
+	if err != nil {
+		t.Fatalf("Erro ao montar corpo: %v", err)
+	}
+	raw, err := provider.buildMessage(&emailMessage{
+		FromName:    "CAST",
+		FromEmail:   "cast@empresa.com",
+		To:          []string{"a@x.com"},
+		Subject:     "Relatório",
+		MessageID:   "<id@x>",
+		Body:        body,
+		Attachments: []string{attachment},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao montar mensagem: %v", err)
+	}
+
+	msg, parts := readMIMEMessage(t, raw)
+	if !strings.HasPrefix(msg.Header.Get("Content-Type"), "multipart/mixed") {
+		t.Errorf("Com anexos o email deveria ser multipart/mixed: %s", msg.Header.Get("Content-Type"))
+	}
+	if _, ok := parts["multipart/alternative"]; !ok {
+		t.Fatalf("multipart/alternative deveria estar aninhado no multipart/mixed: %v", parts)
+	}
+	if parts["text/plain"] != "# Relatório\n\nTudo **ok**" {
+		t.Errorf("Texto plano deveria ser o Markdown original, obtido %q", parts["text/plain"])
+	}
+	html := parts["text/html"]
+	if !strings.Contains(html, "<!DOCTYPE html>") || !strings.Contains(html, "<title>Relatório</title>") {
+		t.Errorf("HTML deveria usar o layout padrão: %s", html)
+	}
+	if !strings.Contains(html, "<h1 style=") || !strings.Contains(html, "<strong>ok</strong>") {
+		t.Errorf("Markdown não convertido com estilos inline: %s", html)
+	}
+	if parts["text/csv"] == "" && parts["application/octet-stream"] == "" {
+		t.Errorf("Anexo ausente: %v", parts)
+	}
+}
+
+func TestBuildEmailBody(t *testing.T) {
+	body, err := buildEmailBody("<p>Versão <b>2.0</b> &amp; notas</p>", "Release", SendOptions{Values: map[string][]string{"html": {"true"}}})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if body.Text != "Versão 2.0 & notas" {
+		t.Errorf("Texto plano gerado inesperado: %q", body.Text)
+	}
+
+	// Documentos completos são enviados sem o layout padrão
+	document := "<!DOCTYPE html><html><head><title>X</title><style>p{}</style></head><body><p>Oi</p></body></html>"
+	path := filepath.Join(t.TempDir(), "corpo.html")
+	if err := os.WriteFile(path, []byte(document), 0644); err != nil {
+		t.Fatal(err)
+	}
+	body, err = buildEmailBody("", "Release", SendOptions{Values: map[string][]string{"html-file": {path}}})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if body.HTML != document || body.Text != "Oi" {
+		t.Errorf("--html-file: obtido HTML %q e texto %q", body.HTML, body.Text)
+	}
+
+	if _, err := buildEmailBody("", "", SendOptions{Values: map[string][]string{"html-file": {filepath.Join(t.TempDir(), "ausente.html")}}}); err == nil {
+		t.Error("Esperado erro para arquivo HTML inexistente")
+	}
+
+	body, _ = buildEmailBody("Texto simples", "", SendOptions{})
+	if body.HTML != "" || body.Text != "Texto simples" {
+		t.Errorf("Sem flags o corpo deveria ser apenas texto: %+v", body)
+	}
+}
+
+// fakeSMTPMessage é uma mensagem recebida pelo servidor SMTP falso.
+type fakeSMTPMessage struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// fakeSMTPServer é um servidor SMTP mínimo (sem TLS) que registra o envelope e o
+// conteúdo de cada mensagem recebida. Com SetToken, exige autenticação XOAUTH2.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []fakeSMTPMessage
+	token    string   // Token XOAUTH2 aceito (vazio = sem autenticação)
+	auths    []string // Tokens apresentados no AUTH XOAUTH2
+	conns    int      // Conexões recebidas
+	reject   string   // Destinatário recusado no RCPT TO
+
+	pipelining bool // Anuncia PIPELINING no EHLO
+	pipelined  int  // Comandos MAIL FROM recebidos junto com os RCPT TO seguintes
+	maxPerConn int  // Mensagens por conexão antes de encerrá-la com 421 (0 = sem limite)
+}
+
+// SetToken passa a exigir AUTH XOAUTH2 com o token informado.
+func (s *fakeSMTPServer) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// Auths retorna os tokens apresentados nas autenticações XOAUTH2.
+func (s *fakeSMTPServer) Auths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.auths...)
+}
+
+// Conns retorna o número de conexões recebidas.
+func (s *fakeSMTPServer) Conns() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+// Reject passa a recusar o destinatário informado (550 no RCPT TO).
+func (s *fakeSMTPServer) Reject(recipient string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reject = recipient
+}
+
+// SetPipelining passa a anunciar PIPELINING no EHLO.
+func (s *fakeSMTPServer) SetPipelining() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pipelining = true
+}
+
+// Pipelined retorna quantos envelopes chegaram em pipelining.
+func (s *fakeSMTPServer) Pipelined() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pipelined
+}
+
+// SetMaxPerConnection limita as mensagens por conexão: a seguinte recebe 421 e a
+// conexão é encerrada, como nos servidores que limitam mensagens por sessão.
+func (s *fakeSMTPServer) SetMaxPerConnection(max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxPerConn = max
+}
+
+// authenticate verifica a resposta XOAUTH2 (user=...\x01auth=Bearer token\x01\x01).
+func (s *fakeSMTPServer) authenticate(initialResponse string) bool {
+	decoded, _ := base64.StdEncoding.DecodeString(initialResponse)
+	_, token, _ := strings.Cut(string(decoded), "auth=Bearer ")
+	token = strings.TrimRight(token, "\x01")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auths = append(s.auths, token)
+	return token == s.token
+}
+
+// newFakeSMTPServer inicia o servidor falso e retorna a configuração para usá-lo.
+func newFakeSMTPServer(t testing.TB) (*fakeSMTPServer, *config.EmailConfig) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao abrir servidor SMTP falso: %v", err)
+	}
+	server := &fakeSMTPServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.conns++
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return server, &config.EmailConfig{
+		SMTPHost:  "127.0.0.1",
+		SMTPPort:  addr.Port,
+		FromEmail: "cast@empresa.com",
+		FromName:  "CAST",
+	}
+}
+
+// serve atende uma conexão SMTP.
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	writer := textproto.NewWriter(bufio.NewWriter(conn))
+	writer.PrintfLine("220 fake.local ESMTP")
+
+	var current fakeSMTPMessage
+	received := 0 // Mensagens recebidas nesta conexão
+	for {
+		line, err := reader.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			lines := []string{"fake.local"}
+			s.mu.Lock()
+			if s.token != "" {
+				lines = append(lines, "AUTH XOAUTH2")
+			}
+			if s.pipelining {
+				lines = append(lines, "PIPELINING")
+			}
+			s.mu.Unlock()
+			for i, ext := range lines {
+				if i < len(lines)-1 {
+					writer.PrintfLine("250-%s", ext)
+				} else {
+					writer.PrintfLine("250 %s", ext)
+				}
+			}
+		case strings.HasPrefix(command, "AUTH XOAUTH2 "):
+			if s.authenticate(line[len("AUTH XOAUTH2 "):]) {
+				writer.PrintfLine("235 2.7.0 Accepted")
+				continue
+			}
+			// Como o Gmail: desafio com o erro em JSON, resposta vazia e recusa
+			writer.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte(`{"status":"401"}`)))
+			reader.ReadLine()
+			writer.PrintfLine("535 5.7.8 Username and Password not accepted")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.mu.Lock()
+			limited := s.maxPerConn > 0 && received >= s.maxPerConn
+			if reader.R.Buffered() > 0 {
+				s.pipelined++
+			}
+			s.mu.Unlock()
+			if limited {
+				writer.PrintfLine("421 4.7.0 Too many messages for this session")
+				return
+			}
+			current = fakeSMTPMessage{From: strings.Trim(line[len("MAIL FROM:"):], "<> ")}
+			writer.PrintfLine("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			recipient := strings.Trim(line[len("RCPT TO:"):], "<> ")
+			s.mu.Lock()
+			rejected := recipient == s.reject
+			s.mu.Unlock()
+			if rejected {
+				writer.PrintfLine("550 5.1.1 Usuário inexistente")
+				continue
+			}
+			current.To = append(current.To, recipient)
+			writer.PrintfLine("250 OK")
+		case command == "DATA":
+			writer.PrintfLine("354 Envie os dados")
+			data, err := reader.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = data
+			received++
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			writer.PrintfLine("250 OK")
+		case command == "RSET", command == "NOOP":
+			writer.PrintfLine("250 OK")
+		case command == "QUIT":
+			writer.PrintfLine("221 Bye")
+			return
+		default:
+			writer.PrintfLine("502 Comando não implementado")
+		}
+	}
+}
+
+// Messages retorna as mensagens recebidas até o momento.
+func (s *fakeSMTPServer) Messages() []fakeSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeSMTPMessage(nil), s.messages...)
+}
+
+func TestEmailProvider_Send_CcBccAndHeaders(t *testing.T) {
+	server, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+
+	opts := SendOptions{
+		Subject: "Deploy",
+		Values: map[string][]string{
+			"cc":               {"gerente@empresa.com;qa@empresa.com", "Operações <ops@empresa.com>"},
+			"bcc":              {"arquivo@empresa.com", "dev@empresa.com"},
+			"reply-to":         {"suporte@empresa.com"},
+			"header":           {"X-Ticket=INC-42", "X-Ambiente = produção"},
+			"priority":         {"urgent"},
+			"list-unsubscribe": {"https://empresa.com/sair?u=1", "mailto:sair@empresa.com"},
+		},
+	}
+	messageID, err := provider.SendEmailWithOptions("dev@empresa.com", "Deploy concluído", opts)
+	if err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Esperado 1 mensagem, obtido %d", len(messages))
+	}
+	received := messages[0]
+
+	// Envelope: To, Cc e Bcc, sem repetir dev@empresa.com
+	expectedRcpt := []string{"dev@empresa.com", "gerente@empresa.com", "qa@empresa.com", "ops@empresa.com", "arquivo@empresa.com"}
+	if strings.Join(received.To, ",") != strings.Join(expectedRcpt, ",") {
+		t.Errorf("Destinatários do envelope inesperados: %v", received.To)
+	}
+	if received.From != "cast@empresa.com" {
+		t.Errorf("MAIL FROM inesperado: %s", received.From)
+	}
+
+	msg, _ := readMIMEMessage(t, received.Data)
+	expectedHeaders := map[string]string{
+		"To":                    "dev@empresa.com",
+		"Cc":                    "gerente@empresa.com, qa@empresa.com, Operações <ops@empresa.com>",
+		"Reply-To":              "suporte@empresa.com",
+		"Message-Id":            messageID,
+		"X-Ticket":              "INC-42",
+		"X-Ambiente":            "produção",
+		"X-Priority":            "1 (Highest)",
+		"Importance":            "high",
+		"List-Unsubscribe":      "<https://empresa.com/sair?u=1>, <mailto:sair@empresa.com>",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+	for name, value := range expectedHeaders {
+		if got := decodedHeader(t, msg, name); got != value {
+			t.Errorf("Header %s = %q, esperado %q", name, got, value)
+		}
+	}
+	if _, ok := msg.Header["Bcc"]; ok || bytes.Contains(received.Data, []byte("arquivo@empresa.com")) {
+		t.Error("Destinatários em Bcc não podem aparecer nos headers")
+	}
+}
+
+func TestEmailProvider_Send_HeaderErrors(t *testing.T) {
+	_, cfg := newFakeSMTPServer(t)
+	provider := NewEmailProviderExtended(cfg)
+
+	tests := []struct {
+		values   map[string][]string
+		contains string
+	}{
+		{map[string][]string{"cc": {"nao-e-email"}}, "--cc: endereço inválido"},
+		{map[string][]string{"bcc": {"a@x.com, @"}}, "--bcc: endereço inválido"},
+		{map[string][]string{"reply-to": {"x"}}, "--reply-to: endereço inválido"},
+		{map[string][]string{"header": {"Subject=Outro"}}, "não pode ser sobrescrito"},
+		{map[string][]string{"header": {"X Espaco=1"}}, "caracteres não permitidos"},
+		{map[string][]string{"header": {"X-Injecao=a\r\nBcc: x@y.com"}}, "quebras de linha"},
+		{map[string][]string{"header": {"sem-valor"}}, "use Nome=valor"},
+		{map[string][]string{"priority": {"P1"}}, "prioridade inválida"},
+		{map[string][]string{"list-unsubscribe": {"ftp://x"}}, "--list-unsubscribe inválido"},
+	}
+	for _, tt := range tests {
+		_, err := provider.SendEmailWithOptions("dev@empresa.com", "Teste", SendOptions{Values: tt.values})
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("%v: esperado erro contendo '%s', obtido: %v", tt.values, tt.contains, err)
+		}
+	}
+}
+
+func TestEmailProvider_BuildMessage_ReplyToAddresses(t *testing.T) {
+	provider := NewEmailProvider(&config.EmailConfig{}).(*emailProvider)
+	tests := []map[string][]string{
+		{"reply-to": {"José Silva <jose@x.com>, ana@x.com"}},
+		{"header": {"Reply-To=José Silva <jose@x.com>; ana@x.com"}},
+	}
+	for _, values := range tests {
+		headers, err := buildEmailHeaders(SendOptions{Values: values})
+		if err != nil {
+			t.Fatalf("%v: erro ao montar headers: %v", values, err)
+		}
+		msg := &emailMessage{
+			FromEmail: "cast@empresa.com",
+			To:        []string{"dev@empresa.com"},
+			Subject:   "Teste",
+			MessageID: "<id@empresa.com>",
+			Headers:   headers,
+			Body:      emailBody{Text: "Olá"},
+		}
+		parsed, _ := readMIMEMessage(t, mustBuildMessage(t, provider, msg))
+
+		replyTo, err := mail.ParseAddressList(parsed.Header.Get("Reply-To"))
+		if err != nil {
+			t.Fatalf("%v: Reply-To deveria ser uma lista de endereços válida (%q): %v", values, parsed.Header.Get("Reply-To"), err)
+		}
+		if len(replyTo) != 2 || replyTo[0].Name != "José Silva" || replyTo[0].Address != "jose@x.com" || replyTo[1].Address != "ana@x.com" {
+			t.Errorf("%v: Reply-To inesperado: %v", values, replyTo)
+		}
+	}
+}
+
+func TestEmailProvider_BuildMessage_RFCEncoding(t *testing.T) {
+	attachment := filepath.Join(t.TempDir(), "Relatório Março.pdf")
+	if err := os.WriteFile(attachment, []byte("%PDF-1.4"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	subject := "Relatório de manutenção programada — atenção às mudanças na configuração dos serviços"
+	provider := NewEmailProvider(&config.EmailConfig{}).(*emailProvider)
+	msg := &emailMessage{
+		FromName:    "Notificações CAST",
+		FromEmail:   "cast@empresa.com",
+		To:          []string{"João Silva <joao@empresa.com>", "ana@empresa.com"},
+		Subject:     subject,
+		MessageID:   "<id@empresa.com>",
+		Body:        emailBody{Text: "Olá, João!\nA manutenção começa às 22h.", HTML: "<p>Olá, <b>João</b>!</p>"},
+		Attachments: []string{attachment},
+	}
+	raw, err := provider.buildMessage(msg)
+	if err != nil {
+		t.Fatalf("Erro ao montar mensagem: %v", err)
+	}
+
+	// Tudo fora dos anexos deve ser ASCII de 7 bits, com linhas de até 78 caracteres nos headers
+	for i, b := range raw {
+		if b >= 0x80 {
+			t.Fatalf("Byte não ASCII na posição %d: a mensagem deveria ser segura para 7bit", i)
+		}
+	}
+	headerBlock := string(raw[:bytes.Index(raw, []byte("\r\n\r\n"))])
+	for _, line := range strings.Split(headerBlock, "\r\n") {
+		if len(line) > 78 {
+			t.Errorf("Linha de header com mais de 78 caracteres: %q", line)
+		}
+	}
+
+	parsed, parts := readMIMEMessage(t, raw)
+	if got := decodedHeader(t, parsed, "Subject"); got != subject {
+		t.Errorf("Subject decodificado = %q, esperado %q", got, subject)
+	}
+	from, err := parsed.Header.AddressList("From")
+	if err != nil || from[0].Name != "Notificações CAST" || from[0].Address != "cast@empresa.com" {
+		t.Errorf("From inesperado: %v (%v)", from, err)
+	}
+	to, err := parsed.Header.AddressList("To")
+	if err != nil || len(to) != 2 || to[0].Name != "João Silva" {
+		t.Errorf("To inesperado: %v (%v)", to, err)
+	}
+	if _, err := parsed.Header.Date(); err != nil {
+		t.Errorf("Header Date ausente ou inválido: %v", err)
+	}
+	if parts["text/plain"] != "Olá, João!\nA manutenção começa às 22h." || parts["text/html"] != "<p>Olá, <b>João</b>!</p>" {
+		t.Errorf("Corpo decodificado inesperado: %v", parts)
+	}
+	if !strings.Contains(string(raw), "Content-Transfer-Encoding: quoted-printable") {
+		t.Error("Partes de texto deveriam usar quoted-printable")
+	}
+	if !strings.Contains(string(raw), "filename*=utf-8''Relat%C3%B3rio%20Mar%C3%A7o.pdf") {
+		t.Errorf("Nome do anexo deveria usar RFC 2231:\n%s", raw)
+	}
+	if parts["application/pdf"] != "%PDF-1.4" {
+		t.Errorf("Anexo decodificado inesperado: %q", parts["application/pdf"])
+	}
+
+	// Boundaries aleatórios: diferentes a cada mensagem e entre multiparts aninhados
+	_, again := readMIMEMessage(t, mustBuildMessage(t, provider, msg))
+	if parts["multipart/mixed"] == again["multipart/mixed"] || parts["multipart/mixed"] == parts["multipart/alternative"] {
+		t.Errorf("Boundaries deveriam ser aleatórios: %s, %s, %s", parts["multipart/mixed"], again["multipart/mixed"], parts["multipart/alternative"])
+	}
+}
+
+// mustBuildMessage monta a mensagem e falha o teste em caso de erro.
+func mustBuildMessage(t *testing.T, provider *emailProvider, msg *emailMessage) []byte {
+	raw, err := provider.buildMessage(msg)
+	if err != nil {
+		t.Fatalf("Erro ao montar mensagem: %v", err)
+	}
+	return raw
+}
+
+func TestEmailProvider_BuildMessage_InlineImages(t *testing.T) {
+	dir := t.TempDir()
+	chart := filepath.Join(dir, "chart.png")
+	if err := os.WriteFile(chart, []byte("\x89PNG-grafico"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	markdown := "# Vendas\n\n![Vendas](" + chart + ")\n\nDe novo: ![](" + chart + ") e ![logo](https://empresa.com/logo.png)"
+	body, err := buildEmailBody(markdown, "Vendas", SendOptions{Values: map[string][]string{"markdown": {"true"}}})
+	if err != nil {
+		t.Fatalf("Erro ao montar corpo: %v", err)
+	}
+	if len(body.Inline) != 1 || body.Inline[0].ContentID != "chart.png" {
+		t.Fatalf("A imagem local deveria ser embutida uma única vez como chart.png: %+v", body.Inline)
+	}
+	if strings.Count(body.HTML, `src="cid:chart.png"`) != 2 || !strings.Contains(body.HTML, `src="https://empresa.com/logo.png"`) {
+		t.Errorf("Imagens locais deveriam virar cid: e as remotas ser mantidas: %s", body.HTML)
+	}
+
+	provider := NewEmailProvider(&config.EmailConfig{}).(*emailProvider)
+	raw := mustBuildMessage(t, provider, &emailMessage{
+		FromEmail: "cast@empresa.com",
+		To:        []string{"a@x.com"},
+		Subject:   "Vendas",
+		MessageID: "<id@x>",
+		Body:      body,
+	})
+	_, parts := readMIMEMessage(t, raw)
+	for _, mediaType := range []string{"multipart/alternative", "multipart/related", "text/plain", "text/html"} {
+		if _, ok := parts[mediaType]; !ok {
+			t.Errorf("Parte %s ausente: %v", mediaType, parts)
+		}
+	}
+	if parts["image/png"] != "\x89PNG-grafico" {
+		t.Errorf("Imagem embutida inesperada: %q", parts["image/png"])
+	}
+	if !strings.Contains(string(raw), "Content-Id: <chart.png>") || !strings.Contains(string(raw), "Content-Disposition: inline; filename=chart.png") {
+		t.Errorf("Imagem deveria ter Content-ID e disposição inline:\n%s", raw)
+	}
+}
+
+func TestBuildEmailBody_InlineFlag(t *testing.T) {
+	dir := t.TempDir()
+	chart := filepath.Join(dir, "chart.png")
+	other := filepath.Join(dir, "outro", "chart.png")
+	notes := filepath.Join(dir, "notas.txt")
+	os.MkdirAll(filepath.Dir(other), 0755)
+	for _, path := range []string{chart, other, notes} {
+		if err := os.WriteFile(path, []byte("dados"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Sem HTML: o texto vira HTML e as imagens são exibidas abaixo dele
+	body, err := buildEmailBody("Segue o <gráfico>", "Vendas", SendOptions{Values: map[string][]string{"inline": {chart, other}}})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(body.Inline) != 2 || body.Inline[1].ContentID != "2-chart.png" {
+		t.Errorf("Content-IDs repetidos deveriam ser diferenciados: %+v", body.Inline)
+	}
+	if !strings.Contains(body.HTML, "Segue o &lt;gráfico&gt;") || !strings.Contains(body.HTML, `src="cid:2-chart.png"`) {
+		t.Errorf("HTML gerado inesperado: %s", body.HTML)
+	}
+
+	// Com --html, as imagens são apenas anexadas ao HTML informado
+	body, err = buildEmailBody(`<img src="cid:chart.png">`, "", SendOptions{Values: map[string][]string{"html": {"true"}, "inline": {chart}}})
+	if err != nil || len(body.Inline) != 1 || strings.Contains(body.HTML, "<p><img") {
+		t.Errorf("--html com --inline: %+v (%v)", body, err)
+	}
+
+	errors := map[string][]string{
+		"apenas imagens": {notes},
+		"não encontrada": {filepath.Join(dir, "ausente.png")},
+	}
+	for contains, inline := range errors {
+		if _, err := buildEmailBody("x", "", SendOptions{Values: map[string][]string{"inline": inline}}); err == nil || !strings.Contains(err.Error(), contains) {
+			t.Errorf("%v: esperado erro contendo '%s', obtido: %v", inline, contains, err)
+		}
+	}
+	if _, err := buildEmailBody("![x](ausente.png)", "", SendOptions{Values: map[string][]string{"markdown": {"true"}}}); err == nil {
+		t.Error("Imagem local inexistente no Markdown deveria gerar erro")
+	}
+}
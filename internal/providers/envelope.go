@@ -0,0 +1,109 @@
+package providers
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// envelopeVersion é a versão do formato do envelope publicado.
+const envelopeVersion = 1
+
+// Envelope é o documento JSON publicado pelos providers de mensageria (MQTT, NATS) e
+// gravado pelo provider file (uma linha por notificação, formato JSONL).
+// Os campos são os mesmos para todos os providers, de modo que consumidores possam
+// tratar notificações do CAST sem saber por qual canal elas chegaram.
+type Envelope struct {
+	Version   int               `json:"version"`
+	ID        string            `json:"id"`     // Identificador único da notificação
+	Source    string            `json:"source"` // Sempre "cast"
+	Provider  string            `json:"provider"`
+	Target    string            `json:"target"` // Tópico, subject ou destino
+	Subject   string            `json:"subject,omitempty"`
+	Message   string            `json:"message"`
+	Timestamp string            `json:"timestamp"` // RFC 3339 (UTC)
+	Hostname  string            `json:"hostname"`
+	Metadata  map[string]string `json:"metadata,omitempty"` // --meta chave=valor
+}
+
+// NewEnvelope monta o envelope de uma notificação, incluindo os metadados de --meta.
+func NewEnvelope(provider string, target string, message string, opts SendOptions) (*Envelope, error) {
+	hostname, _ := os.Hostname()
+	env := &Envelope{
+		Version:   envelopeVersion,
+		ID:        randomHex(16),
+		Source:    "cast",
+		Provider:  provider,
+		Target:    target,
+		Subject:   opts.Subject,
+		Message:   message,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Hostname:  hostname,
+	}
+
+	for _, meta := range opts.GetAll("meta") {
+		key, value, ok := strings.Cut(meta, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("--meta inválido: '%s' (use chave=valor)", meta)
+		}
+		if env.Metadata == nil {
+			env.Metadata = map[string]string{}
+		}
+		env.Metadata[strings.TrimSpace(key)] = value
+	}
+	return env, nil
+}
+
+// envelopePayload retorna o corpo publicado: o envelope JSON ou, com --raw, a mensagem pura.
+func envelopePayload(provider string, target string, message string, opts SendOptions) ([]byte, *Envelope, error) {
+	env, err := NewEnvelope(provider, target, message, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Get("raw") == "true" {
+		return []byte(message), env, nil
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao serializar envelope: %w", err)
+	}
+	return data, env, nil
+}
+
+// randomHex retorna n bytes aleatórios codificados em hexadecimal.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// brokerTLSConfig monta a configuração TLS para conexões com brokers (MQTT, NATS).
+func brokerTLSConfig(host string, caFile string, insecure bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: insecure,
+	}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler ca_file %s: %w", caFile, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ca_file %s não contém certificados PEM válidos", caFile)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
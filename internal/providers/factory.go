@@ -14,84 +14,31 @@ func GetProvider(name string, conf *config.Config) (Provider, error) {
 }
 
 // GetProviderWithVerbose retorna a implementação do provider baseado no nome com modo verbose.
+// O provider é resolvido pelo registro (ver Register), que concentra nomes, aliases e validação.
 func GetProviderWithVerbose(name string, conf *config.Config, verbose bool) (Provider, error) {
-	// Normaliza o nome do provider
-	providerName := normalizeProviderName(name)
-
-	// Instancia o provider baseado no nome
-	switch providerName {
-	case "telegram", "tg":
-		if conf == nil || conf.Telegram.Token == "" {
-			return nil, fmt.Errorf("configuração do Telegram não encontrada: token obrigatório")
-		}
-		if verbose {
-			return NewTelegramProviderWithVerbose(&conf.Telegram, "", true), nil
-		}
-		return NewTelegramProvider(&conf.Telegram, ""), nil
-
-	case "email", "mail":
-		if conf == nil {
-			return nil, fmt.Errorf("configuração do Email não encontrada")
-		}
-		var missing []string
-		if conf.Email.SMTPHost == "" {
-			missing = append(missing, "smtp_host")
-		}
-		if conf.Email.SMTPPort == 0 {
-			missing = append(missing, "smtp_port")
-		}
-		if len(missing) > 0 {
-			return nil, fmt.Errorf("configuração do Email incompleta: %s são obrigatórios", strings.Join(missing, ", "))
-		}
-		// Username e password são opcionais (servidores como MailHog não requerem autenticação)
-		// Retorna como EmailProviderExtended para suportar assunto e anexos
-		return NewEmailProviderExtended(&conf.Email), nil
-
-	case "whatsapp", "zap":
-		if conf == nil {
-			return nil, fmt.Errorf("configuração do WhatsApp não encontrada")
-		}
-		if conf.WhatsApp.PhoneNumberID == "" || conf.WhatsApp.AccessToken == "" {
-			return nil, fmt.Errorf("configuração do WhatsApp incompleta: phone_number_id e access_token são obrigatórios")
-		}
-		return NewWhatsAppProvider(&conf.WhatsApp), nil
+	reg, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("provider desconhecido: %s (suportados: %s)", name, strings.Join(ShortNames(), ", "))
+	}
 
-	case "google_chat", "googlechat":
-		if conf == nil {
-			return nil, fmt.Errorf("configuração do Google Chat não encontrada")
-		}
-		// Webhook URL pode estar vazia se for passada como target no comando send
-		return NewGoogleChatProvider(&conf.GoogleChat), nil
+	if conf == nil {
+		return nil, fmt.Errorf("configuração do %s não encontrada", reg.DisplayName)
+	}
 
-	case "waha":
-		if conf == nil {
-			return nil, fmt.Errorf("configuração do WAHA não encontrada")
-		}
-		if conf.WAHA.APIURL == "" {
-			return nil, fmt.Errorf("configuração do WAHA incompleta: api_url é obrigatório")
+	if reg.Validate != nil {
+		if err := reg.Validate(conf); err != nil {
+			return nil, err
 		}
-		return NewWAHAProvider(conf.WAHA)
-
-	default:
-		return nil, fmt.Errorf("provider desconhecido: %s (suportados: tg, mail, zap, google_chat, waha)", name)
 	}
+
+	return reg.New(conf, verbose)
 }
 
 // normalizeProviderName normaliza o nome do provider para comparação.
+// Nomes desconhecidos são retornados em minúsculas.
 func normalizeProviderName(name string) string {
-	name = strings.ToLower(name)
-	switch name {
-	case "tg", "telegram":
-		return "telegram"
-	case "mail", "email":
-		return "email"
-	case "zap", "whatsapp":
-		return "whatsapp"
-	case "google_chat", "googlechat":
-		return "google_chat"
-	case "waha":
-		return "waha"
-	default:
-		return name
+	if normalized := NormalizeName(name); normalized != "" {
+		return normalized
 	}
+	return strings.ToLower(name)
 }
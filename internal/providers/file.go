@@ -0,0 +1,237 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "file",
+		Aliases:     []string{"jsonl"},
+		DisplayName: "Arquivo",
+		Order:       230,
+		TargetHint:  "caminho do arquivo (ex: /var/log/cast/deploys.jsonl) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "path", Flag: "path", Label: "Arquivo padrão", Required: true},
+			{Key: "format", Flag: "file-format", Label: "Formato das linhas: jsonl ou text", Default: "jsonl", Validate: validateFileFormat},
+			{Key: "sync", Flag: "sync", Label: "Forçar gravação em disco (fsync) a cada mensagem", Kind: FieldBool},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("meta"),
+			sharedSendFlag("raw"),
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.File.Path != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewFileProviderWithVerbose(&conf.File, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testFileAccess(&conf.File, target)
+		},
+	})
+}
+
+// fileMu serializa as gravações do processo; entre processos, cada linha é gravada
+// com uma única escrita em modo O_APPEND.
+var fileMu sync.Mutex
+
+// validateFileFormat valida o formato das linhas (jsonl ou text).
+func validateFileFormat(value string) error {
+	switch value {
+	case "", "jsonl", "text":
+		return nil
+	}
+	return fmt.Errorf("formato inválido: '%s' (use jsonl ou text)", value)
+}
+
+// fileProvider implementa o Provider para arquivos de log somente-acréscimo: cada
+// mensagem vira uma linha JSON (envelope) ou uma linha de texto.
+type fileProvider struct {
+	config  *config.FileConfig
+	timeout time.Duration
+	verbose bool
+}
+
+// NewFileProvider cria uma nova instância do FileProvider.
+func NewFileProvider(cfg *config.FileConfig) Provider {
+	return NewFileProviderWithVerbose(cfg, false)
+}
+
+// NewFileProviderWithVerbose cria uma nova instância do FileProvider com modo verbose.
+func NewFileProviderWithVerbose(cfg *config.FileConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &fileProvider{
+		config:  cfg,
+		timeout: timeout,
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *fileProvider) Name() string {
+	return "file"
+}
+
+// Send acrescenta uma mensagem ao arquivo.
+func (p *fileProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions acrescenta a mensagem como uma linha ao final do arquivo, criando o
+// arquivo e os diretórios se necessário. O arquivo nunca é truncado.
+// Lógica de Target:
+// - "default" ou vazio: usa path
+// - Qualquer outro valor: caminho do arquivo
+// - Suporta múltiplos arquivos separados por vírgula ou ponto-e-vírgula
+func (p *fileProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	if err := validateFileFormat(p.config.Format); err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, path := range targets {
+		if path == "default" || path == "me" {
+			if p.config.Path == "" {
+				return fmt.Errorf("target '%s' requer path configurado", path)
+			}
+			path = p.config.Path
+		}
+
+		line, err := p.line(path, message, opts)
+		if err != nil {
+			return err
+		}
+		if err := p.appendLine(path, line); err != nil {
+			if len(targets) > 1 {
+				return fmt.Errorf("erro ao gravar em %s (target %d/%d): %w", path, i+1, len(targets), err)
+			}
+			return err
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] File: %d bytes acrescentados em %s\n", len(line), path)
+		}
+	}
+	return nil
+}
+
+// line monta a linha gravada: envelope JSON (jsonl), texto ou, com --raw, a mensagem pura.
+func (p *fileProvider) line(path string, message string, opts SendOptions) ([]byte, error) {
+	payload, env, err := envelopePayload(p.Name(), path, message, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Get("raw") == "true" || p.config.Format != "text" {
+		return append(payload, '\n'), nil
+	}
+
+	// Formato text: "TIMESTAMP HOSTNAME [assunto] mensagem", com linhas extras indentadas
+	var b strings.Builder
+	b.WriteString(env.Timestamp + " " + env.Hostname + " ")
+	if env.Subject != "" {
+		b.WriteString("[" + env.Subject + "] ")
+	}
+	b.WriteString(strings.ReplaceAll(message, "\n", "\n\t"))
+	for _, meta := range opts.GetAll("meta") {
+		b.WriteString(" " + meta)
+	}
+	b.WriteString("\n")
+	return []byte(b.String()), nil
+}
+
+// appendLine grava a linha ao final do arquivo com uma única escrita. A espera pelo
+// arquivo é limitada pelo timeout, para não travar o envio em sistemas de arquivos
+// remotos (NFS). O timeout só é reportado se a linha ainda não começou a ser gravada:
+// a gravação abandonada é descartada, e a que já começou é aguardada até o fim.
+func (p *fileProvider) appendLine(path string, line []byte) error {
+	// state: 0 = aguardando, 1 = gravando, 2 = abandonada por timeout
+	var state atomic.Int32
+	begin := func() bool { return state.CompareAndSwap(0, 1) }
+
+	done := make(chan error, 1)
+	go func() {
+		fileMu.Lock()
+		defer fileMu.Unlock()
+		done <- p.writeLine(path, line, begin)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(p.timeout):
+		if state.CompareAndSwap(0, 2) {
+			return fmt.Errorf("timeout ao gravar em %s após %v", path, p.timeout)
+		}
+		return <-done
+	}
+}
+
+// writeLine abre o arquivo em modo O_APPEND, grava a linha e, com sync, força o fsync.
+// begin é chamada antes da escrita; se retornar false, o envio foi abandonado e a linha
+// não é gravada.
+func (p *fileProvider) writeLine(path string, line []byte, begin func() bool) error {
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if !begin() {
+		return nil
+	}
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("erro ao gravar em %s: %w", path, err)
+	}
+	if p.config.Sync {
+		if err := f.Sync(); err != nil {
+			return fmt.Errorf("erro ao sincronizar %s: %w", path, err)
+		}
+	}
+	return f.Close()
+}
+
+// testFileAccess verifica se o arquivo padrão pode ser aberto para acréscimo. Com target,
+// grava uma linha de teste.
+func testFileAccess(cfg *config.FileConfig, target string) error {
+	if cfg.Path != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.Path), 0750); err != nil {
+			return fmt.Errorf("erro ao criar diretório de %s: %w", cfg.Path, err)
+		}
+		f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+		if err != nil {
+			return fmt.Errorf("erro ao abrir %s para escrita: %w", cfg.Path, err)
+		}
+		f.Close()
+	}
+
+	if target == "" {
+		return nil
+	}
+	return NewFileProvider(cfg).Send(target, "Mensagem de teste enviada por 'cast gateway test file'.")
+}
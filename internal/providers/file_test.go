@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestFileProvider_Name(t *testing.T) {
+	provider := NewFileProvider(&config.FileConfig{})
+	if provider.Name() != "file" {
+		t.Errorf("Esperado 'file', obtido '%s'", provider.Name())
+	}
+}
+
+func TestFileProvider_Send_JSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "cast.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{\"existente\":true}\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewFileProvider(&config.FileConfig{Path: path, Format: "jsonl", Sync: true}).(OptionsProvider)
+	opts := SendOptions{Subject: "Deploy", Values: map[string][]string{"meta": {"versao=1.2"}}}
+	for _, message := range []string{"Deploy iniciado", "Deploy concluído"} {
+		if err := provider.SendWithOptions("default", message, opts); err != nil {
+			t.Fatalf("Erro ao gravar: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Erro ao ler arquivo: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 3 || lines[0] != `{"existente":true}` {
+		t.Fatalf("O arquivo deveria manter a linha existente e receber 2 novas, obtido: %q", lines)
+	}
+	var env Envelope
+	if err := json.Unmarshal([]byte(lines[2]), &env); err != nil {
+		t.Fatalf("Linha não é um envelope JSON: %v", err)
+	}
+	if env.Provider != "file" || env.Target != path || env.Message != "Deploy concluído" || env.Subject != "Deploy" || env.Metadata["versao"] != "1.2" {
+		t.Errorf("Envelope inesperado: %+v", env)
+	}
+}
+
+func TestFileProvider_Send_TextAndRaw(t *testing.T) {
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "cast.log")
+	rawPath := filepath.Join(dir, "novo", "raw.log")
+
+	provider := NewFileProvider(&config.FileConfig{Format: "text"}).(OptionsProvider)
+	if err := provider.SendWithOptions(textPath, "Linha 1\nLinha 2", SendOptions{Subject: "Alerta"}); err != nil {
+		t.Fatalf("Erro ao gravar texto: %v", err)
+	}
+	if err := provider.SendWithOptions(rawPath, "ON", SendOptions{Values: map[string][]string{"raw": {"true"}}}); err != nil {
+		t.Fatalf("Erro ao gravar com --raw: %v", err)
+	}
+
+	text, _ := os.ReadFile(textPath)
+	if !strings.HasSuffix(string(text), " [Alerta] Linha 1\n\tLinha 2\n") {
+		t.Errorf("Linha de texto inesperada: %q", text)
+	}
+	raw, _ := os.ReadFile(rawPath)
+	if string(raw) != "ON\n" {
+		t.Errorf("Com --raw deveria gravar apenas a mensagem, obtido %q", raw)
+	}
+}
+
+func TestFileProvider_Errors(t *testing.T) {
+	if err := NewFileProvider(&config.FileConfig{}).Send("default", "Teste"); err == nil || !strings.Contains(err.Error(), "requer path") {
+		t.Errorf("Esperado erro de path não configurado, obtido: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "cast.log")
+	if err := NewFileProvider(&config.FileConfig{Format: "xml"}).Send(path, "Teste"); err == nil || !strings.Contains(err.Error(), "formato inválido") {
+		t.Errorf("Esperado erro de formato, obtido: %v", err)
+	}
+}
+
+func TestFileProvider_TimeoutDiscardsLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cast.log")
+	provider := NewFileProvider(&config.FileConfig{Path: path}).(*fileProvider)
+	provider.timeout = 20 * time.Millisecond
+
+	// Outra gravação em andamento segura o arquivo além do timeout
+	fileMu.Lock()
+	err := provider.appendLine(path, []byte("atrasada\n"))
+	fileMu.Unlock()
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("Esperado erro de timeout, obtido: %v", err)
+	}
+
+	// A linha abandonada não pode ser gravada depois que o arquivo é liberado
+	if err := provider.appendLine(path, []byte("seguinte\n")); err != nil {
+		t.Fatalf("Erro ao gravar: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Erro ao ler arquivo: %v", err)
+	}
+	if string(data) != "seguinte\n" {
+		t.Errorf("A linha que deu timeout não deveria ser gravada, obtido: %q", data)
+	}
+}
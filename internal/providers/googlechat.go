@@ -6,15 +6,60 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/eduardoalcantara/cast/internal/config"
 )
 
+func init() {
+	Register(&Registration{
+		Name:        "google_chat",
+		Aliases:     []string{"googlechat"},
+		DisplayName: "Google Chat",
+		Order:       40,
+		TargetHint:  "webhook_url ou 'default'",
+		Fields: []ConfigField{
+			{Key: "webhook_url", Flag: "webhook-url", Label: "Webhook URL", Required: true, Secret: true, Validate: validateGoogleChatWebhook},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{MultipleTargets: true, HTTP: true},
+		// Webhook URL pode estar vazia se for passada como target no comando send
+		Configured: func(conf *config.Config) bool {
+			return conf.GoogleChat.WebhookURL != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewGoogleChatProvider(&conf.GoogleChat), nil
+		},
+		URLSchemes: []string{"gchat"},
+		FromURL:    googleChatFromURL,
+	})
+}
+
+// googleChatFromURL interpreta gchat://ESPACO/CHAVE/TOKEN, a partir da URL do webhook
+// https://chat.googleapis.com/v1/spaces/ESPACO/messages?key=CHAVE&token=TOKEN.
+func googleChatFromURL(u *ProviderURL, conf *config.Config) (string, error) {
+	if u.Host == "" || len(u.Path) != 2 {
+		return "", fmt.Errorf("use gchat://ESPACO/CHAVE/TOKEN")
+	}
+	conf.GoogleChat.WebhookURL = fmt.Sprintf("https://chat.googleapis.com/v1/spaces/%s/messages?key=%s&token=%s",
+		url.PathEscape(u.Host), url.QueryEscape(u.Path[0]), url.QueryEscape(u.Path[1]))
+	return "default", nil
+}
+
+// validateGoogleChatWebhook valida se a URL é um webhook do Google Chat.
+func validateGoogleChatWebhook(value string) error {
+	if value != "" && !strings.HasPrefix(value, "https://chat.googleapis.com/") {
+		return fmt.Errorf("webhook URL deve começar com https://chat.googleapis.com/")
+	}
+	return nil
+}
+
 // googleChatProvider implementa o Provider para Google Chat (Incoming Webhooks).
 type googleChatProvider struct {
-	config *config.GoogleChatConfig
+	config    *config.GoogleChatConfig
+	transport http.RoundTripper // Transporte HTTP (nil = padrão)
 }
 
 // NewGoogleChatProvider cria uma nova instância do GoogleChatProvider.
@@ -29,6 +74,11 @@ func (p *googleChatProvider) Name() string {
 	return "google_chat"
 }
 
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *googleChatProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.transport = transport
+}
+
 // Send envia uma mensagem via Google Chat (Incoming Webhook).
 // Lógica de Target:
 // - Se target for uma URL completa (começa com https://), usa essa URL
@@ -110,9 +160,7 @@ func (p *googleChatProvider) sendToWebhook(webhookURL string, message string) er
 		timeout = 30 * time.Second
 	}
 
-	client := &http.Client{
-		Timeout: timeout,
-	}
+	client := &http.Client{Timeout: timeout, Transport: p.transport}
 
 	// Executa requisição
 	resp, err := client.Do(req)
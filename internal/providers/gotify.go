@@ -0,0 +1,251 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "gotify",
+		DisplayName: "Gotify",
+		Order:       110,
+		TargetHint:  "'default' ou token de outra aplicação",
+		Fields: []ConfigField{
+			{Key: "server_url", Flag: "server-url", Label: "URL do servidor", Required: true, Validate: ValidateHTTPURL},
+			{Key: "app_token", Flag: "app-token", Label: "Token da aplicação", Required: true, Secret: true},
+			{Key: "default_priority", Flag: "default-priority", Label: "Prioridade padrão (0-10)", Kind: FieldInt, Default: "5", Validate: validateGotifyPriority},
+			{Key: "markdown", Flag: "markdown", Label: "Mensagens em Markdown", Kind: FieldBool},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			Subject: true,
+			HTTP:    true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("priority"),
+			sharedSendFlag("click"),
+			sharedSendFlag("markdown"),
+			{Name: "extras", Usage: "Extras da mensagem em JSON ou @arquivo.json (Gotify)"},
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Gotify.ServerURL != "" && conf.Gotify.AppToken != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewGotifyProvider(&conf.Gotify), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testGotifyConnection(&conf.Gotify, target)
+		},
+	})
+}
+
+// gotifyPriorities mapeia os nomes de prioridade (os mesmos do ntfy) para a escala 0-10 do Gotify.
+var gotifyPriorities = map[string]int{
+	"min":     0,
+	"low":     2,
+	"default": 5,
+	"high":    8,
+	"urgent":  10,
+	"max":     10,
+}
+
+// parseGotifyPriority converte a prioridade (nome ou número 0-10).
+func parseGotifyPriority(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if p, ok := gotifyPriorities[value]; ok {
+		return p, nil
+	}
+	p, err := strconv.Atoi(value)
+	if err != nil || p < 0 || p > 10 {
+		return 0, fmt.Errorf("prioridade inválida: '%s' (use min, low, default, high, urgent ou 0-10)", value)
+	}
+	return p, nil
+}
+
+// validateGotifyPriority valida a prioridade padrão configurada.
+func validateGotifyPriority(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseGotifyPriority(value)
+	return err
+}
+
+// gotifyMessage é o payload de POST /message.
+type gotifyMessage struct {
+	Title    string                 `json:"title,omitempty"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+// gotifyProvider implementa o Provider para Gotify.
+type gotifyProvider struct {
+	config *config.GotifyConfig
+	client *http.Client
+}
+
+// NewGotifyProvider cria uma nova instância do GotifyProvider.
+func NewGotifyProvider(cfg *config.GotifyConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &gotifyProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *gotifyProvider) Name() string {
+	return "gotify"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *gotifyProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma notificação via Gotify.
+func (p *gotifyProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma notificação via Gotify.
+// O assunto (--subject) vira o título; --markdown e --click são convertidos nos
+// extras client::display e client::notification; --extras acrescenta extras livres.
+// Lógica de Target:
+// - "default" ou vazio: usa o app_token configurado
+// - Qualquer outro valor: usado como token de aplicação (envio para outra aplicação)
+func (p *gotifyProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	msg, err := buildGotifyMessage(p.config, message, opts)
+	if err != nil {
+		return err
+	}
+
+	token := p.config.AppToken
+	if target != "" && target != "default" && target != "me" {
+		token = target
+	}
+	if token == "" {
+		return fmt.Errorf("app_token do Gotify não configurado")
+	}
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", strings.TrimSuffix(p.config.ServerURL, "/")+"/message", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", token)
+
+	return p.do(req)
+}
+
+// buildGotifyMessage monta a mensagem com prioridade e extras.
+func buildGotifyMessage(cfg *config.GotifyConfig, message string, opts SendOptions) (gotifyMessage, error) {
+	msg := gotifyMessage{
+		Title:    opts.Subject,
+		Message:  message,
+		Priority: cfg.DefaultPriority,
+	}
+
+	if raw := opts.Get("priority"); raw != "" {
+		priority, err := parseGotifyPriority(raw)
+		if err != nil {
+			return msg, err
+		}
+		msg.Priority = priority
+	}
+
+	extras := map[string]interface{}{}
+	if raw := opts.Get("extras"); raw != "" {
+		value, err := readValueOrFile(raw)
+		if err != nil {
+			return msg, err
+		}
+		if err := json.Unmarshal([]byte(value), &extras); err != nil {
+			return msg, fmt.Errorf("extras inválidos (esperado objeto JSON): %w", err)
+		}
+	}
+
+	if cfg.Markdown || opts.Get("markdown") == "true" {
+		extras["client::display"] = map[string]string{"contentType": "text/markdown"}
+	}
+	if click := opts.Get("click"); click != "" {
+		if err := ValidateHTTPURL(click); err != nil {
+			return msg, fmt.Errorf("--click: %w", err)
+		}
+		extras["client::notification"] = map[string]interface{}{
+			"click": map[string]string{"url": click},
+		}
+	}
+
+	if len(extras) > 0 {
+		msg.Extras = extras
+	}
+	return msg, nil
+}
+
+// do executa a requisição e converte erros do Gotify ({"error":"Unauthorized","errorCode":401,"errorDescription":"..."}).
+func (p *gotifyProvider) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var apiErr struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"errorDescription"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("token da aplicação inválido: %s", apiErr.ErrorDescription)
+		}
+		return fmt.Errorf("Gotify retornou status %d: %s: %s", resp.StatusCode, apiErr.Error, apiErr.ErrorDescription)
+	}
+	return fmt.Errorf("Gotify retornou status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// testGotifyConnection verifica o servidor via /version. Como o token da aplicação
+// só pode ser validado publicando, a mensagem de teste só é enviada se target for informado.
+func testGotifyConnection(cfg *config.GotifyConfig, target string) error {
+	if err := ValidateHTTPURL(cfg.ServerURL); err != nil || cfg.ServerURL == "" {
+		return fmt.Errorf("server_url inválida: %s", cfg.ServerURL)
+	}
+
+	p := NewGotifyProvider(cfg).(*gotifyProvider)
+	req, err := http.NewRequest("GET", strings.TrimSuffix(cfg.ServerURL, "/")+"/version", nil)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	if err := p.do(req); err != nil {
+		return fmt.Errorf("servidor Gotify indisponível: %w", err)
+	}
+
+	if target == "" {
+		return nil
+	}
+	opts := SendOptions{Subject: "CAST - Teste de conectividade"}
+	return p.SendWithOptions(target, "Notificação de teste enviada por 'cast gateway test gotify'.", opts)
+}
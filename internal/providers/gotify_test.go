@@ -0,0 +1,142 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestGotifyProvider_Name(t *testing.T) {
+	provider := NewGotifyProvider(&config.GotifyConfig{})
+	if provider.Name() != "gotify" {
+		t.Errorf("Esperado 'gotify', obtido '%s'", provider.Name())
+	}
+}
+
+func TestGotifyProvider_Send_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/message" {
+			t.Errorf("Esperado POST /message, obtido %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Gotify-Key") != "AppToken123" {
+			t.Errorf("Esperado X-Gotify-Key 'AppToken123', obtido '%s'", r.Header.Get("X-Gotify-Key"))
+		}
+
+		var msg gotifyMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if msg.Title != "Backup" || msg.Message != "Backup concluído" {
+			t.Errorf("Mensagem inesperada: %+v", msg)
+		}
+		if msg.Priority != 5 {
+			t.Errorf("Esperado priority padrão 5, obtido %d", msg.Priority)
+		}
+		if msg.Extras != nil {
+			t.Errorf("Mensagem simples não deveria conter extras: %v", msg.Extras)
+		}
+
+		w.Write([]byte(`{"id":1,"appid":1,"message":"Backup concluído"}`))
+	}))
+	defer server.Close()
+
+	provider := NewGotifyProvider(&config.GotifyConfig{ServerURL: server.URL + "/", AppToken: "AppToken123", DefaultPriority: 5}).(OptionsProvider)
+	if err := provider.SendWithOptions("default", "Backup concluído", SendOptions{Subject: "Backup"}); err != nil {
+		t.Fatalf("Erro ao enviar notificação: %v", err)
+	}
+}
+
+func TestGotifyProvider_Send_Extras(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Priority int `json:"priority"`
+			Extras   map[string]map[string]interface{}
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if msg.Priority != 8 {
+			t.Errorf("Esperado priority 8 (high), obtido %d", msg.Priority)
+		}
+		if msg.Extras["client::display"]["contentType"] != "text/markdown" {
+			t.Errorf("Esperado contentType text/markdown, obtido %v", msg.Extras["client::display"])
+		}
+		click := msg.Extras["client::notification"]["click"].(map[string]interface{})
+		if click["url"] != "https://status.exemplo.com" {
+			t.Errorf("Esperado click url, obtido %v", click)
+		}
+		if msg.Extras["android::action"]["onReceive"] == nil {
+			t.Errorf("Extras livres não foram repassados: %v", msg.Extras)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewGotifyProvider(&config.GotifyConfig{ServerURL: server.URL, AppToken: "AppToken123", DefaultPriority: 5}).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{
+		"priority": {"high"},
+		"markdown": {"true"},
+		"click":    {"https://status.exemplo.com"},
+		"extras":   {`{"android::action":{"onReceive":{"intentUrl":"https://exemplo.com"}}}`},
+	}}
+	if err := provider.SendWithOptions("default", "**Serviço** fora do ar", opts); err != nil {
+		t.Fatalf("Erro ao enviar notificação: %v", err)
+	}
+}
+
+func TestGotifyProvider_Send_Unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Unauthorized","errorCode":401,"errorDescription":"you need to provide a valid access token or user credentials to access this api"}`))
+	}))
+	defer server.Close()
+
+	provider := NewGotifyProvider(&config.GotifyConfig{ServerURL: server.URL, AppToken: "invalido"})
+	err := provider.Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "token da aplicação inválido") {
+		t.Errorf("Esperado erro de token inválido, obtido: %v", err)
+	}
+}
+
+func TestParseGotifyPriority(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{"0", 0, false},
+		{"10", 10, false},
+		{"urgent", 10, false},
+		{"LOW", 2, false},
+		{"11", 0, true},
+		{"alta", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGotifyPriority(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseGotifyPriority(%q) erro = %v, esperado erro = %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("parseGotifyPriority(%q) = %d, esperado %d", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestGotifyConnection_Version(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			t.Errorf("Teste sem target não deveria publicar (path %s)", r.URL.Path)
+		}
+		w.Write([]byte(`{"version":"2.4.0","commit":"abc","buildDate":"2023-01-01"}`))
+	}))
+	defer server.Close()
+
+	if err := testGotifyConnection(&config.GotifyConfig{ServerURL: server.URL, AppToken: "AppToken123"}, ""); err != nil {
+		t.Errorf("Servidor válido não deveria retornar erro: %v", err)
+	}
+}
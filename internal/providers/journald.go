@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "journald",
+		Aliases:     []string{"journal"},
+		DisplayName: "systemd-journald",
+		Order:       220,
+		TargetHint:  "SYSLOG_IDENTIFIER da entrada (ex: deploy) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "identifier", Flag: "identifier", Label: "SYSLOG_IDENTIFIER padrão", Required: true, Default: "cast"},
+			{Key: "facility", Flag: "facility", Label: "Facility (user, daemon, local0..local7...)", Default: "user", Validate: validateSyslogFacility},
+			{Key: "default_severity", Flag: "default-severity", Label: "Severidade padrão (emerg, alert, crit, err, warning, notice, info, debug)", Default: "info", Validate: validateSyslogSeverity},
+			{Key: "socket_path", Flag: "socket-path", Label: "Socket do journald", Default: "/run/systemd/journal/socket"},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("severity"),
+			sharedSendFlag("facility"),
+			sharedSendFlag("meta"),
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Journald.Identifier != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewJournaldProviderWithVerbose(&conf.Journald, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testJournaldConnection(&conf.Journald, target)
+		},
+	})
+}
+
+// journaldReservedFields são os campos preenchidos pelo próprio provider, que não
+// podem ser sobrescritos por --meta.
+var journaldReservedFields = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_FACILITY":   true,
+	"SYSLOG_IDENTIFIER": true,
+	"SUBJECT":           true,
+}
+
+// journaldFieldName converte a chave de --meta no nome de campo do journal:
+// maiúsculas, dígitos e '_', sem começar com '_' ou dígito, até 64 caracteres.
+func journaldFieldName(key string) (string, error) {
+	name := strings.ToUpper(strings.TrimSpace(key))
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+
+	switch {
+	case name == "" || len(name) > 64:
+		return "", fmt.Errorf("nome de metadado inválido para journald: '%s' (até 64 caracteres)", key)
+	case name[0] == '_' || (name[0] >= '0' && name[0] <= '9'):
+		return "", fmt.Errorf("nome de metadado inválido para journald: '%s' (deve começar com letra)", key)
+	case journaldReservedFields[name]:
+		return "", fmt.Errorf("metadado '%s' é reservado no journald", key)
+	}
+	return name, nil
+}
+
+// journaldProvider implementa o Provider para o systemd-journald usando o protocolo
+// nativo (datagramas no socket /run/systemd/journal/socket).
+type journaldProvider struct {
+	config  *config.JournaldConfig
+	timeout time.Duration
+	verbose bool
+}
+
+// NewJournaldProvider cria uma nova instância do JournaldProvider.
+func NewJournaldProvider(cfg *config.JournaldConfig) Provider {
+	return NewJournaldProviderWithVerbose(cfg, false)
+}
+
+// NewJournaldProviderWithVerbose cria uma nova instância do JournaldProvider com modo verbose.
+func NewJournaldProviderWithVerbose(cfg *config.JournaldConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &journaldProvider{
+		config:  cfg,
+		timeout: timeout,
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *journaldProvider) Name() string {
+	return "journald"
+}
+
+// Send grava uma entrada no journal.
+func (p *journaldProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions grava a entrada com MESSAGE, PRIORITY, SYSLOG_FACILITY, SYSLOG_IDENTIFIER,
+// SUBJECT (quando informado) e um campo por --meta (ex: --meta servico=api vira SERVICO=api).
+// Lógica de Target:
+// - "default" ou vazio: usa identifier (padrão "cast")
+// - Qualquer outro valor: SYSLOG_IDENTIFIER da entrada (ex: deploy)
+// - Suporta múltiplos identificadores separados por vírgula ou ponto-e-vírgula
+func (p *journaldProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	pri, err := syslogPriority(p.config.Facility, p.config.DefaultSeverity, opts)
+	if err != nil {
+		return err
+	}
+
+	var extra [][2]string
+	if opts.Subject != "" {
+		extra = append(extra, [2]string{"SUBJECT", opts.Subject})
+	}
+	for _, meta := range opts.GetAll("meta") {
+		key, value, ok := strings.Cut(meta, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("--meta inválido: '%s' (use chave=valor)", meta)
+		}
+		name, err := journaldFieldName(key)
+		if err != nil {
+			return err
+		}
+		extra = append(extra, [2]string{name, value})
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for i, identifier := range targets {
+		if identifier == "default" || identifier == "me" {
+			identifier = p.config.Identifier
+		}
+		if identifier == "" {
+			identifier = "cast"
+		}
+
+		var entry bytes.Buffer
+		journaldField(&entry, "MESSAGE", message)
+		journaldField(&entry, "PRIORITY", strconv.Itoa(pri%8))
+		journaldField(&entry, "SYSLOG_FACILITY", strconv.Itoa(pri/8))
+		journaldField(&entry, "SYSLOG_IDENTIFIER", identifier)
+		for _, field := range extra {
+			journaldField(&entry, field[0], field[1])
+		}
+
+		if _, err := conn.Write(entry.Bytes()); err != nil {
+			if len(targets) > 1 {
+				return fmt.Errorf("erro ao gravar no journald (target %d/%d, %d bytes): %w", i+1, len(targets), entry.Len(), err)
+			}
+			return fmt.Errorf("erro ao gravar no journald (%d bytes): %w", entry.Len(), err)
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Journald: entrada gravada (SYSLOG_IDENTIFIER=%s, PRIORITY=%d, %d bytes)\n", identifier, pri%8, entry.Len())
+		}
+	}
+	return nil
+}
+
+// journaldField serializa um campo no protocolo nativo. Valores sem quebra de linha usam
+// "CAMPO=valor\n"; os demais, "CAMPO\n" + tamanho (64 bits little-endian) + valor + "\n".
+func journaldField(buf *bytes.Buffer, name string, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(name + "=" + value + "\n")
+		return
+	}
+	buf.WriteString(name + "\n")
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value + "\n")
+}
+
+// dial abre o socket de datagramas do journald.
+func (p *journaldProvider) dial() (*net.UnixConn, error) {
+	socketPath := p.config.SocketPath
+	if socketPath == "" {
+		socketPath = "/run/systemd/journal/socket"
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar com o journald em %s: %w", socketPath, err)
+	}
+	conn.SetWriteDeadline(time.Now().Add(p.timeout))
+	return conn, nil
+}
+
+// testJournaldConnection testa o acesso ao socket do journald. Com target, grava uma entrada de teste.
+func testJournaldConnection(cfg *config.JournaldConfig, target string) error {
+	p := NewJournaldProvider(cfg).(*journaldProvider)
+	conn, err := p.dial()
+	if err != nil {
+		return err
+	}
+	conn.Close()
+
+	if target == "" {
+		return nil
+	}
+	return p.Send(target, "Mensagem de teste enviada por 'cast gateway test journald'.")
+}
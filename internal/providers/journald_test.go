@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// fakeJournald abre um socket de datagramas no lugar do journald e retorna o caminho.
+func fakeJournald(t *testing.T) (string, *net.UnixConn) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sockets unixgram não são suportados no Windows")
+	}
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Erro ao abrir socket falso: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return path, conn
+}
+
+// decodeJournaldEntry interpreta uma entrada no protocolo nativo do journald.
+func decodeJournaldEntry(t *testing.T, data []byte) map[string]string {
+	fields := map[string]string{}
+	for len(data) > 0 {
+		nl := bytes.IndexByte(data, '\n')
+		if nl < 0 {
+			t.Fatalf("Entrada truncada: %q", data)
+		}
+		line := string(data[:nl])
+		data = data[nl+1:]
+		if name, value, ok := strings.Cut(line, "="); ok {
+			fields[name] = value
+			continue
+		}
+		size := binary.LittleEndian.Uint64(data[:8])
+		fields[line] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+	return fields
+}
+
+func TestJournaldProvider_Name(t *testing.T) {
+	provider := NewJournaldProvider(&config.JournaldConfig{})
+	if provider.Name() != "journald" {
+		t.Errorf("Esperado 'journald', obtido '%s'", provider.Name())
+	}
+}
+
+func TestJournaldProvider_Send(t *testing.T) {
+	path, conn := fakeJournald(t)
+
+	provider := NewJournaldProvider(&config.JournaldConfig{
+		SocketPath:      path,
+		Identifier:      "cast",
+		Facility:        "daemon",
+		DefaultSeverity: "info",
+	}).(OptionsProvider)
+	opts := SendOptions{
+		Subject: "Backup",
+		Values:  map[string][]string{"severity": {"warning"}, "meta": {"servico=api", "build-id=42"}},
+	}
+	if err := provider.SendWithOptions("default", "Backup lento\nDuração: 2h", opts); err != nil {
+		t.Fatalf("Erro ao gravar: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Erro ao ler datagrama: %v", err)
+	}
+	fields := decodeJournaldEntry(t, buf[:n])
+
+	expected := map[string]string{
+		"MESSAGE":           "Backup lento\nDuração: 2h",
+		"PRIORITY":          "4",
+		"SYSLOG_FACILITY":   "3",
+		"SYSLOG_IDENTIFIER": "cast",
+		"SUBJECT":           "Backup",
+		"SERVICO":           "api",
+		"BUILD_ID":          "42",
+	}
+	for name, value := range expected {
+		if fields[name] != value {
+			t.Errorf("Campo %s = %q, esperado %q", name, fields[name], value)
+		}
+	}
+}
+
+func TestJournaldProvider_Errors(t *testing.T) {
+	path, _ := fakeJournald(t)
+	provider := NewJournaldProvider(&config.JournaldConfig{SocketPath: path}).(OptionsProvider)
+
+	tests := []struct {
+		meta     string
+		contains string
+	}{
+		{"_PID=1", "deve começar com letra"},
+		{"message=x", "reservado"},
+		{"sem-valor", "--meta inválido"},
+	}
+	for _, tt := range tests {
+		err := provider.SendWithOptions("default", "Teste", SendOptions{Values: map[string][]string{"meta": {tt.meta}}})
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("--meta %s: esperado erro contendo '%s', obtido: %v", tt.meta, tt.contains, err)
+		}
+	}
+
+	missing := NewJournaldProvider(&config.JournaldConfig{SocketPath: filepath.Join(t.TempDir(), "ausente.sock")})
+	if err := missing.Send("default", "Teste"); err == nil || !strings.Contains(err.Error(), "erro ao conectar com o journald") {
+		t.Errorf("Esperado erro de conexão, obtido: %v", err)
+	}
+}
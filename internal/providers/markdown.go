@@ -0,0 +1,175 @@
+package providers
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Padrões de bloco do Markdown suportado (subconjunto do CommonMark usado em notificações).
+var (
+	mdHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRulePattern    = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdBulletPattern  = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrderedPattern = regexp.MustCompile(`^\s*(\d{1,9})[.)]\s+(.*)$`)
+	mdQuotePattern   = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdFencePattern   = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// Padrões inline, aplicados sobre o texto já escapado.
+var (
+	mdImagePattern  = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdLinkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldPattern   = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdItalicPattern = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*|\b_([^_\s](?:[^_]*[^_\s])?)_\b`)
+	mdStrikePattern = regexp.MustCompile(`~~(.+?)~~`)
+)
+
+// markdownRenderer converte Markdown em HTML. ImageSrc, se definido, pode reescrever
+// o endereço das imagens (ex: arquivos locais viram referências cid: no email).
+type markdownRenderer struct {
+	ImageSrc func(src string) string
+}
+
+// markdownToHTML converte Markdown em HTML: títulos, parágrafos, ênfase, código,
+// links, imagens, listas, citações e linhas horizontais. Quebras de linha dentro de
+// um parágrafo são preservadas (<br>), como esperado em mensagens de notificação.
+func markdownToHTML(src string) string {
+	return (&markdownRenderer{}).render(src)
+}
+
+// render converte o documento Markdown em HTML.
+func (r *markdownRenderer) render(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var out strings.Builder
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		inline := make([]string, len(paragraph))
+		for i, line := range paragraph {
+			inline[i] = r.inline(strings.TrimSpace(line))
+		}
+		out.WriteString("<p>" + strings.Join(inline, "<br>\n") + "</p>\n")
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case mdFencePattern.MatchString(line):
+			flush()
+			fence := mdFencePattern.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case mdHeadingPattern.MatchString(line):
+			flush()
+			m := mdHeadingPattern.FindStringSubmatch(line)
+			level := strconv.Itoa(len(m[1]))
+			out.WriteString("<h" + level + ">" + r.inline(m[2]) + "</h" + level + ">\n")
+
+		case mdRulePattern.MatchString(line):
+			flush()
+			out.WriteString("<hr>\n")
+
+		case mdQuotePattern.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && mdQuotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			out.WriteString("<blockquote>\n" + r.render(strings.Join(quoted, "\n")) + "</blockquote>\n")
+
+		case mdBulletPattern.MatchString(line), mdOrderedPattern.MatchString(line):
+			flush()
+			ordered := !mdBulletPattern.MatchString(line)
+			pattern, tag := mdBulletPattern, "ul"
+			if ordered {
+				pattern, tag = mdOrderedPattern, "ol"
+			}
+			out.WriteString("<" + tag + r.listStart(line, ordered) + ">\n")
+			for ; i < len(lines) && pattern.MatchString(lines[i]); i++ {
+				m := pattern.FindStringSubmatch(lines[i])
+				out.WriteString("<li>" + r.inline(m[len(m)-1]) + "</li>\n")
+			}
+			i--
+			out.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// listStart retorna o atributo start de listas numeradas que não começam em 1.
+func (r *markdownRenderer) listStart(line string, ordered bool) string {
+	if !ordered {
+		return ""
+	}
+	start := mdOrderedPattern.FindStringSubmatch(line)[1]
+	if n, _ := strconv.Atoi(start); n != 1 {
+		return fmt.Sprintf(` start="%d"`, n)
+	}
+	return ""
+}
+
+// inline converte a marcação inline de uma linha. Código, imagens e endereços de links
+// são protegidos por marcadores para não receberem ênfase.
+func (r *markdownRenderer) inline(text string) string {
+	var protected []string
+	protect := func(s string) string {
+		protected = append(protected, s)
+		return "\x00" + strconv.Itoa(len(protected)-1) + "\x00"
+	}
+
+	// Código inline (`código`): o conteúdo é escapado e não recebe outra formatação
+	var escaped strings.Builder
+	segments := strings.Split(text, "`")
+	for i, segment := range segments {
+		switch {
+		case i%2 == 1 && i < len(segments)-1:
+			escaped.WriteString(protect("<code>" + html.EscapeString(segment) + "</code>"))
+		case i%2 == 1:
+			escaped.WriteString("`" + html.EscapeString(segment)) // Crase sem par
+		default:
+			escaped.WriteString(html.EscapeString(segment))
+		}
+	}
+	text = escaped.String()
+
+	text = mdImagePattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdImagePattern.FindStringSubmatch(m)
+		src := html.UnescapeString(parts[2])
+		if r.ImageSrc != nil {
+			src = r.ImageSrc(src)
+		}
+		return protect(`<img src="` + html.EscapeString(src) + `" alt="` + parts[1] + `">`)
+	})
+	text = mdLinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdLinkPattern.FindStringSubmatch(m)
+		return protect(`<a href="`+parts[2]+`">`) + parts[1] + "</a>"
+	})
+
+	text = mdBoldPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = mdItalicPattern.ReplaceAllString(text, "<em>$1$2</em>")
+	text = mdStrikePattern.ReplaceAllString(text, "<del>$1</del>")
+
+	for i := len(protected) - 1; i >= 0; i-- {
+		text = strings.ReplaceAll(text, "\x00"+strconv.Itoa(i)+"\x00", protected[i])
+	}
+	return text
+}
@@ -0,0 +1,29 @@
+package providers
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"título e parágrafo", "# Deploy\n\nVersão **1.2** _ok_", "<h1>Deploy</h1>\n<p>Versão <strong>1.2</strong> <em>ok</em></p>"},
+		{"quebra de linha", "Linha 1\nLinha 2", "<p>Linha 1<br>\nLinha 2</p>"},
+		{"escape de HTML", "a < b & <script>", "<p>a &lt; b &amp; &lt;script&gt;</p>"},
+		{"código inline protegido", "Use `**cast** <x>`", "<p>Use <code>**cast** &lt;x&gt;</code></p>"},
+		{"link com sublinhado", "[docs](https://x.com/a_b_c)", `<p><a href="https://x.com/a_b_c">docs</a></p>`},
+		{"imagem", "![gráfico](chart.png)", `<p><img src="chart.png" alt="gráfico"></p>`},
+		{"lista", "- um\n- ~~dois~~", "<ul>\n<li>um</li>\n<li><del>dois</del></li>\n</ul>"},
+		{"lista numerada", "3. três\n4. quatro", "<ol start=\"3\">\n<li>três</li>\n<li>quatro</li>\n</ol>"},
+		{"citação", "> atenção\n> agora", "<blockquote>\n<p>atenção<br>\nagora</p></blockquote>"},
+		{"bloco de código", "```\nif a < b {\n```", "<pre><code>if a &lt; b {</code></pre>"},
+		{"linha horizontal", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>"},
+		{"snake_case", "use cast_send_url", "<p>use cast_send_url</p>"},
+	}
+	for _, tt := range tests {
+		if got := markdownToHTML(tt.markdown); got != tt.expected {
+			t.Errorf("%s:\n obtido: %q\nesperado: %q", tt.name, got, tt.expected)
+		}
+	}
+}
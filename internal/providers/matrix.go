@@ -0,0 +1,441 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "matrix",
+		DisplayName: "Matrix",
+		Order:       120,
+		TargetHint:  "room ID (!abc:servidor), alias (#sala:servidor) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "homeserver_url", Flag: "homeserver-url", Label: "URL do homeserver", Required: true, Validate: ValidateHTTPURL},
+			{Key: "access_token", Flag: "access-token", Label: "Access Token", Required: true, Secret: true},
+			{Key: "default_room", Flag: "default-room", Label: "Sala padrão (!id:servidor ou #alias:servidor)", Validate: validateMatrixRoom},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("html"),
+			{Name: "notice", Usage: "Envia como m.notice, o tipo usado por bots (Matrix)", Bool: true},
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Matrix.HomeserverURL != "" && conf.Matrix.AccessToken != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewMatrixProvider(&conf.Matrix), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testMatrixConnection(&conf.Matrix, target)
+		},
+	})
+}
+
+// matrixMaxAttempts é o número máximo de tentativas por evento (rate limit e erros 5xx).
+const matrixMaxAttempts = 3
+
+// validateMatrixRoom valida o formato de room IDs e aliases.
+func validateMatrixRoom(value string) error {
+	if value == "" {
+		return nil
+	}
+	if (strings.HasPrefix(value, "!") || strings.HasPrefix(value, "#")) && strings.Contains(value, ":") {
+		return nil
+	}
+	return fmt.Errorf("sala inválida: '%s' (use !id:servidor ou #alias:servidor)", value)
+}
+
+// matrixTxnCounter garante transaction IDs únicos dentro do mesmo processo.
+var matrixTxnCounter uint64
+
+// newMatrixTxnID gera um transaction ID. O mesmo ID é reutilizado nas novas tentativas
+// de um evento, para que o homeserver descarte duplicatas.
+func newMatrixTxnID() string {
+	n := atomic.AddUint64(&matrixTxnCounter, 1)
+	return fmt.Sprintf("cast-%d-%d", time.Now().UnixNano(), n)
+}
+
+// matrixError é o formato de erro da API ({"errcode":"M_FORBIDDEN","error":"..."}).
+type matrixError struct {
+	ErrCode      string `json:"errcode"`
+	Error        string `json:"error"`
+	RetryAfterMs int    `json:"retry_after_ms"`
+}
+
+// matrixProvider implementa o Provider para Matrix (Client-Server API).
+type matrixProvider struct {
+	config *config.MatrixConfig
+	client *http.Client
+	rooms  map[string]string // Cache de alias -> room ID
+	sleep  func(time.Duration)
+}
+
+// NewMatrixProvider cria uma nova instância do MatrixProvider.
+func NewMatrixProvider(cfg *config.MatrixConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &matrixProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+		rooms:  map[string]string{},
+		sleep:  time.Sleep,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *matrixProvider) Name() string {
+	return "matrix"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *matrixProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem de texto via Matrix.
+func (p *matrixProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem m.room.message para uma ou mais salas.
+// O assunto (--subject) vira um título em negrito; com --html a mensagem é enviada
+// como formatted_body. Anexos são enviados ao media repository e publicados como m.file/m.image.
+// Lógica de Target:
+// - Room ID (!abc:servidor): usa diretamente
+// - Alias (#sala:servidor): resolvido para room ID via directory
+// - "default" ou vazio: usa default_room configurado
+// - Suporta múltiplas salas separadas por vírgula ou ponto-e-vírgula
+func (p *matrixProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	content := buildMatrixContent(message, opts)
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		roomID, err := p.resolveRoom(t)
+		if err != nil {
+			return err
+		}
+
+		if message != "" || opts.Subject != "" {
+			if err := p.sendEvent(roomID, content); err != nil {
+				return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", t, i+1, len(targets), err)
+			}
+		}
+
+		for _, path := range opts.Attachments {
+			if err := p.sendAttachment(roomID, path); err != nil {
+				return fmt.Errorf("erro ao enviar anexo %s para %s: %w", filepath.Base(path), t, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// htmlTagPattern remove tags HTML ao gerar o texto plano.
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// htmlBreakPattern reconhece quebras de linha e fim de parágrafo em HTML.
+var htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</h[1-6]>`)
+
+// htmlHiddenPattern remove blocos que não são exibidos (cabeçalho, estilos e scripts).
+var htmlHiddenPattern = regexp.MustCompile(`(?is)<head[\s>].*?</head>|<style[\s>].*?</style>|<script[\s>].*?</script>`)
+
+// htmlBlankLinesPattern reduz sequências de linhas em branco a uma só.
+var htmlBlankLinesPattern = regexp.MustCompile(`\n[ \t]*(\n[ \t]*)+\n`)
+
+// htmlToPlain converte HTML em texto plano (fallback para clientes sem HTML).
+func htmlToPlain(s string) string {
+	s = htmlHiddenPattern.ReplaceAllString(s, "")
+	s = htmlBreakPattern.ReplaceAllString(s, "\n")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = htmlBlankLinesPattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(html.UnescapeString(s))
+}
+
+// buildMatrixContent monta o conteúdo do evento m.room.message.
+func buildMatrixContent(message string, opts SendOptions) map[string]interface{} {
+	msgtype := "m.text"
+	if opts.Get("notice") == "true" {
+		msgtype = "m.notice"
+	}
+
+	isHTML := opts.Get("html") == "true"
+	if !isHTML && opts.Subject == "" {
+		return map[string]interface{}{"msgtype": msgtype, "body": message}
+	}
+
+	formatted := message
+	plain := message
+	if isHTML {
+		plain = htmlToPlain(message)
+	} else {
+		formatted = strings.ReplaceAll(html.EscapeString(message), "\n", "<br>")
+	}
+	if opts.Subject != "" {
+		formatted = "<strong>" + html.EscapeString(opts.Subject) + "</strong><br>" + formatted
+		plain = opts.Subject + "\n\n" + plain
+	}
+
+	return map[string]interface{}{
+		"msgtype":        msgtype,
+		"body":           plain,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formatted,
+	}
+}
+
+// resolveRoom converte o target em room ID, resolvendo aliases via directory.
+func (p *matrixProvider) resolveRoom(target string) (string, error) {
+	room := target
+	if target == "" || target == "default" || target == "me" {
+		if p.config.DefaultRoom == "" {
+			return "", fmt.Errorf("target '%s' requer default_room configurado", target)
+		}
+		room = p.config.DefaultRoom
+	}
+
+	if err := validateMatrixRoom(room); err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(room, "!") {
+		return room, nil
+	}
+	if roomID, ok := p.rooms[room]; ok {
+		return roomID, nil
+	}
+
+	var resp struct {
+		RoomID string `json:"room_id"`
+	}
+	path := "/_matrix/client/v3/directory/room/" + url.PathEscape(room)
+	if err := p.doJSON("GET", path, nil, &resp); err != nil {
+		return "", fmt.Errorf("erro ao resolver alias %s: %w", room, err)
+	}
+	if resp.RoomID == "" {
+		return "", fmt.Errorf("alias %s não encontrado", room)
+	}
+
+	p.rooms[room] = resp.RoomID
+	return resp.RoomID, nil
+}
+
+// sendEvent envia um evento m.room.message com transaction ID (idempotente entre tentativas).
+func (p *matrixProvider) sendEvent(roomID string, content map[string]interface{}) error {
+	path := fmt.Sprintf("/_matrix/client/v3/rooms/%s/send/m.room.message/%s", url.PathEscape(roomID), newMatrixTxnID())
+	var resp struct {
+		EventID string `json:"event_id"`
+	}
+	return p.doJSON("PUT", path, content, &resp)
+}
+
+// sendAttachment envia o arquivo ao media repository e publica o evento correspondente.
+func (p *matrixProvider) sendAttachment(roomID, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	filename := filepath.Base(path)
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	mimetype, _, _ := mime.ParseMediaType(contentType)
+
+	contentURI, err := p.upload(filename, contentType, data)
+	if err != nil {
+		return err
+	}
+
+	msgtype := "m.file"
+	switch {
+	case strings.HasPrefix(mimetype, "image/"):
+		msgtype = "m.image"
+	case strings.HasPrefix(mimetype, "video/"):
+		msgtype = "m.video"
+	case strings.HasPrefix(mimetype, "audio/"):
+		msgtype = "m.audio"
+	}
+
+	return p.sendEvent(roomID, map[string]interface{}{
+		"msgtype":  msgtype,
+		"body":     filename,
+		"filename": filename,
+		"url":      contentURI,
+		"info": map[string]interface{}{
+			"mimetype": mimetype,
+			"size":     len(data),
+		},
+	})
+}
+
+// upload envia o arquivo para /_matrix/media/v3/upload e retorna a content URI (mxc://).
+func (p *matrixProvider) upload(filename, contentType string, data []byte) (string, error) {
+	endpoint := "/_matrix/media/v3/upload?filename=" + url.QueryEscape(filename)
+	body, err := p.do("POST", endpoint, contentType, data)
+	if err != nil {
+		return "", fmt.Errorf("erro no upload: %w", err)
+	}
+
+	var resp struct {
+		ContentURI string `json:"content_uri"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.ContentURI == "" {
+		return "", fmt.Errorf("resposta de upload inválida: %s", strings.TrimSpace(string(body)))
+	}
+	return resp.ContentURI, nil
+}
+
+// doJSON executa uma chamada JSON autenticada e decodifica a resposta em out.
+func (p *matrixProvider) doJSON(method, path string, payload interface{}, out interface{}) error {
+	var data []byte
+	if payload != nil {
+		var err error
+		data, err = json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar payload: %w", err)
+		}
+	}
+
+	body, err := p.do(method, path, "application/json", data)
+	if err != nil {
+		return err
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("resposta inválida: %w", err)
+		}
+	}
+	return nil
+}
+
+// do executa a requisição autenticada, repetindo em rate limit (M_LIMIT_EXCEEDED) e erros 5xx.
+func (p *matrixProvider) do(method, path, contentType string, data []byte) ([]byte, error) {
+	requestURL := strings.TrimSuffix(p.config.HomeserverURL, "/") + path
+
+	var lastErr error
+	for attempt := 1; attempt <= matrixMaxAttempts; attempt++ {
+		var reqBody io.Reader
+		if data != nil {
+			reqBody = bytes.NewReader(data)
+		}
+		req, err := http.NewRequest(method, requestURL, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+p.config.AccessToken)
+		if data != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("erro ao enviar requisição: %w", err)
+			if errors.Is(err, errPreview) {
+				return nil, lastErr
+			}
+			if attempt < matrixMaxAttempts {
+				p.sleep(time.Duration(attempt) * time.Second)
+			}
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return body, nil
+		}
+
+		var apiErr matrixError
+		json.Unmarshal(body, &apiErr)
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			wait := time.Duration(attempt) * time.Second
+			if apiErr.RetryAfterMs > 0 {
+				wait = time.Duration(apiErr.RetryAfterMs) * time.Millisecond
+			} else if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+				wait = time.Duration(secs) * time.Second
+			}
+			lastErr = formatMatrixError(resp.StatusCode, apiErr, body)
+			if attempt < matrixMaxAttempts {
+				p.sleep(wait)
+			}
+			continue
+		}
+
+		return nil, formatMatrixError(resp.StatusCode, apiErr, body)
+	}
+
+	return nil, fmt.Errorf("%w (após %d tentativas)", lastErr, matrixMaxAttempts)
+}
+
+// formatMatrixError converte o erro da API em mensagem amigável.
+func formatMatrixError(status int, apiErr matrixError, body []byte) error {
+	switch apiErr.ErrCode {
+	case "M_UNKNOWN_TOKEN", "M_MISSING_TOKEN":
+		return fmt.Errorf("access token inválido: %s", apiErr.Error)
+	case "M_FORBIDDEN":
+		return fmt.Errorf("acesso negado (o usuário está na sala?): %s", apiErr.Error)
+	case "M_NOT_FOUND":
+		return fmt.Errorf("não encontrado: %s", apiErr.Error)
+	case "M_LIMIT_EXCEEDED":
+		return fmt.Errorf("rate limit excedido: %s", apiErr.Error)
+	case "M_TOO_LARGE":
+		return fmt.Errorf("arquivo maior que o limite do homeserver: %s", apiErr.Error)
+	case "":
+		return fmt.Errorf("Matrix retornou status %d: %s", status, strings.TrimSpace(string(body)))
+	}
+	return fmt.Errorf("Matrix retornou %s (status %d): %s", apiErr.ErrCode, status, apiErr.Error)
+}
+
+// testMatrixConnection valida o access token via whoami. Se target for informado,
+// resolve a sala e envia uma mensagem de teste.
+func testMatrixConnection(cfg *config.MatrixConfig, target string) error {
+	p := NewMatrixProvider(cfg).(*matrixProvider)
+
+	var whoami struct {
+		UserID string `json:"user_id"`
+	}
+	if err := p.doJSON("GET", "/_matrix/client/v3/account/whoami", nil, &whoami); err != nil {
+		return err
+	}
+	if whoami.UserID == "" {
+		return fmt.Errorf("resposta de whoami sem user_id")
+	}
+
+	if target == "" {
+		return nil
+	}
+	opts := SendOptions{Subject: "CAST - Teste de conectividade", Values: map[string][]string{"notice": {"true"}}}
+	return p.SendWithOptions(target, "Mensagem de teste enviada por 'cast gateway test matrix'.", opts)
+}
@@ -0,0 +1,251 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// newTestMatrixProvider cria um provider apontando para o servidor de teste,
+// registrando as esperas entre tentativas em vez de dormir.
+func newTestMatrixProvider(serverURL string, sleeps *[]time.Duration) *matrixProvider {
+	cfg := &config.MatrixConfig{HomeserverURL: serverURL, AccessToken: "syt_token", DefaultRoom: "#ops:exemplo.org", Timeout: 30}
+	p := NewMatrixProvider(cfg).(*matrixProvider)
+	p.sleep = func(d time.Duration) {
+		*sleeps = append(*sleeps, d)
+	}
+	return p
+}
+
+func TestMatrixProvider_Name(t *testing.T) {
+	provider := NewMatrixProvider(&config.MatrixConfig{})
+	if provider.Name() != "matrix" {
+		t.Errorf("Esperado 'matrix', obtido '%s'", provider.Name())
+	}
+}
+
+func TestMatrixProvider_Send_AliasAndHTML(t *testing.T) {
+	directoryCalls := 0
+	var events []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer syt_token" {
+			t.Errorf("Esperado Authorization Bearer, obtido '%s'", r.Header.Get("Authorization"))
+		}
+
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/directory/room/"):
+			directoryCalls++
+			if r.URL.Path != "/_matrix/client/v3/directory/room/#ops:exemplo.org" {
+				t.Errorf("Alias inesperado: %s", r.URL.Path)
+			}
+			w.Write([]byte(`{"room_id":"!abc123:exemplo.org","servers":["exemplo.org"]}`))
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!abc123:exemplo.org/send/m.room.message/"):
+			var content map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&content); err != nil {
+				t.Fatalf("Erro ao decodificar evento: %v", err)
+			}
+			events = append(events, content)
+			w.Write([]byte(`{"event_id":"$evento"}`))
+		default:
+			t.Errorf("Requisição inesperada: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestMatrixProvider(server.URL, &sleeps)
+	opts := SendOptions{
+		Subject: "Deploy",
+		Values:  map[string][]string{"html": {"true"}},
+	}
+	// Dois envios para a mesma sala: o alias deve ser resolvido uma única vez
+	if err := provider.SendWithOptions("default;#ops:exemplo.org", "<p>Versão <b>1.2.3</b> &amp; migrações</p>", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+
+	if directoryCalls != 1 {
+		t.Errorf("Esperado 1 consulta ao directory (cache), obtido %d", directoryCalls)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Esperado 2 eventos, obtido %d", len(events))
+	}
+	event := events[0]
+	if event["msgtype"] != "m.text" || event["format"] != "org.matrix.custom.html" {
+		t.Errorf("Evento inesperado: %v", event)
+	}
+	if event["formatted_body"] != "<strong>Deploy</strong><br><p>Versão <b>1.2.3</b> &amp; migrações</p>" {
+		t.Errorf("formatted_body inesperado: %v", event["formatted_body"])
+	}
+	if event["body"] != "Deploy\n\nVersão 1.2.3 & migrações" {
+		t.Errorf("body (texto plano) inesperado: %q", event["body"])
+	}
+}
+
+func TestMatrixProvider_Send_PlainNotice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&content)
+		if content["msgtype"] != "m.notice" || content["body"] != "Backup <ok>" {
+			t.Errorf("Evento inesperado: %v", content)
+		}
+		if _, ok := content["formatted_body"]; ok {
+			t.Error("Mensagem simples não deveria conter formatted_body")
+		}
+		w.Write([]byte(`{"event_id":"$evento"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestMatrixProvider(server.URL, &sleeps)
+	opts := SendOptions{Values: map[string][]string{"notice": {"true"}}}
+	if err := provider.SendWithOptions("!sala:exemplo.org", "Backup <ok>", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestMatrixProvider_RateLimit_SameTxnID(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if len(paths) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errcode":"M_LIMIT_EXCEEDED","error":"Too Many Requests","retry_after_ms":1500}`))
+			return
+		}
+		w.Write([]byte(`{"event_id":"$evento"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestMatrixProvider(server.URL, &sleeps)
+	if err := provider.Send("!sala:exemplo.org", "Teste"); err != nil {
+		t.Fatalf("Erro após retry: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Fatalf("Esperado 2 tentativas, obtido %d", len(paths))
+	}
+	if paths[0] != paths[1] {
+		t.Errorf("A nova tentativa deveria reutilizar o transaction ID: %s != %s", paths[0], paths[1])
+	}
+	if len(sleeps) != 1 || sleeps[0] != 1500*time.Millisecond {
+		t.Errorf("Esperado espera de 1.5s (retry_after_ms), obtido %v", sleeps)
+	}
+
+	// Envios diferentes usam transaction IDs diferentes
+	provider.Send("!sala:exemplo.org", "Outro")
+	if paths[2] == paths[0] {
+		t.Error("Eventos diferentes não deveriam compartilhar transaction ID")
+	}
+}
+
+func TestMatrixProvider_Send_Attachment(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "grafico.png")
+	png := []byte("\x89PNG\r\n\x1a\nfake")
+	if err := os.WriteFile(filePath, png, 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	var event map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_matrix/media/v3/upload":
+			if r.URL.Query().Get("filename") != "grafico.png" {
+				t.Errorf("Esperado filename 'grafico.png', obtido '%s'", r.URL.Query().Get("filename"))
+			}
+			if r.Header.Get("Content-Type") != "image/png" {
+				t.Errorf("Esperado Content-Type image/png, obtido '%s'", r.Header.Get("Content-Type"))
+			}
+			data, _ := io.ReadAll(r.Body)
+			if string(data) != string(png) {
+				t.Error("Conteúdo do upload diferente do arquivo")
+			}
+			w.Write([]byte(`{"content_uri":"mxc://exemplo.org/AbCdEf"}`))
+		case strings.Contains(r.URL.Path, "/send/m.room.message/"):
+			json.NewDecoder(r.Body).Decode(&event)
+			w.Write([]byte(`{"event_id":"$evento"}`))
+		}
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestMatrixProvider(server.URL, &sleeps)
+	if err := provider.SendWithOptions("!sala:exemplo.org", "", SendOptions{Attachments: []string{filePath}}); err != nil {
+		t.Fatalf("Erro ao enviar anexo: %v", err)
+	}
+
+	if event["msgtype"] != "m.image" || event["url"] != "mxc://exemplo.org/AbCdEf" || event["body"] != "grafico.png" {
+		t.Errorf("Evento de anexo inesperado: %v", event)
+	}
+	info := event["info"].(map[string]interface{})
+	if info["mimetype"] != "image/png" || info["size"] != float64(len(png)) {
+		t.Errorf("Info inesperado: %v", info)
+	}
+}
+
+func TestMatrixProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/directory/room/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errcode":"M_NOT_FOUND","error":"Room alias #x:exemplo.org not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errcode":"M_FORBIDDEN","error":"User not in room"}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	provider := newTestMatrixProvider(server.URL, &sleeps)
+
+	tests := []struct {
+		target   string
+		contains string
+	}{
+		{"#x:exemplo.org", "erro ao resolver alias"},
+		{"!sala:exemplo.org", "acesso negado"},
+		{"sala-sem-servidor", "sala inválida"},
+	}
+	for _, tt := range tests {
+		err := provider.Send(tt.target, "Teste")
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("Target %s: esperado erro contendo '%s', obtido: %v", tt.target, tt.contains, err)
+		}
+	}
+	if len(sleeps) != 0 {
+		t.Errorf("Erros 4xx não deveriam gerar novas tentativas, obtido %v", sleeps)
+	}
+}
+
+func TestMatrixConnection_Whoami(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_matrix/client/v3/account/whoami" {
+			t.Errorf("Teste sem target não deveria enviar mensagens (path %s)", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer syt_valido" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN","error":"Invalid access token passed."}`))
+			return
+		}
+		w.Write([]byte(`{"user_id":"@cast:exemplo.org"}`))
+	}))
+	defer server.Close()
+
+	if err := testMatrixConnection(&config.MatrixConfig{HomeserverURL: server.URL, AccessToken: "syt_valido"}, ""); err != nil {
+		t.Errorf("Token válido não deveria retornar erro: %v", err)
+	}
+	err := testMatrixConnection(&config.MatrixConfig{HomeserverURL: server.URL, AccessToken: "syt_invalido"}, "")
+	if err == nil || !strings.Contains(err.Error(), "access token inválido") {
+		t.Errorf("Esperado erro de token inválido, obtido: %v", err)
+	}
+}
@@ -0,0 +1,473 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "mattermost",
+		Aliases:     []string{"mm"},
+		DisplayName: "Mattermost",
+		Order:       130,
+		TargetHint:  "ID do canal, equipe/canal, webhook_url ou 'default'",
+		Fields: []ConfigField{
+			{Key: "webhook_url", Flag: "webhook-url", Label: "Webhook URL", Secret: true, Validate: ValidateHTTPURL},
+			{Key: "server_url", Flag: "server-url", Label: "URL do servidor", Validate: ValidateHTTPURL},
+			{Key: "access_token", Flag: "access-token", Label: "Access Token (bot ou pessoal)", Secret: true},
+			{Key: "default_channel", Flag: "default-channel", Label: "Default Channel (ID ou equipe/canal)"},
+			{Key: "username", Flag: "username", Label: "Username"},
+			{Key: "icon_url", Flag: "icon-url", Label: "Icon URL", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("username"),
+			sharedSendFlag("avatar"),
+			sharedSendFlag("attachments-json"),
+			{Name: "props", Usage: "Props do post em JSON ou @arquivo.json (Mattermost)"},
+		},
+		Validate: func(conf *config.Config) error {
+			mm := conf.Mattermost
+			if mm.WebhookURL == "" && (mm.ServerURL == "" || mm.AccessToken == "") {
+				return fmt.Errorf("configuração do Mattermost incompleta: webhook_url ou server_url + access_token é obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Mattermost.WebhookURL != "" || (conf.Mattermost.ServerURL != "" && conf.Mattermost.AccessToken != "")
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewMattermostProvider(&conf.Mattermost), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testMattermostConnection(&conf.Mattermost, target)
+		},
+	})
+}
+
+// mattermostIDPattern reconhece IDs do Mattermost (26 caracteres minúsculos/dígitos).
+var mattermostIDPattern = regexp.MustCompile(`^[a-z0-9]{26}$`)
+
+// mattermostProvider implementa o Provider para Mattermost (Incoming Webhooks e REST API v4).
+type mattermostProvider struct {
+	config   *config.MattermostConfig
+	client   *http.Client
+	channels map[string]string // cache equipe/canal -> ID
+}
+
+// NewMattermostProvider cria uma nova instância do MattermostProvider.
+func NewMattermostProvider(cfg *config.MattermostConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &mattermostProvider{
+		config:   cfg,
+		client:   &http.Client{Timeout: timeout},
+		channels: make(map[string]string),
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *mattermostProvider) Name() string {
+	return "mattermost"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *mattermostProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem de texto via Mattermost.
+func (p *mattermostProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem via Mattermost com attachments, props e arquivos.
+// Lógica de Target:
+// - URL completa (https://...): envia via Incoming Webhook
+// - "default", "me" ou vazio: usa default_channel (access_token) ou webhook_url
+// - Qualquer outro valor: ID do canal ou "equipe/canal" via REST (requer access_token)
+// - Sem access_token, o target é repassado como override de canal do webhook_url
+// - Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula
+func (p *mattermostProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	attachments, err := parseMessageAttachments(opts.Get("attachments-json"))
+	if err != nil {
+		return err
+	}
+	props, err := parseJSONObject("props", opts.Get("props"))
+	if err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		if err := p.sendOne(t, message, attachments, props, opts); err != nil {
+			return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", t, i+1, len(targets), err)
+		}
+	}
+	return nil
+}
+
+// sendOne decide entre webhook e REST para um único target.
+func (p *mattermostProvider) sendOne(target string, message string, attachments json.RawMessage, props map[string]interface{}, opts SendOptions) error {
+	hasToken := p.config.ServerURL != "" && p.config.AccessToken != ""
+
+	webhookURL, channel := "", ""
+	switch {
+	case strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://"):
+		webhookURL = target
+	case target == "default" || target == "me" || target == "":
+		if hasToken && p.config.DefaultChannel != "" {
+			channel = p.config.DefaultChannel
+		} else if p.config.WebhookURL != "" {
+			webhookURL = p.config.WebhookURL
+		} else {
+			return fmt.Errorf("target '%s' requer webhook_url ou default_channel configurado", target)
+		}
+	case hasToken:
+		channel = target
+	case p.config.WebhookURL != "":
+		// Override de canal do webhook (nome do canal ou @usuario)
+		return p.sendToWebhook(p.config.WebhookURL, strings.TrimPrefix(target, "#"), message, attachments, props, opts)
+	default:
+		return fmt.Errorf("envio para o canal '%s' requer server_url e access_token configurados", target)
+	}
+
+	if webhookURL != "" {
+		return p.sendToWebhook(webhookURL, "", message, attachments, props, opts)
+	}
+	return p.createPost(channel, message, attachments, props, opts)
+}
+
+// overrides retorna username e ícone (flags --username/--avatar ou configuração).
+func (p *mattermostProvider) overrides(opts SendOptions) (username string, iconURL string) {
+	username, iconURL = p.config.Username, p.config.IconURL
+	if v := opts.Get("username"); v != "" {
+		username = v
+	}
+	if v := opts.Get("avatar"); v != "" {
+		iconURL = v
+	}
+	return username, iconURL
+}
+
+// sendToWebhook envia mensagem para um Incoming Webhook.
+func (p *mattermostProvider) sendToWebhook(webhookURL string, channel string, message string, attachments json.RawMessage, props map[string]interface{}, opts SendOptions) error {
+	if len(opts.Attachments) > 0 {
+		return fmt.Errorf("upload de arquivos requer server_url e access_token (Incoming Webhooks não suportam arquivos)")
+	}
+
+	payload := map[string]interface{}{"text": message}
+	if channel != "" {
+		payload["channel"] = channel
+	}
+	username, iconURL := p.overrides(opts)
+	if username != "" {
+		payload["username"] = username
+	}
+	if iconURL != "" {
+		payload["icon_url"] = iconURL
+	}
+	if attachments != nil {
+		payload["attachments"] = attachments
+	}
+	if len(props) > 0 {
+		payload["props"] = props
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return mattermostError(resp.StatusCode, body)
+	}
+	return nil
+}
+
+// createPost cria o post via POST /api/v4/posts, enviando antes os arquivos.
+// Username e ícone vão em props (override_username/override_icon_url), o que exige
+// "Enable integrations to override usernames/profile picture icons" no servidor.
+func (p *mattermostProvider) createPost(channel string, message string, attachments json.RawMessage, props map[string]interface{}, opts SendOptions) error {
+	channelID, err := p.resolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	var fileIDs []string
+	for _, file := range opts.Attachments {
+		id, err := p.uploadFile(channelID, file)
+		if err != nil {
+			return fmt.Errorf("erro ao enviar arquivo %s: %w", filepath.Base(file), err)
+		}
+		fileIDs = append(fileIDs, id)
+	}
+
+	postProps := map[string]interface{}{}
+	for k, v := range props {
+		postProps[k] = v
+	}
+	username, iconURL := p.overrides(opts)
+	if username != "" {
+		postProps["override_username"] = username
+		postProps["from_webhook"] = "true"
+	}
+	if iconURL != "" {
+		postProps["override_icon_url"] = iconURL
+		postProps["from_webhook"] = "true"
+	}
+	if attachments != nil {
+		postProps["attachments"] = attachments
+	}
+
+	post := map[string]interface{}{
+		"channel_id": channelID,
+		"message":    message,
+	}
+	if len(postProps) > 0 {
+		post["props"] = postProps
+	}
+	if len(fileIDs) > 0 {
+		post["file_ids"] = fileIDs
+	}
+
+	jsonData, err := json.Marshal(post)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+	req, err := http.NewRequest("POST", p.apiURL("/posts"), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return p.doAPI(req, nil)
+}
+
+// resolveChannel converte "equipe/canal" no ID do canal (com cache). IDs são usados diretamente.
+func (p *mattermostProvider) resolveChannel(channel string) (string, error) {
+	channel = strings.TrimPrefix(channel, "#")
+	if mattermostIDPattern.MatchString(channel) {
+		return channel, nil
+	}
+	if id, ok := p.channels[channel]; ok {
+		return id, nil
+	}
+
+	team, name, ok := strings.Cut(channel, "/")
+	if !ok || team == "" || name == "" {
+		return "", fmt.Errorf("canal inválido: '%s' (use o ID do canal ou equipe/canal)", channel)
+	}
+
+	req, err := http.NewRequest("GET", p.apiURL("/teams/name/"+url.PathEscape(team)+"/channels/name/"+url.PathEscape(name)), nil)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := p.doAPI(req, &result); err != nil {
+		return "", fmt.Errorf("erro ao resolver canal '%s': %w", channel, err)
+	}
+
+	p.channels[channel] = result.ID
+	return result.ID, nil
+}
+
+// uploadFile envia um arquivo via POST /api/v4/files e retorna o ID do arquivo.
+func (p *mattermostProvider) uploadFile(channelID string, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.WriteField("channel_id", channelID); err != nil {
+		return "", fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+	part, err := writer.CreateFormFile("files", filepath.Base(path))
+	if err != nil {
+		return "", fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+	part.Write(data)
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", p.apiURL("/files"), &buf)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar requisição de upload: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var result struct {
+		FileInfos []struct {
+			ID string `json:"id"`
+		} `json:"file_infos"`
+	}
+	if err := p.doAPI(req, &result); err != nil {
+		return "", err
+	}
+	if len(result.FileInfos) == 0 {
+		return "", fmt.Errorf("resposta do upload sem file_infos")
+	}
+	return result.FileInfos[0].ID, nil
+}
+
+// doAPI executa a requisição autenticada e decodifica a resposta em out (se informado).
+func (p *mattermostProvider) doAPI(req *http.Request, out interface{}) error {
+	req.Header.Set("Authorization", "Bearer "+p.config.AccessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return mattermostError(resp.StatusCode, body)
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("erro ao decodificar resposta do Mattermost: %w", err)
+		}
+	}
+	return nil
+}
+
+// apiURL retorna a URL de um endpoint da API v4.
+func (p *mattermostProvider) apiURL(path string) string {
+	return strings.TrimRight(p.config.ServerURL, "/") + "/api/v4" + path
+}
+
+// mattermostError converte erros da API ({"id":"...","message":"...","status_code":401}) em mensagens amigáveis.
+func mattermostError(statusCode int, body []byte) error {
+	var apiErr struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		message = apiErr.Message
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("access token inválido ou expirado: %s", message)
+	case http.StatusForbidden:
+		return fmt.Errorf("permissão negada (o bot é membro do canal?): %s", message)
+	case http.StatusNotFound:
+		return fmt.Errorf("canal ou recurso não encontrado: %s", message)
+	}
+	return fmt.Errorf("Mattermost retornou status %d: %s", statusCode, message)
+}
+
+// parseMessageAttachments interpreta --attachments-json (JSON inline ou @arquivo), no formato
+// de message attachments do Slack usado por Mattermost e Rocket.Chat.
+// Aceita um array ou o objeto {"attachments": [...]}.
+func parseMessageAttachments(value string) (json.RawMessage, error) {
+	value, err := readValueOrFile(value)
+	if err != nil || value == "" {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var wrapper struct {
+			Attachments json.RawMessage `json:"attachments"`
+		}
+		if err := json.Unmarshal([]byte(value), &wrapper); err != nil {
+			return nil, fmt.Errorf("attachments inválidos: %w", err)
+		}
+		if wrapper.Attachments == nil {
+			return nil, fmt.Errorf("attachments inválidos: objeto sem a chave \"attachments\"")
+		}
+		value = string(wrapper.Attachments)
+	}
+
+	var attachments []map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &attachments); err != nil {
+		return nil, fmt.Errorf("attachments inválidos (esperado array JSON): %w", err)
+	}
+	return json.RawMessage(value), nil
+}
+
+// parseJSONObject interpreta uma flag com objeto JSON inline ou @arquivo.
+func parseJSONObject(flag string, value string) (map[string]interface{}, error) {
+	value, err := readValueOrFile(value)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+		return nil, fmt.Errorf("--%s inválido (esperado objeto JSON): %w", flag, err)
+	}
+	return obj, nil
+}
+
+// testMattermostConnection valida o access_token via GET /api/v4/users/me. Webhooks não
+// possuem endpoint de verificação, então só a URL é validada; a mensagem de teste é
+// enviada apenas se target for informado.
+func testMattermostConnection(cfg *config.MattermostConfig, target string) error {
+	p := NewMattermostProvider(cfg).(*mattermostProvider)
+
+	if cfg.ServerURL != "" && cfg.AccessToken != "" {
+		req, err := http.NewRequest("GET", p.apiURL("/users/me"), nil)
+		if err != nil {
+			return fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		var me struct {
+			Username string `json:"username"`
+		}
+		if err := p.doAPI(req, &me); err != nil {
+			return err
+		}
+	}
+
+	if cfg.WebhookURL != "" {
+		if err := ValidateHTTPURL(cfg.WebhookURL); err != nil {
+			return fmt.Errorf("webhook_url inválida: %w", err)
+		}
+	}
+
+	if target == "" {
+		return nil
+	}
+	return p.Send(target, "Mensagem de teste enviada por 'cast gateway test mattermost'.")
+}
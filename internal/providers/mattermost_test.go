@@ -0,0 +1,226 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestMattermostProvider_Name(t *testing.T) {
+	provider := NewMattermostProvider(&config.MattermostConfig{})
+	if provider.Name() != "mattermost" {
+		t.Errorf("Esperado 'mattermost', obtido '%s'", provider.Name())
+	}
+}
+
+func TestMattermostProvider_Send_Webhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload["text"] != "Deploy concluído" || payload["channel"] != "town-square" {
+			t.Errorf("Payload inesperado: %v", payload)
+		}
+		if payload["username"] != "deploy-bot" || payload["icon_url"] != "https://exemplo.com/bot.png" {
+			t.Errorf("Overrides inesperados: %v", payload)
+		}
+		attachments := payload["attachments"].([]interface{})
+		if len(attachments) != 1 || attachments[0].(map[string]interface{})["color"] != "#36a64f" {
+			t.Errorf("Attachments inesperados: %v", payload["attachments"])
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// Sem access_token, o target vira override de canal do webhook
+	provider := NewMattermostProvider(&config.MattermostConfig{WebhookURL: server.URL + "/hooks/xyz", Username: "cast"}).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{
+		"username":         {"deploy-bot"},
+		"avatar":           {"https://exemplo.com/bot.png"},
+		"attachments-json": {`{"attachments":[{"color":"#36a64f","text":"v1.2.3"}]}`},
+	}}
+	if err := provider.SendWithOptions("#town-square", "Deploy concluído", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestMattermostProvider_Send_RESTWithFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "relatorio.csv")
+	if err := os.WriteFile(filePath, []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	lookups := 0
+	var post map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mm_token" {
+			t.Errorf("Esperado Authorization Bearer, obtido '%s'", r.Header.Get("Authorization"))
+		}
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v4/teams/name/ops/channels/name/alertas":
+			lookups++
+			w.Write([]byte(`{"id":"abcdefghijklmnopqrstuvwxyz","name":"alertas"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v4/files":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("Erro ao ler multipart: %v", err)
+			}
+			if r.FormValue("channel_id") != "abcdefghijklmnopqrstuvwxyz" {
+				t.Errorf("channel_id inesperado: %s", r.FormValue("channel_id"))
+			}
+			file, header, err := r.FormFile("files")
+			if err != nil {
+				t.Fatalf("Campo files ausente: %v", err)
+			}
+			data, _ := io.ReadAll(file)
+			if header.Filename != "relatorio.csv" || string(data) != "a,b\n1,2\n" {
+				t.Errorf("Arquivo inesperado: %s %q", header.Filename, string(data))
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"file_infos":[{"id":"arquivo123"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v4/posts":
+			json.NewDecoder(r.Body).Decode(&post)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"post123"}`))
+		default:
+			t.Errorf("Requisição inesperada: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewMattermostProvider(&config.MattermostConfig{
+		ServerURL:      server.URL + "/",
+		AccessToken:    "mm_token",
+		DefaultChannel: "ops/alertas",
+		Username:       "cast",
+	}).(OptionsProvider)
+	opts := SendOptions{
+		Attachments: []string{filePath},
+		Values:      map[string][]string{"props": {`{"card":"Detalhes"}`}},
+	}
+	if err := provider.SendWithOptions("default", "Relatório diário", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+	// Segundo envio usa o ID do canal em cache
+	if err := provider.Send("ops/alertas", "Outro"); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+
+	if lookups != 1 {
+		t.Errorf("Esperado 1 consulta de canal (cache), obtido %d", lookups)
+	}
+	if post["channel_id"] != "abcdefghijklmnopqrstuvwxyz" || post["message"] != "Outro" {
+		t.Errorf("Post inesperado: %v", post)
+	}
+	props := post["props"].(map[string]interface{})
+	if props["override_username"] != "cast" {
+		t.Errorf("Esperado override_username 'cast', obtido %v", props)
+	}
+}
+
+func TestMattermostProvider_Send_PostPayload(t *testing.T) {
+	var post map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/v4/files") {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"file_infos":[{"id":"arquivo123"}]}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&post)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "log.txt")
+	os.WriteFile(filePath, []byte("log"), 0644)
+
+	provider := NewMattermostProvider(&config.MattermostConfig{ServerURL: server.URL, AccessToken: "mm_token"}).(OptionsProvider)
+	opts := SendOptions{
+		Attachments: []string{filePath},
+		Values: map[string][]string{
+			"props":            {`{"card":"Detalhes"}`},
+			"attachments-json": {`[{"title":"Build #42"}]`},
+		},
+	}
+	if err := provider.SendWithOptions("abcdefghijklmnopqrstuvwxyz", "Build", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+
+	fileIDs := post["file_ids"].([]interface{})
+	if len(fileIDs) != 1 || fileIDs[0] != "arquivo123" {
+		t.Errorf("file_ids inesperados: %v", post["file_ids"])
+	}
+	props := post["props"].(map[string]interface{})
+	if props["card"] != "Detalhes" || props["attachments"] == nil {
+		t.Errorf("Props inesperadas: %v", props)
+	}
+	if _, ok := props["override_username"]; ok {
+		t.Error("Sem username configurado não deveria haver override_username")
+	}
+}
+
+func TestMattermostProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"id":"api.context.session_expired.app_error","message":"Invalid or expired session, please login again.","status_code":401}`))
+	}))
+	defer server.Close()
+
+	provider := NewMattermostProvider(&config.MattermostConfig{ServerURL: server.URL, AccessToken: "expirado"}).(OptionsProvider)
+
+	tests := []struct {
+		target   string
+		opts     SendOptions
+		contains string
+	}{
+		{"abcdefghijklmnopqrstuvwxyz", SendOptions{}, "access token inválido"},
+		{"alertas", SendOptions{}, "canal inválido"},
+		{"abcdefghijklmnopqrstuvwxyz", SendOptions{Values: map[string][]string{"props": {"[1]"}}}, "--props inválido"},
+		{"abcdefghijklmnopqrstuvwxyz", SendOptions{Values: map[string][]string{"attachments-json": {`{"text":"x"}`}}}, "attachments inválidos"},
+	}
+	for _, tt := range tests {
+		err := provider.SendWithOptions(tt.target, "Teste", tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("Target %s: esperado erro contendo '%s', obtido: %v", tt.target, tt.contains, err)
+		}
+	}
+
+	webhookOnly := NewMattermostProvider(&config.MattermostConfig{WebhookURL: server.URL}).(OptionsProvider)
+	err := webhookOnly.SendWithOptions("default", "Teste", SendOptions{Attachments: []string{"a.txt"}})
+	if err == nil || !strings.Contains(err.Error(), "Incoming Webhooks não suportam arquivos") {
+		t.Errorf("Esperado erro de arquivos via webhook, obtido: %v", err)
+	}
+}
+
+func TestMattermostConnection_UsersMe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/users/me" {
+			t.Errorf("Teste sem target não deveria publicar (path %s)", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer mm_valido" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"id":"api.context.session_expired.app_error","message":"Invalid or expired session, please login again."}`))
+			return
+		}
+		w.Write([]byte(`{"id":"abcdefghijklmnopqrstuvwxyz","username":"cast-bot"}`))
+	}))
+	defer server.Close()
+
+	if err := testMattermostConnection(&config.MattermostConfig{ServerURL: server.URL, AccessToken: "mm_valido"}, ""); err != nil {
+		t.Errorf("Token válido não deveria retornar erro: %v", err)
+	}
+	err := testMattermostConnection(&config.MattermostConfig{ServerURL: server.URL, AccessToken: "mm_invalido"}, "")
+	if err == nil || !strings.Contains(err.Error(), "access token inválido") {
+		t.Errorf("Esperado erro de token inválido, obtido: %v", err)
+	}
+}
@@ -0,0 +1,478 @@
+package providers
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "mqtt",
+		DisplayName: "MQTT",
+		Order:       190,
+		TargetHint:  "tópico (ex: devices/alerts) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "broker_url", Flag: "broker-url", Label: "URL do broker (mqtt://host:1883 ou mqtts://host:8883)", Required: true, Validate: validateMQTTBrokerURL},
+			{Key: "default_topic", Flag: "default-topic", Label: "Tópico padrão"},
+			{Key: "client_id", Flag: "client-id", Label: "Client ID (padrão: cast-<aleatório>)"},
+			{Key: "username", Flag: "username", Label: "Usuário"},
+			{Key: "password", Flag: "password", Label: "Senha", Secret: true},
+			{Key: "qos", Flag: "qos", Label: "QoS padrão (0, 1 ou 2)", Kind: FieldInt, Default: "0", Validate: validateMQTTQoS},
+			{Key: "retain", Flag: "retain", Label: "Publicar como mensagem retida", Kind: FieldBool},
+			{Key: "ca_file", Flag: "ca-file", Label: "Arquivo PEM com a CA do broker (mqtts://)"},
+			{Key: "tls_insecure", Flag: "tls-insecure", Label: "Não verificar o certificado do broker", Kind: FieldBool},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "qos", Usage: "QoS da publicação: 0, 1 ou 2 (MQTT)"},
+			{Name: "retain", Usage: "Publica como mensagem retida (MQTT)", Bool: true},
+			sharedSendFlag("meta"),
+			sharedSendFlag("raw"),
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.MQTT.BrokerURL != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewMQTTProviderWithVerbose(&conf.MQTT, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testMQTTConnection(&conf.MQTT, target)
+		},
+	})
+}
+
+// Tipos de pacote MQTT 3.1.1 (4 bits mais significativos do cabeçalho fixo).
+const (
+	mqttConnect    = 0x10
+	mqttConnack    = 0x20
+	mqttPublish    = 0x30
+	mqttPuback     = 0x40
+	mqttPubrec     = 0x50
+	mqttPubrel     = 0x62 // PUBREL exige os bits de flag 0010
+	mqttPubcomp    = 0x70
+	mqttDisconnect = 0xE0
+)
+
+// mqttKeepAlive é o keep alive informado no CONNECT (a conexão dura apenas o envio).
+const mqttKeepAlive = 30
+
+// mqttConnackErrors traduz os códigos de retorno do CONNACK.
+var mqttConnackErrors = map[byte]string{
+	1: "versão do protocolo não suportada pelo broker",
+	2: "client_id rejeitado pelo broker",
+	3: "broker indisponível",
+	4: "usuário ou senha inválidos",
+	5: "não autorizado",
+}
+
+// mqttBroker descreve o endereço do broker extraído de broker_url.
+type mqttBroker struct {
+	Addr string
+	Host string
+	TLS  bool
+	User *url.Userinfo
+}
+
+// parseMQTTBroker interpreta broker_url (mqtt://, tcp://, mqtts://, ssl:// ou tls://).
+func parseMQTTBroker(raw string) (mqttBroker, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return mqttBroker{}, fmt.Errorf("broker_url inválida: '%s' (ex: mqtt://broker:1883)", raw)
+	}
+
+	broker := mqttBroker{Host: u.Hostname(), User: u.User}
+	port := "1883"
+	switch strings.ToLower(u.Scheme) {
+	case "mqtt", "tcp":
+	case "mqtts", "ssl", "tls":
+		broker.TLS = true
+		port = "8883"
+	default:
+		return mqttBroker{}, fmt.Errorf("esquema '%s' não suportado em broker_url (use mqtt:// ou mqtts://)", u.Scheme)
+	}
+	if u.Port() != "" {
+		port = u.Port()
+	}
+	broker.Addr = net.JoinHostPort(broker.Host, port)
+	return broker, nil
+}
+
+// validateMQTTBrokerURL valida a broker_url configurada.
+func validateMQTTBrokerURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseMQTTBroker(value)
+	return err
+}
+
+// parseMQTTQoS converte o QoS informado (0, 1 ou 2).
+func parseMQTTQoS(value string) (byte, error) {
+	qos, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || qos < 0 || qos > 2 {
+		return 0, fmt.Errorf("QoS inválido: '%s' (use 0, 1 ou 2)", value)
+	}
+	return byte(qos), nil
+}
+
+// validateMQTTQoS valida o QoS padrão configurado.
+func validateMQTTQoS(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseMQTTQoS(value)
+	return err
+}
+
+// validateMQTTTopic valida um tópico de publicação (sem curingas + e #).
+func validateMQTTTopic(topic string) error {
+	switch {
+	case topic == "":
+		return fmt.Errorf("tópico vazio")
+	case strings.ContainsAny(topic, "+#"):
+		return fmt.Errorf("tópico '%s' inválido: curingas + e # só podem ser usados em assinaturas", topic)
+	case strings.ContainsRune(topic, 0) || len(topic) > 65535:
+		return fmt.Errorf("tópico '%s' inválido", topic)
+	}
+	return nil
+}
+
+// mqttProvider implementa o Provider para MQTT 3.1.1. A implementação cobre apenas
+// a publicação (CONNECT, PUBLISH com QoS 0/1/2 e DISCONNECT), sem dependências externas.
+type mqttProvider struct {
+	config  *config.MQTTConfig
+	timeout time.Duration
+	verbose bool
+}
+
+// NewMQTTProvider cria uma nova instância do MQTTProvider.
+func NewMQTTProvider(cfg *config.MQTTConfig) Provider {
+	return NewMQTTProviderWithVerbose(cfg, false)
+}
+
+// NewMQTTProviderWithVerbose cria uma nova instância do MQTTProvider com modo verbose.
+func NewMQTTProviderWithVerbose(cfg *config.MQTTConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &mqttProvider{
+		config:  cfg,
+		timeout: timeout,
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *mqttProvider) Name() string {
+	return "mqtt"
+}
+
+// Send publica uma mensagem via MQTT.
+func (p *mqttProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions publica o envelope JSON (ou a mensagem pura, com --raw) em um ou mais tópicos,
+// usando uma única conexão com o broker.
+// Lógica de Target:
+// - "default" ou vazio: usa default_topic
+// - Qualquer outro valor: tópico de publicação (ex: devices/alerts)
+// - Suporta múltiplos tópicos separados por vírgula ou ponto-e-vírgula
+func (p *mqttProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	if p.config.BrokerURL == "" {
+		return fmt.Errorf("broker_url do MQTT não configurada")
+	}
+
+	qos := byte(p.config.QoS)
+	if raw := opts.Get("qos"); raw != "" {
+		parsed, err := parseMQTTQoS(raw)
+		if err != nil {
+			return err
+		}
+		qos = parsed
+	}
+	if qos > 2 {
+		return fmt.Errorf("QoS inválido: %d (use 0, 1 ou 2)", qos)
+	}
+	retain := p.config.Retain || opts.Get("retain") == "true"
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+	topics := make([]string, 0, len(targets))
+	for _, t := range targets {
+		if t == "default" || t == "me" {
+			if p.config.DefaultTopic == "" {
+				return fmt.Errorf("target '%s' requer default_topic configurado", t)
+			}
+			t = p.config.DefaultTopic
+		}
+		if err := validateMQTTTopic(t); err != nil {
+			return err
+		}
+		topics = append(topics, t)
+	}
+
+	conn, err := p.connect()
+	if err != nil {
+		return err
+	}
+	defer conn.close()
+
+	for i, topic := range topics {
+		payload, _, err := envelopePayload(p.Name(), topic, message, opts)
+		if err != nil {
+			return err
+		}
+		if err := conn.publish(topic, payload, qos, retain); err != nil {
+			if len(topics) > 1 {
+				return fmt.Errorf("erro ao publicar em %s (target %d/%d): %w", topic, i+1, len(topics), err)
+			}
+			return err
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] MQTT: publicado em %s (QoS %d, retain %v, %d bytes)\n", topic, qos, retain, len(payload))
+		}
+	}
+	return nil
+}
+
+// mqttConn é uma conexão MQTT aberta.
+type mqttConn struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	packetID uint16
+}
+
+// connect abre a conexão com o broker e envia o CONNECT.
+func (p *mqttProvider) connect() (*mqttConn, error) {
+	broker, err := parseMQTTBroker(p.config.BrokerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: p.timeout}
+	var conn net.Conn
+	if broker.TLS {
+		tlsConfig, err := brokerTLSConfig(broker.Host, p.config.CAFile, p.config.TLSInsecure)
+		if err != nil {
+			return nil, err
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", broker.Addr, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao conectar com o broker MQTT %s: %w", broker.Addr, err)
+		}
+	} else {
+		conn, err = dialer.Dial("tcp", broker.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao conectar com o broker MQTT %s: %w", broker.Addr, err)
+		}
+	}
+	conn.SetDeadline(time.Now().Add(p.timeout))
+
+	username, password := p.config.Username, p.config.Password
+	if username == "" && broker.User != nil {
+		username = broker.User.Username()
+		password, _ = broker.User.Password()
+	}
+	clientID := p.config.ClientID
+	if clientID == "" {
+		clientID = "cast-" + randomHex(6)
+	}
+
+	c := &mqttConn{conn: conn, reader: bufio.NewReader(conn)}
+	if err := c.handshake(clientID, username, password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// handshake envia o CONNECT (sessão limpa) e valida o CONNACK.
+func (c *mqttConn) handshake(clientID, username, password string) error {
+	flags := byte(0x02) // Clean session
+	if username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+
+	body := mqttString("MQTT")
+	body = append(body, 4, flags) // Nível de protocolo 4 = MQTT 3.1.1
+	body = binary.BigEndian.AppendUint16(body, mqttKeepAlive)
+	body = append(body, mqttString(clientID)...)
+	if username != "" {
+		body = append(body, mqttString(username)...)
+		if password != "" {
+			body = append(body, mqttString(password)...)
+		}
+	}
+	if err := c.write(mqttConnect, body); err != nil {
+		return err
+	}
+
+	header, resp, err := c.read()
+	if err != nil {
+		return fmt.Errorf("erro ao aguardar CONNACK: %w", err)
+	}
+	if header != mqttConnack || len(resp) != 2 {
+		return fmt.Errorf("resposta inesperada do broker (pacote 0x%02X)", header)
+	}
+	if resp[1] != 0 {
+		if msg, ok := mqttConnackErrors[resp[1]]; ok {
+			return fmt.Errorf("conexão MQTT recusada: %s", msg)
+		}
+		return fmt.Errorf("conexão MQTT recusada (código %d)", resp[1])
+	}
+	return nil
+}
+
+// publish envia um PUBLISH e conclui o fluxo de confirmação do QoS (PUBACK ou PUBREC/PUBREL/PUBCOMP).
+func (c *mqttConn) publish(topic string, payload []byte, qos byte, retain bool) error {
+	header := byte(mqttPublish) | qos<<1
+	if retain {
+		header |= 0x01
+	}
+
+	body := mqttString(topic)
+	var id uint16
+	if qos > 0 {
+		c.packetID++
+		id = c.packetID
+		body = binary.BigEndian.AppendUint16(body, id)
+	}
+	body = append(body, payload...)
+	if err := c.write(header, body); err != nil {
+		return err
+	}
+
+	switch qos {
+	case 1:
+		return c.expectAck(mqttPuback, id)
+	case 2:
+		if err := c.expectAck(mqttPubrec, id); err != nil {
+			return err
+		}
+		if err := c.write(mqttPubrel, binary.BigEndian.AppendUint16(nil, id)); err != nil {
+			return err
+		}
+		return c.expectAck(mqttPubcomp, id)
+	}
+	return nil
+}
+
+// expectAck aguarda o pacote de confirmação do packet ID informado.
+func (c *mqttConn) expectAck(packetType byte, id uint16) error {
+	header, body, err := c.read()
+	if err != nil {
+		return fmt.Errorf("erro ao aguardar confirmação do broker: %w", err)
+	}
+	if header&0xF0 != packetType&0xF0 || len(body) < 2 || binary.BigEndian.Uint16(body) != id {
+		return fmt.Errorf("confirmação inesperada do broker (pacote 0x%02X)", header)
+	}
+	return nil
+}
+
+// close envia o DISCONNECT e fecha a conexão.
+func (c *mqttConn) close() {
+	c.write(mqttDisconnect, nil)
+	c.conn.Close()
+}
+
+// write envia um pacote com o cabeçalho fixo e o tamanho restante codificado.
+func (c *mqttConn) write(header byte, body []byte) error {
+	packet := append([]byte{header}, mqttRemainingLength(len(body))...)
+	packet = append(packet, body...)
+	if _, err := c.conn.Write(packet); err != nil {
+		return fmt.Errorf("erro ao enviar pacote MQTT: %w", err)
+	}
+	return nil
+}
+
+// read lê um pacote (cabeçalho fixo e corpo).
+func (c *mqttConn) read() (byte, []byte, error) {
+	return readMQTTPacket(c.reader)
+}
+
+// readMQTTPacket lê um pacote MQTT do reader.
+func readMQTTPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(b&0x7F) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, fmt.Errorf("tamanho de pacote MQTT inválido")
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// mqttRemainingLength codifica o tamanho restante (1 a 4 bytes, 7 bits por byte).
+func mqttRemainingLength(n int) []byte {
+	var encoded []byte
+	for {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		encoded = append(encoded, b)
+		if n == 0 {
+			return encoded
+		}
+	}
+}
+
+// mqttString codifica uma string UTF-8 com prefixo de tamanho de 2 bytes.
+func mqttString(s string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(s))), s...)
+}
+
+// testMQTTConnection conecta e desconecta do broker, validando credenciais.
+// A mensagem de teste só é publicada se target for informado.
+func testMQTTConnection(cfg *config.MQTTConfig, target string) error {
+	p := NewMQTTProvider(cfg).(*mqttProvider)
+	conn, err := p.connect()
+	if err != nil {
+		return err
+	}
+	conn.close()
+
+	if target == "" {
+		return nil
+	}
+	return p.Send(target, "Mensagem de teste enviada por 'cast gateway test mqtt'.")
+}
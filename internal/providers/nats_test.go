@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// natsFrame é uma publicação recebida pelo servidor falso.
+type natsFrame struct {
+	Subject string
+	Reply   string
+	Headers string
+	Payload string
+}
+
+// fakeNATSServer simula um servidor NATS: envia INFO, responde PING e, para publicações
+// com reply, devolve a resposta de jsReply (HMSG quando começa com "NATS/1.0").
+type fakeNATSServer struct {
+	URL     string
+	mu      sync.Mutex
+	connect map[string]interface{}
+	frames  []natsFrame
+}
+
+func newFakeNATSServer(t *testing.T, info string, authErr string, jsReply func(subject string) string) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao abrir servidor falso: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeNATSServer{URL: "nats://" + listener.Addr().String()}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn, info, authErr, jsReply)
+		}
+	}()
+	return server
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn, info string, authErr string, jsReply func(string) string) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO %s\r\n", info)
+	reader := bufio.NewReader(conn)
+	sid := ""
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &s.connect)
+			s.mu.Unlock()
+			if authErr != "" {
+				fmt.Fprintf(conn, "-ERR '%s'\r\n", authErr)
+				return
+			}
+		case "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case "SUB":
+			sid = fields[2]
+		case "PUB", "HPUB":
+			frame := natsFrame{Subject: fields[1]}
+			headerLen := 0
+			total, _ := strconv.Atoi(fields[len(fields)-1])
+			if fields[0] == "HPUB" {
+				headerLen, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+			if len(fields) == 4 && fields[0] == "PUB" || len(fields) == 5 {
+				frame.Reply = fields[2]
+			}
+			data := make([]byte, total+2)
+			io.ReadFull(reader, data)
+			frame.Headers = string(data[:headerLen])
+			frame.Payload = string(data[headerLen:total])
+
+			s.mu.Lock()
+			s.frames = append(s.frames, frame)
+			s.mu.Unlock()
+
+			if frame.Reply != "" && jsReply != nil {
+				reply := jsReply(frame.Subject)
+				if strings.HasPrefix(reply, "NATS/1.0") {
+					fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s\r\n", frame.Reply, sid, len(reply), len(reply), reply)
+				} else {
+					fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", frame.Reply, sid, len(reply), reply)
+				}
+			}
+		}
+	}
+}
+
+func (s *fakeNATSServer) published() []natsFrame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]natsFrame(nil), s.frames...)
+}
+
+func TestNATSProvider_Name(t *testing.T) {
+	provider := NewNATSProvider(&config.NATSConfig{})
+	if provider.Name() != "nats" {
+		t.Errorf("Esperado 'nats', obtido '%s'", provider.Name())
+	}
+}
+
+func TestNATSProvider_Send_Core(t *testing.T) {
+	server := newFakeNATSServer(t, `{"server_id":"fake","version":"2.10.0","headers":true,"max_payload":1048576}`, "", nil)
+
+	provider := NewNATSProvider(&config.NATSConfig{
+		ServerURL:      server.URL,
+		Token:          "s3cr3t",
+		DefaultSubject: "alertas.producao",
+		Timeout:        5,
+	}).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{"meta": {"servico=api"}}}
+	if err := provider.SendWithOptions("default;alertas.db", "Deploy concluído", opts); err != nil {
+		t.Fatalf("Erro ao publicar: %v", err)
+	}
+
+	if server.connect["auth_token"] != "s3cr3t" || server.connect["name"] != "cast" {
+		t.Errorf("CONNECT inesperado: %v", server.connect)
+	}
+	frames := server.published()
+	if len(frames) != 2 || frames[0].Subject != "alertas.producao" || frames[1].Subject != "alertas.db" {
+		t.Fatalf("Publicações inesperadas: %+v", frames)
+	}
+	if frames[0].Reply != "" {
+		t.Error("Publicação core não deveria ter reply")
+	}
+	var env Envelope
+	if err := json.Unmarshal([]byte(frames[0].Payload), &env); err != nil {
+		t.Fatalf("Payload não é um envelope JSON: %v", err)
+	}
+	if env.Provider != "nats" || env.Target != "alertas.producao" || env.Message != "Deploy concluído" || env.Metadata["servico"] != "api" {
+		t.Errorf("Envelope inesperado: %+v", env)
+	}
+}
+
+func TestNATSProvider_Send_JetStream(t *testing.T) {
+	server := newFakeNATSServer(t, `{"server_id":"fake","headers":true}`, "", func(subject string) string {
+		if subject == "sem.stream" {
+			return "NATS/1.0 503\r\n\r\n"
+		}
+		return `{"stream":"ALERTAS","seq":42}`
+	})
+
+	provider := NewNATSProvider(&config.NATSConfig{ServerURL: server.URL, JetStream: true, Timeout: 5})
+	if err := provider.Send("alertas.producao", "Disco cheio"); err != nil {
+		t.Fatalf("Erro ao publicar via JetStream: %v", err)
+	}
+
+	frames := server.published()
+	if len(frames) != 1 || !strings.HasPrefix(frames[0].Reply, "_INBOX.") {
+		t.Fatalf("Publicação JetStream deveria ter reply para inbox: %+v", frames)
+	}
+	var env Envelope
+	json.Unmarshal([]byte(frames[0].Payload), &env)
+	if !strings.Contains(frames[0].Headers, "Nats-Msg-Id: "+env.ID) {
+		t.Errorf("Cabeçalho Nats-Msg-Id deveria conter o ID do envelope, obtido %q", frames[0].Headers)
+	}
+
+	err := provider.Send("sem.stream", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "nenhum stream") {
+		t.Errorf("Esperado erro de stream inexistente, obtido: %v", err)
+	}
+}
+
+func TestNATSProvider_Errors(t *testing.T) {
+	server := newFakeNATSServer(t, `{"server_id":"fake"}`, "Authorization Violation", nil)
+	err := NewNATSProvider(&config.NATSConfig{ServerURL: server.URL, Timeout: 5}).Send("alertas", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "credenciais do NATS inválidas") {
+		t.Errorf("Esperado erro de autorização, obtido: %v", err)
+	}
+
+	provider := NewNATSProvider(&config.NATSConfig{ServerURL: "nats://127.0.0.1:1", Timeout: 5})
+	tests := []struct {
+		target   string
+		contains string
+	}{
+		{"alertas.*", "curingas"},
+		{"alertas..db", "token vazio"},
+		{"alertas producao", "espaços"},
+		{"default", "default_subject"},
+	}
+	for _, tt := range tests {
+		err := provider.Send(tt.target, "Teste")
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("Target %s: esperado erro contendo '%s', obtido: %v", tt.target, tt.contains, err)
+		}
+	}
+}
+
+func TestNATSConnection_UserPassFromURL(t *testing.T) {
+	server := newFakeNATSServer(t, `{"server_id":"fake"}`, "", nil)
+
+	cfg := &config.NATSConfig{ServerURL: strings.Replace(server.URL, "nats://", "nats://cast:senha@", 1), Timeout: 5}
+	if err := testNATSConnection(cfg, ""); err != nil {
+		t.Fatalf("Erro no teste de conexão: %v", err)
+	}
+	if server.connect["user"] != "cast" || server.connect["pass"] != "senha" {
+		t.Errorf("Credenciais da URL não enviadas no CONNECT: %v", server.connect)
+	}
+	if len(server.published()) != 0 {
+		t.Error("Teste sem target não deveria publicar")
+	}
+}
@@ -0,0 +1,356 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "ntfy",
+		DisplayName: "ntfy",
+		Order:       100,
+		TargetHint:  "tópico, URL do tópico ou 'default'",
+		Fields: []ConfigField{
+			{Key: "server_url", Flag: "server-url", Label: "URL do servidor", Default: "https://ntfy.sh", Validate: ValidateHTTPURL},
+			{Key: "default_topic", Flag: "default-topic", Label: "Tópico padrão"},
+			{Key: "token", Flag: "token", Label: "Token de acesso (tk_... ou usuário:senha)", Secret: true},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("priority"),
+			sharedSendFlag("tags"),
+			sharedSendFlag("click"),
+			sharedSendFlag("markdown"),
+		},
+		Configured: func(conf *config.Config) bool {
+			// Servidor próprio conta como configurado mesmo sem tópico padrão
+			return conf.Ntfy.DefaultTopic != "" || conf.Ntfy.Token != "" ||
+				(conf.Ntfy.ServerURL != "" && conf.Ntfy.ServerURL != "https://ntfy.sh")
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewNtfyProvider(&conf.Ntfy), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testNtfyConnection(&conf.Ntfy, target)
+		},
+	})
+}
+
+// ntfyPriorities mapeia os nomes de prioridade do ntfy para os níveis 1-5.
+var ntfyPriorities = map[string]int{
+	"min":     1,
+	"low":     2,
+	"default": 3,
+	"high":    4,
+	"urgent":  5,
+	"max":     5,
+}
+
+// parseNtfyPriority converte a prioridade (nome ou número 1-5).
+func parseNtfyPriority(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if p, ok := ntfyPriorities[value]; ok {
+		return p, nil
+	}
+	p, err := strconv.Atoi(value)
+	if err != nil || p < 1 || p > 5 {
+		return 0, fmt.Errorf("prioridade inválida: '%s' (use min, low, default, high, urgent ou 1-5)", value)
+	}
+	return p, nil
+}
+
+// ntfyMessage é o payload JSON de publicação do ntfy.
+type ntfyMessage struct {
+	Topic    string   `json:"topic"`
+	Message  string   `json:"message"`
+	Title    string   `json:"title,omitempty"`
+	Priority int      `json:"priority,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Click    string   `json:"click,omitempty"`
+	Markdown bool     `json:"markdown,omitempty"`
+}
+
+// ntfyProvider implementa o Provider para ntfy (publicação HTTP).
+type ntfyProvider struct {
+	config *config.NtfyConfig
+	client *http.Client
+}
+
+// NewNtfyProvider cria uma nova instância do NtfyProvider.
+func NewNtfyProvider(cfg *config.NtfyConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &ntfyProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *ntfyProvider) Name() string {
+	return "ntfy"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *ntfyProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma notificação via ntfy.
+func (p *ntfyProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma notificação via ntfy.
+// O assunto (--subject) vira o título; --priority, --tags, --click e --markdown
+// são repassados ao ntfy. Cada anexo é publicado como uma mensagem (a primeira leva o texto).
+// Lógica de Target:
+// - Nome do tópico (ex: alertas): publica no servidor configurado
+// - URL do tópico (https://ntfy.exemplo.com/alertas): publica nesse servidor
+// - "default" ou vazio: usa o default_topic configurado
+// - Suporta múltiplos tópicos separados por vírgula ou ponto-e-vírgula
+func (p *ntfyProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	msg := ntfyMessage{
+		Message:  message,
+		Title:    opts.Subject,
+		Click:    opts.Get("click"),
+		Markdown: opts.Get("markdown") == "true",
+	}
+
+	if raw := opts.Get("priority"); raw != "" {
+		priority, err := parseNtfyPriority(raw)
+		if err != nil {
+			return err
+		}
+		msg.Priority = priority
+	}
+	for _, tags := range opts.GetAll("tags") {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				msg.Tags = append(msg.Tags, tag)
+			}
+		}
+	}
+	if msg.Click != "" {
+		if err := ValidateHTTPURL(msg.Click); err != nil {
+			return fmt.Errorf("--click: %w", err)
+		}
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		server, topic, err := p.resolveTopic(t)
+		if err != nil {
+			return err
+		}
+		msg.Topic = topic
+
+		if len(opts.Attachments) == 0 {
+			err = p.publish(server, msg)
+		} else {
+			err = p.publishAttachments(server, msg, opts.Attachments)
+		}
+		if err != nil {
+			return fmt.Errorf("erro ao publicar no tópico %s (target %d/%d): %w", topic, i+1, len(targets), err)
+		}
+	}
+
+	return nil
+}
+
+// resolveTopic retorna o servidor e o tópico a partir do target.
+func (p *ntfyProvider) resolveTopic(target string) (string, string, error) {
+	if strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://") {
+		u, err := url.Parse(target)
+		if err != nil {
+			return "", "", fmt.Errorf("URL do tópico inválida: %w", err)
+		}
+		path := strings.Trim(u.Path, "/")
+		idx := strings.LastIndex(path, "/")
+		topic := path[idx+1:]
+		if topic == "" {
+			return "", "", fmt.Errorf("URL sem tópico: %s", target)
+		}
+		u.Path = "/" + path[:idx+1]
+		u.RawQuery = ""
+		return strings.TrimSuffix(u.String(), "/"), topic, nil
+	}
+
+	topic := target
+	if target == "" || target == "default" || target == "me" {
+		if p.config.DefaultTopic == "" {
+			return "", "", fmt.Errorf("target '%s' requer default_topic configurado", target)
+		}
+		topic = p.config.DefaultTopic
+	}
+
+	server := strings.TrimSuffix(p.config.ServerURL, "/")
+	if server == "" {
+		server = "https://ntfy.sh"
+	}
+	return server, topic, nil
+}
+
+// setAuth adiciona a autenticação: token (Bearer) ou usuário:senha (Basic).
+func (p *ntfyProvider) setAuth(req *http.Request) {
+	if p.config.Token == "" {
+		return
+	}
+	if user, pass, ok := strings.Cut(p.config.Token, ":"); ok {
+		req.SetBasicAuth(user, pass)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.Token)
+}
+
+// publish publica a mensagem em JSON na raiz do servidor.
+func (p *ntfyProvider) publish(server string, msg ntfyMessage) error {
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", server, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	p.setAuth(req)
+
+	return p.do(req)
+}
+
+// publishAttachments envia cada arquivo via PUT no tópico, com os metadados em headers.
+// Headers com caracteres não-ASCII são codificados conforme a RFC 2047, aceita pelo ntfy.
+func (p *ntfyProvider) publishAttachments(server string, msg ntfyMessage, attachments []string) error {
+	for i, path := range attachments {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("erro ao ler anexo %s: %w", path, err)
+		}
+
+		req, err := http.NewRequest("PUT", server+"/"+url.PathEscape(msg.Topic), bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		req.Header.Set("Filename", mime.BEncoding.Encode("UTF-8", filepath.Base(path)))
+		if i == 0 && msg.Message != "" {
+			req.Header.Set("Message", mime.BEncoding.Encode("UTF-8", msg.Message))
+		}
+		if msg.Title != "" {
+			req.Header.Set("Title", mime.BEncoding.Encode("UTF-8", msg.Title))
+		}
+		if msg.Priority > 0 {
+			req.Header.Set("Priority", strconv.Itoa(msg.Priority))
+		}
+		if len(msg.Tags) > 0 {
+			req.Header.Set("Tags", mime.BEncoding.Encode("UTF-8", strings.Join(msg.Tags, ",")))
+		}
+		if msg.Click != "" {
+			req.Header.Set("Click", msg.Click)
+		}
+		if msg.Markdown {
+			req.Header.Set("Markdown", "yes")
+		}
+		p.setAuth(req)
+
+		if err := p.do(req); err != nil {
+			return fmt.Errorf("anexo %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// do executa a requisição e converte erros do ntfy ({"code":40101,"http":401,"error":"..."}).
+func (p *ntfyProvider) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var apiErr struct {
+		Code  int    `json:"code"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return fmt.Errorf("acesso negado ao tópico (verifique o token): %s", apiErr.Error)
+		case http.StatusRequestEntityTooLarge:
+			return fmt.Errorf("anexo maior que o limite do servidor: %s", apiErr.Error)
+		}
+		return fmt.Errorf("ntfy retornou status %d: %s (código %d)", resp.StatusCode, apiErr.Error, apiErr.Code)
+	}
+	return fmt.Errorf("ntfy retornou status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// testNtfyConnection verifica o servidor (/v1/health) e, se houver token, a conta (/v1/account).
+// Se target for informado, publica uma notificação de teste nesse tópico.
+func testNtfyConnection(cfg *config.NtfyConfig, target string) error {
+	p := NewNtfyProvider(cfg).(*ntfyProvider)
+	server := strings.TrimSuffix(cfg.ServerURL, "/")
+	if server == "" {
+		server = "https://ntfy.sh"
+	}
+
+	resp, err := p.client.Get(server + "/v1/health")
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao servidor: %w", err)
+	}
+	var health struct {
+		Healthy bool `json:"healthy"`
+	}
+	json.NewDecoder(resp.Body).Decode(&health)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !health.Healthy {
+		return fmt.Errorf("servidor ntfy indisponível (status %d)", resp.StatusCode)
+	}
+
+	if cfg.Token != "" {
+		req, err := http.NewRequest("GET", server+"/v1/account", nil)
+		if err != nil {
+			return fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		p.setAuth(req)
+		if err := p.do(req); err != nil {
+			return fmt.Errorf("token inválido: %w", err)
+		}
+	}
+
+	if target == "" {
+		return nil
+	}
+	opts := SendOptions{Subject: "CAST - Teste de conectividade"}
+	return p.SendWithOptions(target, "Notificação de teste enviada por 'cast gateway test ntfy'.", opts)
+}
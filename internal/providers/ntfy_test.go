@@ -0,0 +1,185 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestNtfyProvider_Name(t *testing.T) {
+	provider := NewNtfyProvider(&config.NtfyConfig{})
+	if provider.Name() != "ntfy" {
+		t.Errorf("Esperado 'ntfy', obtido '%s'", provider.Name())
+	}
+}
+
+func TestNtfyProvider_Send_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/" {
+			t.Errorf("Esperado POST na raiz, obtido %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer tk_teste" {
+			t.Errorf("Esperado Authorization Bearer, obtido '%s'", r.Header.Get("Authorization"))
+		}
+
+		var msg ntfyMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if msg.Topic != "alertas" || msg.Message != "Disco cheio" || msg.Title != "Servidor" {
+			t.Errorf("Mensagem inesperada: %+v", msg)
+		}
+		if msg.Priority != 4 {
+			t.Errorf("Esperado priority 4 (high), obtido %d", msg.Priority)
+		}
+		if strings.Join(msg.Tags, ",") != "warning,disk,skull" {
+			t.Errorf("Tags inesperadas: %v", msg.Tags)
+		}
+		if msg.Click != "https://grafana.exemplo.com" || !msg.Markdown {
+			t.Errorf("Click/markdown inesperados: %+v", msg)
+		}
+
+		w.Write([]byte(`{"id":"abc","event":"message"}`))
+	}))
+	defer server.Close()
+
+	provider := NewNtfyProvider(&config.NtfyConfig{ServerURL: server.URL, DefaultTopic: "alertas", Token: "tk_teste"}).(OptionsProvider)
+	opts := SendOptions{
+		Subject: "Servidor",
+		Values: map[string][]string{
+			"priority": {"high"},
+			"tags":     {"warning,disk", "skull"},
+			"click":    {"https://grafana.exemplo.com"},
+			"markdown": {"true"},
+		},
+	}
+	if err := provider.SendWithOptions("default", "Disco cheio", opts); err != nil {
+		t.Fatalf("Erro ao enviar notificação: %v", err)
+	}
+}
+
+func TestNtfyProvider_Send_TopicURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "cast" || pass != "segredo" {
+			t.Errorf("Esperado Basic Auth cast:segredo, obtido %s:%s", user, pass)
+		}
+		if r.URL.Path != "/ntfy" {
+			t.Errorf("Esperado publicação na raiz do servidor (/ntfy), obtido '%s'", r.URL.Path)
+		}
+
+		var msg ntfyMessage
+		json.NewDecoder(r.Body).Decode(&msg)
+		if msg.Topic != "deploys" {
+			t.Errorf("Esperado tópico 'deploys', obtido '%s'", msg.Topic)
+		}
+	}))
+	defer server.Close()
+
+	// Servidor publicado em subcaminho: o tópico é o último segmento da URL
+	provider := NewNtfyProvider(&config.NtfyConfig{Token: "cast:segredo"})
+	if err := provider.Send(server.URL+"/ntfy/deploys", "Versão publicada"); err != nil {
+		t.Fatalf("Erro ao enviar notificação: %v", err)
+	}
+}
+
+func TestNtfyProvider_Send_Attachment(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "relatório.txt")
+	if err := os.WriteFile(filePath, []byte("conteúdo"), 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/alertas" {
+			t.Errorf("Esperado PUT /alertas, obtido %s %s", r.Method, r.URL.Path)
+		}
+
+		dec := new(mime.WordDecoder)
+		filename, _ := dec.DecodeHeader(r.Header.Get("Filename"))
+		message, _ := dec.DecodeHeader(r.Header.Get("Message"))
+		if filename != "relatório.txt" {
+			t.Errorf("Esperado Filename 'relatório.txt', obtido '%s'", filename)
+		}
+		if message != "Relatório diário" {
+			t.Errorf("Esperado Message 'Relatório diário', obtido '%s'", message)
+		}
+		if r.Header.Get("Priority") != "5" {
+			t.Errorf("Esperado Priority 5, obtido '%s'", r.Header.Get("Priority"))
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		if string(data) != "conteúdo" {
+			t.Errorf("Corpo inesperado: %s", string(data))
+		}
+	}))
+	defer server.Close()
+
+	provider := NewNtfyProvider(&config.NtfyConfig{ServerURL: server.URL}).(OptionsProvider)
+	opts := SendOptions{
+		Attachments: []string{filePath},
+		Values:      map[string][]string{"priority": {"urgent"}},
+	}
+	if err := provider.SendWithOptions("alertas", "Relatório diário", opts); err != nil {
+		t.Fatalf("Erro ao enviar anexo: %v", err)
+	}
+}
+
+func TestNtfyProvider_Send_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":40301,"http":403,"error":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	provider := NewNtfyProvider(&config.NtfyConfig{ServerURL: server.URL}).(OptionsProvider)
+
+	err := provider.Send("privado", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "acesso negado") {
+		t.Errorf("Esperado erro de acesso negado, obtido: %v", err)
+	}
+
+	err = provider.Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "default_topic") {
+		t.Errorf("Esperado erro de default_topic ausente, obtido: %v", err)
+	}
+
+	err = provider.SendWithOptions("alertas", "Teste", SendOptions{Values: map[string][]string{"priority": {"altíssima"}}})
+	if err == nil || !strings.Contains(err.Error(), "prioridade inválida") {
+		t.Errorf("Esperado erro de prioridade inválida, obtido: %v", err)
+	}
+}
+
+func TestNtfyConnection_HealthAndAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/health":
+			w.Write([]byte(`{"healthy":true}`))
+		case "/v1/account":
+			if r.Header.Get("Authorization") != "Bearer tk_valido" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":40101,"http":401,"error":"unauthorized"}`))
+				return
+			}
+			w.Write([]byte(`{"username":"cast"}`))
+		default:
+			t.Errorf("Teste sem target não deveria publicar (path %s)", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	if err := testNtfyConnection(&config.NtfyConfig{ServerURL: server.URL, Token: "tk_valido"}, ""); err != nil {
+		t.Errorf("Servidor e token válidos não deveriam retornar erro: %v", err)
+	}
+	if err := testNtfyConnection(&config.NtfyConfig{ServerURL: server.URL, Token: "tk_invalido"}, ""); err == nil {
+		t.Error("Token inválido deveria retornar erro")
+	}
+}
@@ -0,0 +1,439 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "opsgenie",
+		DisplayName: "Opsgenie",
+		Order:       180,
+		TargetHint:  "responders (team:Nome, user:email, escalation:Nome, schedule:Nome) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "api_key", Flag: "api-key", Label: "API key (integração API)", Required: true, Secret: true},
+			{Key: "default_priority", Flag: "default-priority", Label: "Prioridade padrão (P1 a P5)", Default: "P3", Validate: validateOpsgeniePriority},
+			{Key: "default_responders", Flag: "default-responders", Label: "Responders padrão (ex: team:SRE,user:ana@exemplo.com)"},
+			{Key: "source", Flag: "source", Label: "Origem do alerta (padrão: hostname)"},
+			{Key: "api_url", Flag: "api-url", Label: "URL da API (EU: https://api.eu.opsgenie.com)", Default: "https://api.opsgenie.com", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("priority"),
+			sharedSendFlag("tags"),
+			sharedSendFlag("dedup-key"),
+			sharedSendFlag("event"),
+			sharedSendFlag("detail"),
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Opsgenie.APIKey != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewOpsgenieProviderWithVerbose(&conf.Opsgenie, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testOpsgenieConnection(&conf.Opsgenie, target)
+		},
+	})
+}
+
+// Limites de tamanho da Alert API.
+const (
+	opsgenieMessageLimit     = 130
+	opsgenieDescriptionLimit = 15000
+)
+
+// opsgeniePriorities mapeia os nomes de prioridade (os mesmos do ntfy) para P1-P5.
+var opsgeniePriorities = map[string]string{
+	"urgent":  "P1",
+	"max":     "P1",
+	"high":    "P2",
+	"default": "P3",
+	"low":     "P4",
+	"min":     "P5",
+}
+
+// parseOpsgeniePriority converte a prioridade (P1 a P5 ou nome).
+func parseOpsgeniePriority(value string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if p, ok := opsgeniePriorities[normalized]; ok {
+		return p, nil
+	}
+	if len(normalized) == 2 && normalized[0] == 'p' && normalized[1] >= '1' && normalized[1] <= '5' {
+		return strings.ToUpper(normalized), nil
+	}
+	return "", fmt.Errorf("prioridade inválida: '%s' (use P1 a P5 ou min, low, default, high, urgent)", value)
+}
+
+// validateOpsgeniePriority valida a prioridade padrão configurada.
+func validateOpsgeniePriority(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseOpsgeniePriority(value)
+	return err
+}
+
+// opsgenieResponder é um destinatário do alerta (team, user, escalation ou schedule).
+type opsgenieResponder struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username,omitempty"` // Usado quando type = user
+}
+
+// opsgenieAlert é o payload de POST /v2/alerts.
+type opsgenieAlert struct {
+	Message     string              `json:"message"`
+	Alias       string              `json:"alias,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Responders  []opsgenieResponder `json:"responders,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Details     map[string]string   `json:"details,omitempty"`
+	Source      string              `json:"source,omitempty"`
+	Priority    string              `json:"priority,omitempty"`
+}
+
+// opsgenieProvider implementa o Provider para Opsgenie via Alert API v2.
+type opsgenieProvider struct {
+	config  *config.OpsgenieConfig
+	client  *http.Client
+	verbose bool
+}
+
+// NewOpsgenieProvider cria uma nova instância do OpsgenieProvider.
+func NewOpsgenieProvider(cfg *config.OpsgenieConfig) Provider {
+	return NewOpsgenieProviderWithVerbose(cfg, false)
+}
+
+// NewOpsgenieProviderWithVerbose cria uma nova instância do OpsgenieProvider com modo verbose.
+func NewOpsgenieProviderWithVerbose(cfg *config.OpsgenieConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &opsgenieProvider{
+		config:  cfg,
+		client:  &http.Client{Timeout: timeout},
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *opsgenieProvider) Name() string {
+	return "opsgenie"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *opsgenieProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send cria um alerta no Opsgenie.
+func (p *opsgenieProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions cria (ou reconhece/fecha, com --event) um alerta via Alert API v2.
+// O assunto (--subject) vira a mensagem do alerta (até 130 caracteres) e a mensagem vira
+// a descrição; sem assunto, mensagens longas são resumidas e enviadas completas na descrição.
+// --dedup-key define o alias, usado pelo Opsgenie para deduplicar e depois fechar o alerta.
+// Lógica de Target (todos os responders vão em um único alerta):
+// - "default" ou vazio: usa default_responders (pode ser vazio: roteamento da integração)
+// - team:Nome, user:email, escalation:Nome ou schedule:Nome
+// - Valor sem prefixo: usuário se contiver @, senão equipe
+func (p *opsgenieProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("api_key do Opsgenie não configurada")
+	}
+
+	alias := opts.Get("dedup-key")
+	switch action := strings.ToLower(opts.Get("event")); action {
+	case "", "trigger":
+	case "acknowledge", "ack":
+		if alias == "" {
+			return fmt.Errorf("--event %s requer --dedup-key", action)
+		}
+		return p.Acknowledge(target, alias, message)
+	case "resolve", "close":
+		if alias == "" {
+			return fmt.Errorf("--event %s requer --dedup-key", action)
+		}
+		return p.Resolve(target, alias, message)
+	default:
+		return fmt.Errorf("evento inválido: '%s' (use trigger, acknowledge ou resolve)", action)
+	}
+
+	alert, err := p.buildAlert(target, message, opts)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		RequestID string `json:"requestId"`
+	}
+	if err := p.call("POST", "/v2/alerts", alert, &result); err != nil {
+		return err
+	}
+	if p.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Opsgenie: alerta aceito (requestId: %s, alias: %s)\n", result.RequestID, alert.Alias)
+	}
+	return nil
+}
+
+// Acknowledge reconhece o alerta com o alias informado.
+func (p *opsgenieProvider) Acknowledge(target string, key string, note string) error {
+	return p.alertAction(key, "acknowledge", note)
+}
+
+// Resolve fecha o alerta com o alias informado.
+func (p *opsgenieProvider) Resolve(target string, key string, note string) error {
+	return p.alertAction(key, "close", note)
+}
+
+// alertAction executa POST /v2/alerts/{alias}/{action}?identifierType=alias.
+func (p *opsgenieProvider) alertAction(alias string, action string, note string) error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("api_key do Opsgenie não configurada")
+	}
+	if alias == "" {
+		return fmt.Errorf("alias do alerta não informado")
+	}
+
+	body := map[string]string{"source": alertSource(p.config.Source)}
+	if note != "" {
+		body["note"] = note
+	}
+	path := "/v2/alerts/" + url.PathEscape(alias) + "/" + action + "?identifierType=alias"
+	if err := p.call("POST", path, body, nil); err != nil {
+		return err
+	}
+	if p.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Opsgenie: %s aceito para o alias %s\n", action, alias)
+	}
+	return nil
+}
+
+// buildAlert monta o alerta com prioridade, responders, tags e detalhes.
+func (p *opsgenieProvider) buildAlert(target string, message string, opts SendOptions) (opsgenieAlert, error) {
+	alert := opsgenieAlert{
+		Alias:    opts.Get("dedup-key"),
+		Source:   alertSource(p.config.Source),
+		Priority: p.config.DefaultPriority,
+	}
+
+	summary, description := opts.Subject, message
+	if summary == "" {
+		summary, description = message, ""
+		if len([]rune(message)) > opsgenieMessageLimit {
+			description = message
+		}
+	}
+	if strings.TrimSpace(summary) == "" {
+		return alert, fmt.Errorf("mensagem vazia")
+	}
+	alert.Message = truncateRunes(summary, opsgenieMessageLimit)
+	alert.Description = truncateRunes(description, opsgenieDescriptionLimit)
+
+	if raw := opts.Get("priority"); raw != "" {
+		priority, err := parseOpsgeniePriority(raw)
+		if err != nil {
+			return alert, err
+		}
+		alert.Priority = priority
+	} else if alert.Priority != "" {
+		priority, err := parseOpsgeniePriority(alert.Priority)
+		if err != nil {
+			return alert, err
+		}
+		alert.Priority = priority
+	}
+
+	responders, err := p.responders(target)
+	if err != nil {
+		return alert, err
+	}
+	alert.Responders = responders
+
+	for _, tags := range opts.GetAll("tags") {
+		alert.Tags = append(alert.Tags, config.ParseTargets(tags)...)
+	}
+
+	details, err := parseAlertDetails(opts.GetAll("detail"))
+	if err != nil {
+		return alert, err
+	}
+	if len(details) > 0 {
+		alert.Details = make(map[string]string, len(details))
+		for k, v := range details {
+			if s, ok := v.(string); ok {
+				alert.Details[k] = s
+				continue
+			}
+			encoded, _ := json.Marshal(v)
+			alert.Details[k] = string(encoded)
+		}
+	}
+	return alert, nil
+}
+
+// responders converte os targets em responders do alerta.
+func (p *opsgenieProvider) responders(target string) ([]opsgenieResponder, error) {
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	var responders []opsgenieResponder
+	for _, t := range targets {
+		if t == "default" || t == "me" {
+			for _, d := range config.ParseTargets(p.config.DefaultResponders) {
+				r, err := parseOpsgenieResponder(d)
+				if err != nil {
+					return nil, fmt.Errorf("default_responders: %w", err)
+				}
+				responders = append(responders, r)
+			}
+			continue
+		}
+		r, err := parseOpsgenieResponder(t)
+		if err != nil {
+			return nil, err
+		}
+		responders = append(responders, r)
+	}
+	return responders, nil
+}
+
+// parseOpsgenieResponder interpreta "tipo:nome" (team, user, escalation ou schedule).
+func parseOpsgenieResponder(value string) (opsgenieResponder, error) {
+	kind, name, ok := strings.Cut(value, ":")
+	if !ok {
+		if strings.Contains(value, "@") {
+			return opsgenieResponder{Type: "user", Username: value}, nil
+		}
+		return opsgenieResponder{Type: "team", Name: value}, nil
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return opsgenieResponder{}, fmt.Errorf("responder inválido: '%s'", value)
+	}
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "team":
+		return opsgenieResponder{Type: "team", Name: name}, nil
+	case "user":
+		return opsgenieResponder{Type: "user", Username: name}, nil
+	case "escalation":
+		return opsgenieResponder{Type: "escalation", Name: name}, nil
+	case "schedule":
+		return opsgenieResponder{Type: "schedule", Name: name}, nil
+	}
+	return opsgenieResponder{}, fmt.Errorf("tipo de responder inválido: '%s' (use team, user, escalation ou schedule)", kind)
+}
+
+// call executa uma requisição autenticada na Alert API.
+func (p *opsgenieProvider) call(method string, path string, payload interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar payload: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	apiURL := p.config.APIURL
+	if apiURL == "" {
+		apiURL = "https://api.opsgenie.com"
+	}
+	req, err := http.NewRequest(method, strings.TrimRight(apiURL, "/")+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Authorization", "GenieKey "+p.config.APIKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar com o Opsgenie: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return opsgenieError(resp.StatusCode, body)
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("erro ao decodificar resposta: %w", err)
+		}
+	}
+	return nil
+}
+
+// opsgenieError converte erros da API ({"message": "...", "errors": {...}}) em mensagens amigáveis.
+func opsgenieError(statusCode int, body []byte) error {
+	var apiErr struct {
+		Message string            `json:"message"`
+		Errors  map[string]string `json:"errors"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		message = apiErr.Message
+		for field, detail := range apiErr.Errors {
+			message += fmt.Sprintf(" (%s: %s)", field, detail)
+		}
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("api_key do Opsgenie inválida: %s", message)
+	case http.StatusForbidden:
+		return fmt.Errorf("integração sem permissão para esta operação (verifique o acesso de leitura/escrita da API key): %s", message)
+	case http.StatusNotFound:
+		return fmt.Errorf("alerta não encontrado no Opsgenie: %s", message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("limite de requisições do Opsgenie atingido: %s", message)
+	}
+	return fmt.Errorf("Opsgenie retornou status %d: %s", statusCode, message)
+}
+
+// testOpsgenieConnection valida a api_key listando um alerta (GET /v2/alerts?limit=1).
+// Com target, um alerta de teste (P5) é criado e fechado em seguida.
+func testOpsgenieConnection(cfg *config.OpsgenieConfig, target string) error {
+	if err := validateOpsgeniePriority(cfg.DefaultPriority); err != nil {
+		return err
+	}
+
+	p := NewOpsgenieProvider(cfg).(*opsgenieProvider)
+	if err := p.call("GET", "/v2/alerts?limit=1", nil, nil); err != nil {
+		return err
+	}
+
+	if target == "" {
+		return nil
+	}
+	alias := fmt.Sprintf("cast-test-%d", time.Now().Unix())
+	opts := SendOptions{Values: map[string][]string{"priority": {"P5"}, "dedup-key": {alias}}}
+	if err := p.SendWithOptions(target, "Alerta de teste criado por 'cast gateway test opsgenie'.", opts); err != nil {
+		return err
+	}
+	return p.Resolve(target, alias, "Teste concluído")
+}
@@ -0,0 +1,165 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func newTestOpsgenieConfig(serverURL string) *config.OpsgenieConfig {
+	return &config.OpsgenieConfig{
+		APIURL:            serverURL,
+		APIKey:            "chave-genie",
+		DefaultPriority:   "P3",
+		DefaultResponders: "team:SRE",
+		Source:            "srv-01",
+		Timeout:           30,
+	}
+}
+
+func TestOpsgenieProvider_Name(t *testing.T) {
+	provider := NewOpsgenieProvider(&config.OpsgenieConfig{})
+	if provider.Name() != "opsgenie" {
+		t.Errorf("Esperado 'opsgenie', obtido '%s'", provider.Name())
+	}
+}
+
+func TestOpsgenieProvider_Send_CreateAlert(t *testing.T) {
+	var alert opsgenieAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v2/alerts" {
+			t.Errorf("Esperado POST /v2/alerts, obtido %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "GenieKey chave-genie" {
+			t.Errorf("Authorization inesperado: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Fatalf("Erro ao decodificar alerta: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"result":"Request will be processed","took":0.1,"requestId":"req-1"}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpsgenieProvider(newTestOpsgenieConfig(server.URL)).(OptionsProvider)
+	opts := SendOptions{
+		Values: map[string][]string{
+			"priority":  {"urgent"},
+			"tags":      {"db,producao", "noturno"},
+			"dedup-key": {"db-down"},
+			"detail":    {"host=db-01", `{"replicas": 2}`},
+		},
+	}
+	message := strings.Repeat("Conexões recusadas. ", 10)
+	if err := provider.SendWithOptions("default,ana@exemplo.com,escalation:Plantão", message, opts); err != nil {
+		t.Fatalf("Erro ao criar alerta: %v", err)
+	}
+
+	if alert.Alias != "db-down" || alert.Priority != "P1" || alert.Source != "srv-01" {
+		t.Errorf("Alerta inesperado: %+v", alert)
+	}
+	if n := len([]rune(alert.Message)); n != opsgenieMessageLimit {
+		t.Errorf("Mensagem deveria ser resumida em %d caracteres, obtido %d", opsgenieMessageLimit, n)
+	}
+	if alert.Description != message {
+		t.Error("Mensagem longa deveria ser enviada completa na descrição")
+	}
+	if strings.Join(alert.Tags, ",") != "db,producao,noturno" {
+		t.Errorf("Tags inesperadas: %v", alert.Tags)
+	}
+	if alert.Details["host"] != "db-01" || alert.Details["replicas"] != "2" {
+		t.Errorf("Detalhes inesperados: %v", alert.Details)
+	}
+
+	expected := []opsgenieResponder{
+		{Type: "team", Name: "SRE"},
+		{Type: "user", Username: "ana@exemplo.com"},
+		{Type: "escalation", Name: "Plantão"},
+	}
+	if len(alert.Responders) != len(expected) {
+		t.Fatalf("Esperado %d responders, obtido %v", len(expected), alert.Responders)
+	}
+	for i, r := range expected {
+		if alert.Responders[i] != r {
+			t.Errorf("Responder %d: esperado %+v, obtido %+v", i, r, alert.Responders[i])
+		}
+	}
+}
+
+func TestOpsgenieProvider_ResolveAndAcknowledge(t *testing.T) {
+	var paths []string
+	var note string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("identifierType") != "alias" {
+			t.Errorf("Esperado identifierType=alias, obtido %s", r.URL.RawQuery)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		note = body["note"]
+		paths = append(paths, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider := NewOpsgenieProvider(newTestOpsgenieConfig(server.URL)).(IncidentProvider)
+	if err := provider.Acknowledge("default", "db down", ""); err != nil {
+		t.Fatalf("Erro ao reconhecer alerta: %v", err)
+	}
+	if err := provider.Resolve("default", "db down", "Failover concluído"); err != nil {
+		t.Fatalf("Erro ao fechar alerta: %v", err)
+	}
+
+	if strings.Join(paths, ",") != "/v2/alerts/db%20down/acknowledge,/v2/alerts/db%20down/close" {
+		t.Errorf("Paths inesperados: %v", paths)
+	}
+	if note != "Failover concluído" {
+		t.Errorf("Nota inesperada: %s", note)
+	}
+}
+
+func TestOpsgenieProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/close") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Alert with id [inexistente] not found.","took":0.002}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Key format is not valid!","took":0.001}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpsgenieProvider(newTestOpsgenieConfig(server.URL))
+	if err := provider.Send("default", "Teste"); err == nil || !strings.Contains(err.Error(), "api_key do Opsgenie inválida") {
+		t.Errorf("Esperado erro de api_key, obtido: %v", err)
+	}
+	if err := provider.(IncidentProvider).Resolve("default", "inexistente", ""); err == nil || !strings.Contains(err.Error(), "não encontrado") {
+		t.Errorf("Esperado erro de alerta não encontrado, obtido: %v", err)
+	}
+
+	opts := SendOptions{Values: map[string][]string{"priority": {"P9"}}}
+	if err := provider.(OptionsProvider).SendWithOptions("default", "Teste", opts); err == nil || !strings.Contains(err.Error(), "prioridade inválida") {
+		t.Errorf("Esperado erro de prioridade, obtido: %v", err)
+	}
+	if err := provider.Send("squad:X", "Teste"); err == nil || !strings.Contains(err.Error(), "tipo de responder inválido") {
+		t.Errorf("Esperado erro de responder, obtido: %v", err)
+	}
+}
+
+func TestOpsgenieConnection_ListAlerts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/v2/alerts" {
+			t.Errorf("Teste sem target não deveria criar alertas (%s %s)", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":[],"took":0.01,"requestId":"req-2"}`))
+	}))
+	defer server.Close()
+
+	if err := testOpsgenieConnection(newTestOpsgenieConfig(server.URL), ""); err != nil {
+		t.Errorf("Teste sem target não deveria retornar erro: %v", err)
+	}
+}
@@ -0,0 +1,422 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "pagerduty",
+		Aliases:     []string{"pd"},
+		DisplayName: "PagerDuty",
+		Order:       170,
+		TargetHint:  "nome do serviço (pagerduty.services), routing key ou 'default'",
+		Fields: []ConfigField{
+			{Key: "routing_key", Flag: "routing-key", Label: "Routing key (integration key Events API v2) do serviço padrão", Secret: true, Validate: validatePagerDutyRoutingKey},
+			{Key: "services", Flag: "services", Label: "Serviços adicionais (nome=routing_key, separados por vírgula)", Secret: true},
+			{Key: "default_severity", Flag: "default-severity", Label: "Severidade padrão (critical, error, warning ou info)", Default: "error", Validate: validatePagerDutySeverity},
+			{Key: "source", Flag: "source", Label: "Origem do evento (padrão: hostname)"},
+			{Key: "events_url", Flag: "events-url", Label: "URL da Events API", Default: "https://events.pagerduty.com", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("severity"),
+			sharedSendFlag("dedup-key"),
+			sharedSendFlag("event"),
+			sharedSendFlag("detail"),
+		},
+		Validate: func(conf *config.Config) error {
+			if conf.PagerDuty.RoutingKey == "" && len(conf.PagerDuty.Services) == 0 {
+				return fmt.Errorf("configuração do PagerDuty incompleta: routing_key ou services é obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.PagerDuty.RoutingKey != "" || len(conf.PagerDuty.Services) > 0
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewPagerDutyProviderWithVerbose(&conf.PagerDuty, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testPagerDutyConnection(&conf.PagerDuty, target)
+		},
+	})
+}
+
+// pagerDutySummaryLimit é o tamanho máximo de payload.summary aceito pela Events API.
+const pagerDutySummaryLimit = 1024
+
+// pagerDutyKeyPattern reconhece routing keys (integration keys de 32 caracteres).
+var pagerDutyKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]{32}$`)
+
+// pagerDutySeverities são as severidades aceitas pela Events API v2.
+var pagerDutySeverities = []string{"critical", "error", "warning", "info"}
+
+// validatePagerDutySeverity valida a severidade padrão configurada.
+func validatePagerDutySeverity(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range pagerDutySeverities {
+		if strings.ToLower(value) == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("severidade inválida: '%s' (use %s)", value, strings.Join(pagerDutySeverities, ", "))
+}
+
+// validatePagerDutyRoutingKey valida o formato da routing key configurada.
+func validatePagerDutyRoutingKey(value string) error {
+	if value == "" || pagerDutyKeyPattern.MatchString(value) {
+		return nil
+	}
+	return fmt.Errorf("routing key inválida (esperado 32 caracteres alfanuméricos)")
+}
+
+// pagerDutyEvent é o payload de POST /v2/enqueue.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"` // trigger, acknowledge ou resolve
+	DedupKey    string            `json:"dedup_key,omitempty"`
+	Client      string            `json:"client,omitempty"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"` // Obrigatório apenas em trigger
+}
+
+// pagerDutyPayload descreve o incidente de um evento trigger.
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// pagerDutyResponse é a resposta da Events API (sucesso e erro).
+type pagerDutyResponse struct {
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	DedupKey string   `json:"dedup_key"`
+	Errors   []string `json:"errors"`
+}
+
+// pagerDutyProvider implementa o Provider para PagerDuty via Events API v2.
+type pagerDutyProvider struct {
+	config  *config.PagerDutyConfig
+	client  *http.Client
+	verbose bool
+}
+
+// NewPagerDutyProvider cria uma nova instância do PagerDutyProvider.
+func NewPagerDutyProvider(cfg *config.PagerDutyConfig) Provider {
+	return NewPagerDutyProviderWithVerbose(cfg, false)
+}
+
+// NewPagerDutyProviderWithVerbose cria uma nova instância do PagerDutyProvider com modo verbose.
+func NewPagerDutyProviderWithVerbose(cfg *config.PagerDutyConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &pagerDutyProvider{
+		config:  cfg,
+		client:  &http.Client{Timeout: timeout},
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *pagerDutyProvider) Name() string {
+	return "pagerduty"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *pagerDutyProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send abre um incidente no PagerDuty.
+func (p *pagerDutyProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia um evento para a Events API v2.
+// O assunto (--subject) vira o resumo do incidente e a mensagem vai para os detalhes;
+// sem assunto, a própria mensagem é o resumo. --event acknowledge/resolve exige --dedup-key.
+// Lógica de Target:
+// - "default" ou vazio: usa routing_key
+// - Nome de serviço definido em pagerduty.services
+// - Routing key de 32 caracteres (uso direto)
+// - Múltiplos serviços separados por vírgula ou ponto-e-vírgula recebem o mesmo evento
+func (p *pagerDutyProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	action := strings.ToLower(opts.Get("event"))
+	if action == "" {
+		action = "trigger"
+	}
+	dedupKey := opts.Get("dedup-key")
+
+	switch action {
+	case "trigger":
+	case "acknowledge", "ack", "resolve":
+		if action == "ack" {
+			action = "acknowledge"
+		}
+		if dedupKey == "" {
+			return fmt.Errorf("--event %s requer --dedup-key", action)
+		}
+	default:
+		return fmt.Errorf("evento inválido: '%s' (use trigger, acknowledge ou resolve)", action)
+	}
+
+	event := pagerDutyEvent{EventAction: action, DedupKey: dedupKey, Client: "CAST"}
+	if action == "trigger" {
+		payload, err := p.buildPayload(message, opts)
+		if err != nil {
+			return err
+		}
+		event.Payload = payload
+	}
+	return p.enqueueAll(target, event)
+}
+
+// Acknowledge reconhece o incidente com a dedup_key informada (a nota é ignorada pela Events API).
+func (p *pagerDutyProvider) Acknowledge(target string, key string, note string) error {
+	return p.enqueueAll(target, pagerDutyEvent{EventAction: "acknowledge", DedupKey: key, Client: "CAST"})
+}
+
+// Resolve resolve o incidente com a dedup_key informada (a nota é ignorada pela Events API).
+func (p *pagerDutyProvider) Resolve(target string, key string, note string) error {
+	return p.enqueueAll(target, pagerDutyEvent{EventAction: "resolve", DedupKey: key, Client: "CAST"})
+}
+
+// buildPayload monta o payload de um evento trigger.
+func (p *pagerDutyProvider) buildPayload(message string, opts SendOptions) (*pagerDutyPayload, error) {
+	summary := opts.Subject
+	if summary == "" {
+		summary = message
+	}
+	if strings.TrimSpace(summary) == "" {
+		return nil, fmt.Errorf("mensagem vazia")
+	}
+
+	severity := strings.ToLower(opts.Get("severity"))
+	if severity == "" {
+		severity = strings.ToLower(p.config.DefaultSeverity)
+	}
+	if severity == "" {
+		severity = "error"
+	}
+	if err := validatePagerDutySeverity(severity); err != nil {
+		return nil, err
+	}
+
+	details, err := parseAlertDetails(opts.GetAll("detail"))
+	if err != nil {
+		return nil, err
+	}
+	if opts.Subject != "" && message != "" {
+		if details == nil {
+			details = map[string]interface{}{}
+		}
+		details["message"] = message
+	}
+
+	return &pagerDutyPayload{
+		Summary:       truncateRunes(summary, pagerDutySummaryLimit),
+		Source:        alertSource(p.config.Source),
+		Severity:      severity,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		CustomDetails: details,
+	}, nil
+}
+
+// enqueueAll resolve os targets e envia o evento para cada serviço.
+func (p *pagerDutyProvider) enqueueAll(target string, event pagerDutyEvent) error {
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	// Resolve todos os serviços antes de enviar, evitando envios parciais por erro de digitação
+	keys := make([]string, 0, len(targets))
+	for _, t := range targets {
+		key, err := p.routingKey(t)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	for i, key := range keys {
+		event.RoutingKey = key
+		result, err := p.enqueue(event)
+		if err != nil {
+			if len(keys) > 1 {
+				return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", targets[i], i+1, len(keys), err)
+			}
+			return err
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] PagerDuty: evento %s aceito para %s (dedup_key: %s)\n", event.EventAction, targets[i], result.DedupKey)
+		}
+	}
+	return nil
+}
+
+// routingKey converte um target em routing key.
+func (p *pagerDutyProvider) routingKey(target string) (string, error) {
+	if target == "default" || target == "me" {
+		if p.config.RoutingKey == "" {
+			return "", fmt.Errorf("target '%s' requer routing_key do PagerDuty configurada", target)
+		}
+		return p.config.RoutingKey, nil
+	}
+
+	if key, ok := p.config.Services[target]; ok {
+		return key, nil
+	}
+	for name, key := range p.config.Services {
+		if strings.EqualFold(name, target) {
+			return key, nil
+		}
+	}
+	if pagerDutyKeyPattern.MatchString(target) {
+		return target, nil
+	}
+	return "", fmt.Errorf("serviço '%s' não encontrado em pagerduty.services (use 'cast gateway update pagerduty --services %s=ROUTING_KEY')", target, target)
+}
+
+// enqueue envia um evento via POST /v2/enqueue.
+func (p *pagerDutyProvider) enqueue(event pagerDutyEvent) (*pagerDutyResponse, error) {
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+
+	eventsURL := p.config.EventsURL
+	if eventsURL == "" {
+		eventsURL = "https://events.pagerduty.com"
+	}
+	req, err := http.NewRequest("POST", strings.TrimRight(eventsURL, "/")+"/v2/enqueue", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar com o PagerDuty: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result pagerDutyResponse
+	json.Unmarshal(body, &result)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return &result, nil
+	}
+	return nil, pagerDutyError(resp.StatusCode, result, body)
+}
+
+// pagerDutyError converte erros da Events API em mensagens amigáveis.
+func pagerDutyError(statusCode int, result pagerDutyResponse, body []byte) error {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("limite de eventos do PagerDuty atingido, tente novamente em instantes")
+	case http.StatusBadRequest:
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("evento rejeitado pelo PagerDuty: %s", strings.Join(result.Errors, "; "))
+		}
+	}
+	if result.Message != "" {
+		return fmt.Errorf("PagerDuty retornou status %d: %s", statusCode, result.Message)
+	}
+	return fmt.Errorf("PagerDuty retornou status %d: %s", statusCode, strings.TrimSpace(string(body)))
+}
+
+// parseAlertDetails converte valores de --detail (chave=valor, objeto JSON ou @arquivo.json) em mapa.
+func parseAlertDetails(values []string) (map[string]interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	details := map[string]interface{}{}
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "@") {
+			obj, err := parseJSONObject("detail", trimmed)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range obj {
+				details[k] = v
+			}
+			continue
+		}
+		key, val, ok := strings.Cut(trimmed, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("--detail inválido: '%s' (use chave=valor ou objeto JSON)", value)
+		}
+		details[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return details, nil
+}
+
+// alertSource retorna a origem configurada ou, se vazia, o hostname da máquina.
+func alertSource(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "cast"
+}
+
+// truncateRunes limita o texto a max caracteres, terminando com reticências quando cortado.
+func truncateRunes(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// testPagerDutyConnection valida o formato das routing keys. A Events API não possui
+// endpoint de verificação; com target, um incidente de teste (severidade info) é aberto
+// e resolvido em seguida.
+func testPagerDutyConnection(cfg *config.PagerDutyConfig, target string) error {
+	if err := validatePagerDutyRoutingKey(cfg.RoutingKey); err != nil {
+		return err
+	}
+	for name, key := range cfg.Services {
+		if err := validatePagerDutyRoutingKey(key); err != nil {
+			return fmt.Errorf("serviço '%s': %w", name, err)
+		}
+	}
+
+	if target == "" {
+		return nil
+	}
+	p := NewPagerDutyProvider(cfg).(*pagerDutyProvider)
+	dedupKey := fmt.Sprintf("cast-test-%d", time.Now().Unix())
+	opts := SendOptions{Values: map[string][]string{"severity": {"info"}, "dedup-key": {dedupKey}}}
+	if err := p.SendWithOptions(target, "Incidente de teste aberto por 'cast gateway test pagerduty'.", opts); err != nil {
+		return err
+	}
+	return p.Resolve(target, dedupKey, "")
+}
@@ -0,0 +1,171 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+const (
+	testPagerDutyKey   = "R0UT1NGKEYDEFAULT000000000000001"
+	testPagerDutyDBKey = "R0UT1NGKEYDATABASE00000000000002"
+)
+
+func newTestPagerDutyConfig(serverURL string) *config.PagerDutyConfig {
+	return &config.PagerDutyConfig{
+		EventsURL:       serverURL,
+		RoutingKey:      testPagerDutyKey,
+		Services:        map[string]string{"database": testPagerDutyDBKey},
+		DefaultSeverity: "error",
+		Source:          "srv-01",
+		Timeout:         30,
+	}
+}
+
+func TestPagerDutyProvider_Name(t *testing.T) {
+	provider := NewPagerDutyProvider(&config.PagerDutyConfig{})
+	if provider.Name() != "pagerduty" {
+		t.Errorf("Esperado 'pagerduty', obtido '%s'", provider.Name())
+	}
+}
+
+func TestPagerDutyProvider_Send_Trigger(t *testing.T) {
+	var event pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v2/enqueue" {
+			t.Errorf("Esperado POST /v2/enqueue, obtido %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Fatalf("Erro ao decodificar evento: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"success","message":"Event processed","dedup_key":"db-down"}`))
+	}))
+	defer server.Close()
+
+	provider := NewPagerDutyProvider(newTestPagerDutyConfig(server.URL)).(OptionsProvider)
+	opts := SendOptions{
+		Subject: "Banco principal fora do ar",
+		Values: map[string][]string{
+			"severity":  {"Critical"},
+			"dedup-key": {"db-down"},
+			"detail":    {"host=db-01", `{"replicas": 2}`},
+		},
+	}
+	if err := provider.SendWithOptions("Database", "Conexões recusadas desde 03:12", opts); err != nil {
+		t.Fatalf("Erro ao enviar evento: %v", err)
+	}
+
+	if event.RoutingKey != testPagerDutyDBKey || event.EventAction != "trigger" || event.DedupKey != "db-down" {
+		t.Errorf("Evento inesperado: %+v", event)
+	}
+	if event.Payload == nil {
+		t.Fatal("Evento trigger deveria ter payload")
+	}
+	if event.Payload.Summary != "Banco principal fora do ar" || event.Payload.Severity != "critical" || event.Payload.Source != "srv-01" {
+		t.Errorf("Payload inesperado: %+v", event.Payload)
+	}
+	details := event.Payload.CustomDetails
+	if details["host"] != "db-01" || details["replicas"] != float64(2) || details["message"] != "Conexões recusadas desde 03:12" {
+		t.Errorf("custom_details inesperado: %v", details)
+	}
+}
+
+func TestPagerDutyProvider_ResolveWithoutPayload(t *testing.T) {
+	var raw map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&raw)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"success","dedup_key":"db-down"}`))
+	}))
+	defer server.Close()
+
+	provider := NewPagerDutyProvider(newTestPagerDutyConfig(server.URL)).(IncidentProvider)
+	if err := provider.Resolve("default", "db-down", ""); err != nil {
+		t.Fatalf("Erro ao resolver incidente: %v", err)
+	}
+	if raw["event_action"] != "resolve" || raw["dedup_key"] != "db-down" || raw["routing_key"] != testPagerDutyKey {
+		t.Errorf("Evento inesperado: %v", raw)
+	}
+	if _, ok := raw["payload"]; ok {
+		t.Error("Evento resolve não deveria enviar payload")
+	}
+}
+
+func TestPagerDutyProvider_Send_Validation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	provider := NewPagerDutyProvider(newTestPagerDutyConfig(server.URL)).(OptionsProvider)
+	tests := []struct {
+		target   string
+		values   map[string][]string
+		contains string
+	}{
+		{"database", map[string][]string{"event": {"resolve"}}, "requer --dedup-key"},
+		{"database", map[string][]string{"event": {"silence"}}, "evento inválido"},
+		{"database", map[string][]string{"severity": {"fatal"}}, "severidade inválida"},
+		{"database", map[string][]string{"detail": {"sem-igual"}}, "--detail inválido"},
+		{"database,inexistente", nil, "serviço 'inexistente' não encontrado"},
+	}
+	for _, tt := range tests {
+		err := provider.SendWithOptions(tt.target, "Teste", SendOptions{Values: tt.values})
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("%v: esperado erro contendo '%s', obtido: %v", tt.values, tt.contains, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Nenhum evento deveria ser enviado, obtido %d requisições", requests)
+	}
+}
+
+func TestPagerDutyProvider_APIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"invalid event","message":"Event object is invalid","errors":["'routing_key' is invalid"]}`))
+	}))
+	defer server.Close()
+
+	err := NewPagerDutyProvider(newTestPagerDutyConfig(server.URL)).Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "'routing_key' is invalid") {
+		t.Errorf("Esperado erro de evento inválido, obtido: %v", err)
+	}
+}
+
+func TestPagerDutyConnection_TriggerAndResolve(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		actions = append(actions, event.EventAction)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cfg := newTestPagerDutyConfig(server.URL)
+	if err := testPagerDutyConnection(cfg, ""); err != nil {
+		t.Errorf("Teste sem target não deveria retornar erro: %v", err)
+	}
+	if len(actions) != 0 {
+		t.Errorf("Teste sem target não deveria enviar eventos, obtido %v", actions)
+	}
+
+	if err := testPagerDutyConnection(cfg, "database"); err != nil {
+		t.Fatalf("Erro no teste com target: %v", err)
+	}
+	if strings.Join(actions, ",") != "trigger,resolve" {
+		t.Errorf("Esperado trigger seguido de resolve, obtido %v", actions)
+	}
+
+	cfg.Services["legado"] = "curta"
+	if err := testPagerDutyConnection(cfg, ""); err == nil || !strings.Contains(err.Error(), "legado") {
+		t.Errorf("Esperado erro de routing key inválida, obtido: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// CapturedRequest é uma requisição HTTP interceptada por PreviewHTTP.
+type CapturedRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// HTTPTransportSetter é implementado pelos providers que enviam por HTTP, permitindo
+// substituir o transporte do cliente HTTP do provider (usado por PreviewHTTP).
+type HTTPTransportSetter interface {
+	SetHTTPTransport(transport http.RoundTripper)
+}
+
+// errPreview interrompe o envio após a requisição ser capturada.
+var errPreview = errors.New("requisição não enviada (preview)")
+
+// previewTransport captura as requisições em vez de enviá-las.
+type previewTransport struct {
+	mu       sync.Mutex
+	requests []CapturedRequest
+}
+
+// RoundTrip registra a requisição e falha sem acessar a rede.
+func (t *previewTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests = append(t.requests, CapturedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	return nil, errPreview
+}
+
+// PreviewHTTP executa o envio com as requisições HTTP interceptadas e retorna as requisições
+// que o provider faria, sem enviar nada. Cada requisição recebe um erro em vez de resposta:
+// em fluxos de várias etapas (ex: upload de anexo antes da mensagem), apenas as requisições
+// até a primeira etapa são exibidas. Apenas providers com Capabilities.HTTP cujo transporte
+// pode ser substituído (HTTPTransportSetter) são aceitos.
+func PreviewHTTP(provider Provider, target string, message string, opts SendOptions) ([]CapturedRequest, error) {
+	reg, ok := Lookup(provider.Name())
+	if !ok || !reg.Capabilities.HTTP {
+		return nil, fmt.Errorf("provider '%s' não envia por HTTP: preview indisponível", provider.Name())
+	}
+	setter, ok := provider.(HTTPTransportSetter)
+	if !ok {
+		return nil, fmt.Errorf("provider '%s' não permite interceptar as requisições: preview indisponível", provider.Name())
+	}
+
+	transport := &previewTransport{}
+	setter.SetHTTPTransport(transport)
+
+	var err error
+	if optProv, ok := provider.(OptionsProvider); ok {
+		err = optProv.SendWithOptions(target, message, opts)
+	} else {
+		err = provider.Send(target, message)
+	}
+	if len(transport.requests) == 0 {
+		if err == nil {
+			err = fmt.Errorf("nenhuma requisição HTTP foi feita pelo provider '%s'", provider.Name())
+		}
+		return nil, err
+	}
+	return transport.requests, nil
+}
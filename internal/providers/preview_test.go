@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// sentinelProvider registra se Send foi chamado.
+type sentinelProvider struct {
+	name string
+	sent bool
+}
+
+func (p *sentinelProvider) Name() string { return p.name }
+
+func (p *sentinelProvider) Send(target string, message string) error {
+	p.sent = true
+	return nil
+}
+
+func TestPreviewHTTP(t *testing.T) {
+	original := http.DefaultTransport
+	provider := NewTelegramProvider(&config.TelegramConfig{Token: "123456:SEGREDO", Timeout: 5}, "")
+
+	requests, err := PreviewHTTP(provider, "42", "Deploy finalizado", SendOptions{})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("Esperada 1 requisição, obtido %d", len(requests))
+	}
+	req := requests[0]
+	if req.Method != "POST" || req.URL != "https://api.telegram.org/bot123456:SEGREDO/sendMessage" {
+		t.Errorf("Requisição inesperada: %s %s", req.Method, req.URL)
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type inesperado: %s", req.Header.Get("Content-Type"))
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(req.Body, &payload); err != nil || payload["text"] != "Deploy finalizado" {
+		t.Errorf("Corpo inesperado (%v): %s", err, req.Body)
+	}
+	if http.DefaultTransport != original {
+		t.Error("O transporte HTTP padrão deveria ser restaurado após o preview")
+	}
+
+	// Providers que não usam HTTP não são executados (o envio seria real)
+	file := &sentinelProvider{name: "file"}
+	if _, err := PreviewHTTP(file, "default", "oi", SendOptions{}); err == nil || !strings.Contains(err.Error(), "não envia por HTTP") {
+		t.Errorf("Esperado erro para provider sem HTTP, obtido: %v", err)
+	}
+	if file.sent {
+		t.Error("O provider sem HTTP não deveria ter enviado a mensagem")
+	}
+
+	// Providers HTTP que não permitem trocar o transporte também não são executados
+	opaque := &sentinelProvider{name: "telegram"}
+	if _, err := PreviewHTTP(opaque, "42", "oi", SendOptions{}); err == nil || !strings.Contains(err.Error(), "não permite interceptar") {
+		t.Errorf("Esperado erro para provider sem HTTPTransportSetter, obtido: %v", err)
+	}
+	if opaque.sent {
+		t.Error("O provider sem HTTPTransportSetter não deveria ter enviado a mensagem")
+	}
+}
+
+// countingTransport conta as requisições que chegam ao transporte HTTP padrão.
+type countingTransport struct {
+	hits atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.hits.Add(1)
+	return nil, errPreview
+}
+
+func TestPreviewHTTP_NoRequestReachesNetwork(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	// Providers com URL fixa (ex: api.telegram.org) usariam o transporte padrão
+	fallback := &countingTransport{}
+	original := http.DefaultTransport
+	http.DefaultTransport = fallback
+	defer func() { http.DefaultTransport = original }()
+
+	for _, reg := range Registered() {
+		if !reg.Capabilities.HTTP {
+			continue
+		}
+		// Campos de URL apontam para o servidor de teste; os demais recebem valores fictícios
+		cfg := &config.Config{}
+		for _, f := range reg.Fields {
+			value := f.Default
+			switch {
+			case strings.Contains(f.Key, "url"):
+				value = server.URL
+			case value != "":
+			case f.Kind == FieldInt:
+				value = "30"
+			case f.Key == "routing_key":
+				value = "0123456789abcdef0123456789abcdef"
+			case f.Key == "from_number":
+				value = "+5511988887777"
+			case f.Kind == FieldString && f.Required:
+				value = "valor-" + f.Key
+			}
+			if f.Key == "timeout" {
+				value = "30"
+			}
+			if err := cfg.SetField(reg.Name, f.Key, value); err != nil {
+				t.Fatalf("%s: erro ao configurar %s: %v", reg.Name, f.Key, err)
+			}
+		}
+		provider, err := reg.New(cfg, false)
+		if err != nil {
+			t.Errorf("%s: erro ao criar provider: %v", reg.Name, err)
+			continue
+		}
+
+		requests, err := PreviewHTTP(provider, previewTestTargets[reg.Name], "Deploy finalizado", SendOptions{})
+		if err != nil || len(requests) == 0 {
+			t.Errorf("%s: o preview deveria capturar a requisição: %v", reg.Name, err)
+		}
+	}
+
+	if hits.Load() != 0 || fallback.hits.Load() != 0 {
+		t.Errorf("Nenhuma requisição deveria sair no preview: %d no servidor, %d no transporte padrão", hits.Load(), fallback.hits.Load())
+	}
+}
+
+// previewTestTargets são targets válidos para os providers que não aceitam "default".
+var previewTestTargets = map[string]string{
+	"telegram": "42",
+	"whatsapp": "5511999999999",
+	"waha":     "5511999999999@c.us",
+	"webhook":  "https://exemplo.com/hook",
+	"ntfy":     "alertas",
+	"matrix":   "!sala:exemplo.com",
+	"signal":   "+5511999999999",
+	"sms":      "+5511999999999",
+}
@@ -1,5 +1,11 @@
 package providers
 
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
 // Provider define o contrato para provedores de envio de mensagens.
 type Provider interface {
 	// Name retorna o nome do provider (ex: "telegram", "email").
@@ -9,3 +15,55 @@ type Provider interface {
 	// Retorna erro se a operação falhar.
 	Send(target string, message string) error
 }
+
+// SendOptions contém as opções de envio além de target e mensagem.
+type SendOptions struct {
+	Subject     string   // Assunto (--subject)
+	Attachments []string // Arquivos anexos (--attachment)
+
+	// Values contém as flags específicas do provider (ver SendFlag), indexadas pelo nome.
+	Values map[string][]string
+}
+
+// Get retorna o último valor da flag informada, ou "" se ausente.
+func (o SendOptions) Get(name string) string {
+	values := o.Values[name]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// GetAll retorna todos os valores da flag informada (flags repetíveis).
+func (o SendOptions) GetAll(name string) []string {
+	return o.Values[name]
+}
+
+// readValueOrFile retorna o conteúdo de um valor de flag que pode ser inline
+// ou referenciar um arquivo com o prefixo @ (ex: --blocks @blocos.json).
+func readValueOrFile(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "@") {
+		return value, nil
+	}
+	data, err := os.ReadFile(value[1:])
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler arquivo %s: %w", value[1:], err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// OptionsProvider é implementado por providers que aceitam opções de envio
+// (anexos, flags específicas, etc).
+type OptionsProvider interface {
+	Provider
+	SendWithOptions(target string, message string, opts SendOptions) error
+}
+
+// IncidentProvider é implementado por providers que abrem incidentes (PagerDuty, Opsgenie).
+// A chave é a dedup_key (PagerDuty) ou o alias (Opsgenie) usado na abertura.
+type IncidentProvider interface {
+	Provider
+	Acknowledge(target string, key string, note string) error
+	Resolve(target string, key string, note string) error
+}
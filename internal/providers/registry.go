@@ -0,0 +1,284 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// Capabilities descreve os recursos opcionais suportados por um provider.
+type Capabilities struct {
+	MultipleTargets bool // Aceita múltiplos targets separados por vírgula ou ponto-e-vírgula
+	Subject         bool // Aceita assunto (--subject)
+	Attachments     bool // Aceita anexos (--attachment)
+	WaitForResponse bool // Suporta aguardar resposta (--wfr)
+	HTTP            bool // Envia por HTTP (permite exibir a requisição com "cast preview")
+}
+
+// FieldKind indica o tipo de um campo de configuração.
+type FieldKind int
+
+const (
+	FieldString FieldKind = iota
+	FieldInt
+	FieldBool
+)
+
+// ConfigField descreve um campo da seção de configuração de um provider.
+// O schema é usado para gerar flags, wizards, "gateway show" e "config sources".
+type ConfigField struct {
+	Key      string    // Chave no cast.yaml (ex: "webhook_url")
+	Flag     string    // Flag de linha de comando (ex: "webhook-url"). Vazio = sem flag
+	Label    string    // Nome curto exibido em wizards e show
+	Usage    string    // Descrição da flag no help (opcional; vazio = Label)
+	Kind     FieldKind // Tipo do campo (string, int, bool)
+	Required bool      // Obrigatório para o provider funcionar
+	Secret   bool      // Mascarado em "gateway show" e "config sources"
+	Default  string    // Valor padrão aplicado no add e sugerido no wizard
+
+	// Validate valida o valor informado pelo usuário (opcional).
+	Validate func(value string) error
+}
+
+// FlagUsage retorna a descrição da flag do campo exibida no help.
+func (f ConfigField) FlagUsage() string {
+	if f.Usage != "" {
+		return f.Usage
+	}
+	return f.Label
+}
+
+// SendFlag descreve uma flag específica de um provider no comando send
+// (ex: --blocks do Slack). O valor chega ao provider via SendOptions.
+type SendFlag struct {
+	Name       string // Nome da flag (sem --)
+	Usage      string // Descrição exibida no help
+	Repeatable bool   // Pode ser usada múltiplas vezes
+	Bool       bool   // Flag booleana (sem valor); repassada como "true"
+}
+
+// sharedSendFlags são as flags do send aceitas por mais de um provider. Cada uma é
+// definida uma única vez aqui e referenciada pelos providers com sharedSendFlag.
+var sharedSendFlags = map[string]SendFlag{
+	"priority":         {Name: "priority", Usage: "Prioridade: min, low, default, high, urgent, número (ntfy 1-5, Gotify 0-10) ou P1-P5 (Opsgenie); no email vira X-Priority e Importance"},
+	"markdown":         {Name: "markdown", Usage: "Renderiza a mensagem como Markdown (ntfy, Gotify; no email é convertida em HTML)", Bool: true},
+	"html":             {Name: "html", Usage: "A mensagem é HTML (email: enviada com alternativa em texto plano; Matrix: formatted_body com texto plano gerado)", Bool: true},
+	"tags":             {Name: "tags", Usage: "Tags/emojis separados por vírgula, ex: warning,skull (ntfy, Opsgenie; pode ser repetido)", Repeatable: true},
+	"click":            {Name: "click", Usage: "URL aberta ao tocar na notificação (ntfy, Gotify)"},
+	"event":            {Name: "event", Usage: "Evento do incidente: trigger (padrão), acknowledge ou resolve (PagerDuty, Opsgenie)"},
+	"dedup-key":        {Name: "dedup-key", Usage: "Chave de deduplicação usada para atualizar e resolver o incidente (PagerDuty dedup_key, Opsgenie alias)"},
+	"detail":           {Name: "detail", Usage: "Detalhe do incidente chave=valor, objeto JSON ou @arquivo.json (PagerDuty, Opsgenie; pode ser repetido)", Repeatable: true},
+	"severity":         {Name: "severity", Usage: "Severidade: critical, error, warning ou info (PagerDuty); emerg, alert, crit, err, warning, notice, info ou debug (syslog, journald)"},
+	"facility":         {Name: "facility", Usage: "Facility: kern, user, mail, daemon, auth, local0..local7... (syslog, journald)"},
+	"meta":             {Name: "meta", Usage: "Metadado chave=valor (envelope JSON no MQTT, NATS e file; structured data no syslog; campo no journald; pode ser repetido)", Repeatable: true},
+	"raw":              {Name: "raw", Usage: "Publica apenas a mensagem, sem o envelope JSON (MQTT, NATS, file)", Bool: true},
+	"username":         {Name: "username", Usage: "Sobrescreve o nome exibido (Discord, Mattermost, Rocket.Chat)"},
+	"avatar":           {Name: "avatar", Usage: "Sobrescreve a URL do avatar (Discord, Mattermost, Rocket.Chat)"},
+	"attachments-json": {Name: "attachments-json", Usage: "Message attachments em JSON ou @arquivo.json (Mattermost, Rocket.Chat)"},
+}
+
+// sharedSendFlag retorna a flag compartilhada com o nome informado.
+func sharedSendFlag(name string) SendFlag {
+	f, ok := sharedSendFlags[name]
+	if !ok {
+		panic(fmt.Sprintf("providers: flag compartilhada '%s' não definida", name))
+	}
+	return f
+}
+
+// Registration descreve um provider registrado no CAST. Comandos, flags, wizards, help de
+// flags, "gateway show", "config sources" e URLs de provider são gerados a partir dela.
+//
+// A seção do cast.yaml ainda é declarada à mão no pacote config. Um provider novo também
+// precisa de:
+//   - struct da seção e campo em config.Config (a tag mapstructure é o Name);
+//   - viper.BindEnv e applyEnvOverrides para cada chave (variáveis CAST_<SEÇÃO>_<CHAVE>);
+//   - applyDefaults e Validate para os padrões e limites usados fora do "gateway add";
+//   - mergeSection em MergeConfig (config import --merge);
+//   - exemplos em help.go e README.md (opcional).
+type Registration struct {
+	// Name é o nome canônico, igual à seção do cast.yaml (ex: "telegram").
+	Name string
+	// ShortName é o nome gravado em aliases (ex: "tg"). Se vazio, usa Name.
+	ShortName string
+	// Aliases são nomes alternativos aceitos na linha de comando (ex: "tg").
+	Aliases []string
+	// DisplayName é o nome amigável (ex: "Telegram").
+	DisplayName string
+	// Order define a posição do provider em listagens e help (menor primeiro).
+	// Providers com mesmo Order mantêm a ordem de registro.
+	Order int
+	// TargetHint descreve o formato do target (ex: "chat_id").
+	TargetHint string
+	// Fields é o schema da seção de configuração.
+	Fields []ConfigField
+	// Capabilities descreve os recursos opcionais suportados.
+	Capabilities Capabilities
+	// SendFlags são as flags específicas do provider no comando send.
+	SendFlags []SendFlag
+	// Validate verifica se a configuração mínima está presente.
+	Validate func(conf *config.Config) error
+	// Configured indica se a seção do provider está configurada.
+	Configured func(conf *config.Config) bool
+	// New instancia o provider. A configuração já foi validada.
+	New func(conf *config.Config, verbose bool) (Provider, error)
+	// Test testa conectividade do gateway (opcional).
+	Test func(conf *config.Config, target string) error
+	// URLSchemes são os esquemas aceitos em "cast send url" (ex: "tgram"). Opcional.
+	URLSchemes []string
+	// FromURL preenche a seção do provider em conf a partir da URL e retorna o target.
+	// Os parâmetros de query tratados devem ser removidos de u.Query; os restantes
+	// são aplicados aos campos do schema com a mesma chave (ex: ?timeout=60).
+	FromURL func(u *ProviderURL, conf *config.Config) (string, error)
+}
+
+// Short retorna o nome curto do provider usado em aliases.
+func (r *Registration) Short() string {
+	if r.ShortName != "" {
+		return r.ShortName
+	}
+	return r.Name
+}
+
+// Field retorna o campo de configuração com a chave informada.
+func (r *Registration) Field(key string) (ConfigField, bool) {
+	for _, f := range r.Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return ConfigField{}, false
+}
+
+var (
+	registry      = map[string]*Registration{}
+	registryOrder []string
+	urlSchemes    = map[string]*Registration{}
+)
+
+// ValidateTimeout valida timeouts em segundos (mínimo 5, máximo 300).
+func ValidateTimeout(value string) error {
+	if value == "" {
+		return nil
+	}
+	timeout, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("timeout deve ser um número")
+	}
+	if timeout < 5 || timeout > 300 {
+		return fmt.Errorf("timeout deve estar entre 5 e 300 segundos")
+	}
+	return nil
+}
+
+// ValidateHTTPURL valida URLs que devem começar com http:// ou https://.
+func ValidateHTTPURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+		return fmt.Errorf("URL deve começar com http:// ou https://")
+	}
+	return nil
+}
+
+// timeoutField retorna o campo padrão de timeout, comum a todos os providers.
+func timeoutField() ConfigField {
+	return ConfigField{
+		Key:      "timeout",
+		Flag:     "timeout",
+		Label:    "Timeout em segundos",
+		Kind:     FieldInt,
+		Default:  "30",
+		Validate: ValidateTimeout,
+	}
+}
+
+// Register registra um provider. Deve ser chamado em init() do arquivo do provider.
+// Entra em pânico se o nome ou algum alias já estiver registrado.
+func Register(r *Registration) {
+	if r == nil || r.Name == "" || r.New == nil {
+		panic("providers: registro inválido")
+	}
+	names := append([]string{r.Name, r.Short()}, r.Aliases...)
+	for _, n := range names {
+		key := strings.ToLower(n)
+		if existing, ok := registry[key]; ok && existing != r {
+			panic(fmt.Sprintf("providers: nome '%s' já registrado por '%s'", n, existing.Name))
+		}
+		registry[key] = r
+	}
+	for _, scheme := range r.URLSchemes {
+		key := strings.ToLower(scheme)
+		if existing, ok := urlSchemes[key]; ok {
+			panic(fmt.Sprintf("providers: esquema '%s' já registrado por '%s'", scheme, existing.Name))
+		}
+		urlSchemes[key] = r
+	}
+	registryOrder = append(registryOrder, r.Name)
+}
+
+// Lookup retorna o registro do provider pelo nome canônico, nome curto ou alias.
+func Lookup(name string) (*Registration, bool) {
+	r, ok := registry[strings.ToLower(name)]
+	return r, ok
+}
+
+// Registered retorna todos os providers registrados, ordenados por Order.
+func Registered() []*Registration {
+	list := make([]*Registration, 0, len(registryOrder))
+	for _, name := range registryOrder {
+		list = append(list, registry[name])
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Order < list[j].Order
+	})
+	return list
+}
+
+// Names retorna os nomes canônicos dos providers registrados.
+func Names() []string {
+	names := make([]string, 0, len(registryOrder))
+	for _, r := range Registered() {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// ShortNames retorna os nomes curtos dos providers registrados (ex: tg, mail, zap).
+func ShortNames() []string {
+	names := make([]string, 0, len(registryOrder))
+	for _, r := range Registered() {
+		names = append(names, r.Short())
+	}
+	return names
+}
+
+// AllNames retorna todos os nomes aceitos (canônicos, curtos e aliases), ordenados.
+func AllNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NormalizeName retorna o nome canônico do provider, ou "" se desconhecido.
+func NormalizeName(name string) string {
+	if r, ok := Lookup(name); ok {
+		return r.Name
+	}
+	return ""
+}
+
+// NormalizeShortName retorna o nome curto do provider (usado em aliases), ou "" se desconhecido.
+func NormalizeShortName(name string) string {
+	if r, ok := Lookup(name); ok {
+		return r.Short()
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestLookup_NamesAndAliases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"tg", "telegram"},
+		{"TELEGRAM", "telegram"},
+		{"mail", "email"},
+		{"zap", "whatsapp"},
+		{"googlechat", "google_chat"},
+		{"google_chat", "google_chat"},
+		{"waha", "waha"},
+	}
+
+	for _, tt := range tests {
+		reg, ok := Lookup(tt.input)
+		if !ok {
+			t.Errorf("Lookup(%s) deveria encontrar o provider", tt.input)
+			continue
+		}
+		if reg.Name != tt.expected {
+			t.Errorf("Lookup(%s) = %s, esperado %s", tt.input, reg.Name, tt.expected)
+		}
+	}
+
+	if _, ok := Lookup("inexistente"); ok {
+		t.Error("Lookup de provider inexistente deveria falhar")
+	}
+}
+
+func TestNormalizeShortName(t *testing.T) {
+	tests := map[string]string{
+		"telegram":   "tg",
+		"email":      "mail",
+		"whatsapp":   "zap",
+		"googlechat": "google_chat",
+		"waha":       "waha",
+		"invalido":   "",
+	}
+
+	for input, expected := range tests {
+		if got := NormalizeShortName(input); got != expected {
+			t.Errorf("NormalizeShortName(%s) = %q, esperado %q", input, got, expected)
+		}
+	}
+}
+
+func TestShortNames_Builtins(t *testing.T) {
+	names := map[string]bool{}
+	for _, n := range ShortNames() {
+		names[n] = true
+	}
+	for _, expected := range []string{"tg", "mail", "zap", "google_chat", "waha"} {
+		if !names[expected] {
+			t.Errorf("ShortNames() deveria conter '%s'", expected)
+		}
+	}
+}
+
+func TestRegister_DuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Register deveria entrar em pânico com nome duplicado")
+		}
+	}()
+
+	Register(&Registration{
+		Name:    "telegram_dup",
+		Aliases: []string{"tg"},
+		New: func(*config.Config, bool) (Provider, error) {
+			return nil, nil
+		},
+	})
+}
+
+func TestRegistration_FieldsValidate(t *testing.T) {
+	for _, reg := range Registered() {
+		if reg.DisplayName == "" {
+			t.Errorf("Provider %s sem DisplayName", reg.Name)
+		}
+		field, ok := reg.Field("timeout")
+		if !ok {
+			t.Errorf("Provider %s deveria ter o campo timeout", reg.Name)
+			continue
+		}
+		if err := field.Validate("2"); err == nil {
+			t.Errorf("Provider %s: timeout 2 deveria ser inválido", reg.Name)
+		}
+		if err := field.Validate("30"); err != nil {
+			t.Errorf("Provider %s: timeout 30 deveria ser válido: %v", reg.Name, err)
+		}
+	}
+}
+
+func TestRegistration_SharedSendFlags(t *testing.T) {
+	declared := map[string]string{}
+	for _, reg := range Registered() {
+		for _, f := range reg.SendFlags {
+			owner, seen := declared[f.Name]
+			if !seen {
+				declared[f.Name] = reg.Name
+				continue
+			}
+			if f != sharedSendFlags[f.Name] {
+				t.Errorf("Flag --%s usada por %s e %s deveria vir de sharedSendFlag", f.Name, owner, reg.Name)
+			}
+		}
+	}
+}
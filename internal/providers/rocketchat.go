@@ -0,0 +1,442 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "rocketchat",
+		Aliases:     []string{"rocket"},
+		DisplayName: "Rocket.Chat",
+		Order:       140,
+		TargetHint:  "#canal, @usuario, ID da sala, webhook_url ou 'default'",
+		Fields: []ConfigField{
+			{Key: "webhook_url", Flag: "webhook-url", Label: "Webhook URL", Secret: true, Validate: ValidateHTTPURL},
+			{Key: "server_url", Flag: "server-url", Label: "URL do servidor", Validate: ValidateHTTPURL},
+			{Key: "user_id", Flag: "user-id", Label: "User ID"},
+			{Key: "auth_token", Flag: "auth-token", Label: "Auth Token (Personal Access Token)", Secret: true},
+			{Key: "default_channel", Flag: "default-channel", Label: "Default Channel (#canal ou @usuario)"},
+			{Key: "username", Flag: "username", Label: "Username (alias)"},
+			{Key: "avatar_url", Flag: "avatar-url", Label: "Avatar URL", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("username"),
+			sharedSendFlag("avatar"),
+			sharedSendFlag("attachments-json"),
+		},
+		Validate: func(conf *config.Config) error {
+			rc := conf.RocketChat
+			if rc.WebhookURL == "" && (rc.ServerURL == "" || rc.UserID == "" || rc.AuthToken == "") {
+				return fmt.Errorf("configuração do Rocket.Chat incompleta: webhook_url ou server_url + user_id + auth_token é obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			rc := conf.RocketChat
+			return rc.WebhookURL != "" || (rc.ServerURL != "" && rc.UserID != "" && rc.AuthToken != "")
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewRocketChatProvider(&conf.RocketChat), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testRocketChatConnection(&conf.RocketChat, target)
+		},
+	})
+}
+
+// rocketChatRoomIDPattern reconhece IDs de sala do Rocket.Chat (17+ caracteres alfanuméricos).
+var rocketChatRoomIDPattern = regexp.MustCompile(`^[A-Za-z0-9]{17,}$`)
+
+// rocketChatResponse representa a resposta padrão da REST API do Rocket.Chat.
+type rocketChatResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Message struct {
+		RoomID string `json:"rid"`
+	} `json:"message"`
+	Room struct {
+		ID string `json:"_id"`
+	} `json:"room"`
+	File struct {
+		ID string `json:"_id"`
+	} `json:"file"`
+}
+
+// rocketChatProvider implementa o Provider para Rocket.Chat (Incoming Webhooks e REST API).
+type rocketChatProvider struct {
+	config *config.RocketChatConfig
+	client *http.Client
+}
+
+// NewRocketChatProvider cria uma nova instância do RocketChatProvider.
+func NewRocketChatProvider(cfg *config.RocketChatConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &rocketChatProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *rocketChatProvider) Name() string {
+	return "rocketchat"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *rocketChatProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem de texto via Rocket.Chat.
+func (p *rocketChatProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem via Rocket.Chat com attachments e arquivos.
+// Lógica de Target:
+// - URL completa (https://...): envia via Incoming Webhook
+// - "default", "me" ou vazio: usa default_channel (REST) ou webhook_url
+// - #canal, @usuario ou ID da sala: via chat.postMessage (requer user_id e auth_token)
+// - Sem credenciais, o target é repassado como override de canal do webhook_url
+// - Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula
+func (p *rocketChatProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	attachments, err := parseMessageAttachments(opts.Get("attachments-json"))
+	if err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		if err := p.sendOne(t, message, attachments, opts); err != nil {
+			return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", t, i+1, len(targets), err)
+		}
+	}
+	return nil
+}
+
+// sendOne decide entre webhook e REST para um único target.
+func (p *rocketChatProvider) sendOne(target string, message string, attachments json.RawMessage, opts SendOptions) error {
+	hasAuth := p.config.ServerURL != "" && p.config.UserID != "" && p.config.AuthToken != ""
+
+	switch {
+	case strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://"):
+		return p.sendToWebhook(target, "", message, attachments, opts)
+	case target == "default" || target == "me" || target == "":
+		if hasAuth && p.config.DefaultChannel != "" {
+			return p.postMessage(p.config.DefaultChannel, message, attachments, opts)
+		}
+		if p.config.WebhookURL != "" {
+			return p.sendToWebhook(p.config.WebhookURL, "", message, attachments, opts)
+		}
+		return fmt.Errorf("target '%s' requer webhook_url ou default_channel configurado", target)
+	case hasAuth:
+		return p.postMessage(target, message, attachments, opts)
+	case p.config.WebhookURL != "":
+		return p.sendToWebhook(p.config.WebhookURL, normalizeRocketChatChannel(target), message, attachments, opts)
+	}
+	return fmt.Errorf("envio para '%s' requer server_url, user_id e auth_token configurados", target)
+}
+
+// normalizeRocketChatChannel adiciona "#" a nomes de canal sem prefixo.
+func normalizeRocketChatChannel(channel string) string {
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		return channel
+	}
+	return "#" + channel
+}
+
+// buildPayload monta o payload comum a webhooks e chat.postMessage.
+// O Rocket.Chat chama o nome exibido de "alias" e o ícone de "avatar".
+func (p *rocketChatProvider) buildPayload(message string, attachments json.RawMessage, opts SendOptions) map[string]interface{} {
+	payload := map[string]interface{}{"text": message}
+
+	alias, avatar := p.config.Username, p.config.AvatarURL
+	if v := opts.Get("username"); v != "" {
+		alias = v
+	}
+	if v := opts.Get("avatar"); v != "" {
+		avatar = v
+	}
+	if alias != "" {
+		payload["alias"] = alias
+	}
+	if avatar != "" {
+		payload["avatar"] = avatar
+	}
+	if attachments != nil {
+		payload["attachments"] = attachments
+	}
+	return payload
+}
+
+// sendToWebhook envia mensagem para um Incoming Webhook.
+func (p *rocketChatProvider) sendToWebhook(webhookURL string, channel string, message string, attachments json.RawMessage, opts SendOptions) error {
+	if len(opts.Attachments) > 0 {
+		return fmt.Errorf("upload de arquivos requer server_url, user_id e auth_token (Incoming Webhooks não suportam arquivos)")
+	}
+
+	payload := p.buildPayload(message, attachments, opts)
+	if channel != "" {
+		payload["channel"] = channel
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rocketChatResponse
+	if resp.StatusCode != http.StatusOK || (json.Unmarshal(body, &result) == nil && !result.Success) {
+		return rocketChatError("webhook", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// postMessage envia a mensagem via chat.postMessage e, se houver, os arquivos para a mesma sala.
+func (p *rocketChatProvider) postMessage(target string, message string, attachments json.RawMessage, opts SendOptions) error {
+	roomID := ""
+	if message != "" || attachments != nil || len(opts.Attachments) == 0 {
+		payload := p.buildPayload(message, attachments, opts)
+		if rocketChatRoomIDPattern.MatchString(target) {
+			payload["roomId"] = target
+		} else {
+			payload["channel"] = normalizeRocketChatChannel(target)
+		}
+
+		result, err := p.callJSON("POST", "chat.postMessage", payload)
+		if err != nil {
+			return err
+		}
+		roomID = result.Message.RoomID
+	}
+
+	if len(opts.Attachments) == 0 {
+		return nil
+	}
+	if roomID == "" {
+		var err error
+		if roomID, err = p.resolveRoomID(target); err != nil {
+			return err
+		}
+	}
+	for _, file := range opts.Attachments {
+		if err := p.uploadFile(roomID, file); err != nil {
+			return fmt.Errorf("erro ao enviar arquivo %s: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// resolveRoomID obtém o ID da sala: rooms.info para #canal, im.create para @usuario.
+func (p *rocketChatProvider) resolveRoomID(target string) (string, error) {
+	if rocketChatRoomIDPattern.MatchString(target) {
+		return target, nil
+	}
+
+	target = normalizeRocketChatChannel(target)
+	var result *rocketChatResponse
+	var err error
+	if strings.HasPrefix(target, "@") {
+		result, err = p.callJSON("POST", "im.create", map[string]string{"username": target[1:]})
+	} else {
+		result, err = p.callJSON("GET", "rooms.info?roomName="+url.QueryEscape(target[1:]), nil)
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao resolver sala '%s': %w", target, err)
+	}
+	if result.Room.ID == "" {
+		return "", fmt.Errorf("sala '%s' não encontrada", target)
+	}
+	return result.Room.ID, nil
+}
+
+// uploadFile envia um arquivo com rooms.media + rooms.mediaConfirm (Rocket.Chat 6.8+),
+// recorrendo ao rooms.upload em servidores antigos.
+func (p *rocketChatProvider) uploadFile(roomID string, path string) error {
+	req, err := p.newUploadRequest("rooms.media/"+roomID, path)
+	if err != nil {
+		return err
+	}
+	result, status, err := p.do("rooms.media", req)
+	if status == http.StatusNotFound {
+		legacy, err := p.newUploadRequest("rooms.upload/"+roomID, path)
+		if err != nil {
+			return err
+		}
+		_, _, err = p.do("rooms.upload", legacy)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = p.callJSON("POST", "rooms.mediaConfirm/"+roomID+"/"+result.File.ID, map[string]string{})
+	return err
+}
+
+// newUploadRequest monta a requisição multipart com o campo "file".
+func (p *rocketChatProvider) newUploadRequest(method string, path string) (*http.Request, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+	part.Write(data)
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao montar multipart: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", p.methodURL(method), &buf)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição de upload: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req, nil
+}
+
+// callJSON chama um método da REST API com corpo JSON (ou sem corpo, se payload for nil).
+func (p *rocketChatProvider) callJSON(httpMethod string, method string, payload interface{}) (*rocketChatResponse, error) {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao serializar payload: %w", err)
+		}
+		body = bytes.NewBuffer(jsonData)
+	}
+	req, err := http.NewRequest(httpMethod, p.methodURL(method), body)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	name, _, _ := strings.Cut(method, "?")
+	result, _, err := p.do(name, req)
+	return result, err
+}
+
+// do executa a requisição autenticada (X-User-Id/X-Auth-Token) e interpreta {"success": ..., "error": ...}.
+func (p *rocketChatProvider) do(method string, req *http.Request) (*rocketChatResponse, int, error) {
+	req.Header.Set("X-User-Id", p.config.UserID)
+	req.Header.Set("X-Auth-Token", p.config.AuthToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rocketChatResponse
+	if resp.StatusCode != http.StatusOK || json.Unmarshal(body, &result) != nil || !result.Success {
+		return nil, resp.StatusCode, rocketChatError(method, resp.StatusCode, body)
+	}
+	return &result, resp.StatusCode, nil
+}
+
+// methodURL retorna a URL de um método da REST API v1.
+func (p *rocketChatProvider) methodURL(method string) string {
+	return strings.TrimRight(p.config.ServerURL, "/") + "/api/v1/" + method
+}
+
+// rocketChatError converte erros da API ({"success":false,"error":"..."} ou
+// {"status":"error","message":"..."}) em mensagens amigáveis.
+func rocketChatError(method string, statusCode int, body []byte) error {
+	var apiErr struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &apiErr) == nil {
+		if apiErr.Error != "" {
+			message = apiErr.Error
+		} else if apiErr.Message != "" {
+			message = apiErr.Message
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return fmt.Errorf("Rocket.Chat %s: credenciais inválidas (verifique user_id e auth_token): %s", method, message)
+	case statusCode == http.StatusForbidden:
+		return fmt.Errorf("Rocket.Chat %s: permissão negada: %s", method, message)
+	case strings.Contains(message, "error-room-not-found"), strings.Contains(message, "error-invalid-channel"):
+		return fmt.Errorf("Rocket.Chat %s: sala não encontrada (%s)", method, message)
+	}
+	return fmt.Errorf("Rocket.Chat %s retornou status %d: %s", method, statusCode, message)
+}
+
+// testRocketChatConnection valida as credenciais via GET /api/v1/me. Webhooks não
+// possuem endpoint de verificação, então só a URL é validada; a mensagem de teste é
+// enviada apenas se target for informado.
+func testRocketChatConnection(cfg *config.RocketChatConfig, target string) error {
+	p := NewRocketChatProvider(cfg).(*rocketChatProvider)
+
+	if cfg.ServerURL != "" && cfg.UserID != "" && cfg.AuthToken != "" {
+		req, err := http.NewRequest("GET", p.methodURL("me"), nil)
+		if err != nil {
+			return fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		if _, _, err := p.do("me", req); err != nil {
+			return err
+		}
+	}
+
+	if cfg.WebhookURL != "" {
+		if err := ValidateHTTPURL(cfg.WebhookURL); err != nil {
+			return fmt.Errorf("webhook_url inválida: %w", err)
+		}
+	}
+
+	if target == "" {
+		return nil
+	}
+	return p.Send(target, "Mensagem de teste enviada por 'cast gateway test rocketchat'.")
+}
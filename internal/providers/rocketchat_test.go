@@ -0,0 +1,213 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestRocketChatProvider_Name(t *testing.T) {
+	provider := NewRocketChatProvider(&config.RocketChatConfig{})
+	if provider.Name() != "rocketchat" {
+		t.Errorf("Esperado 'rocketchat', obtido '%s'", provider.Name())
+	}
+}
+
+func TestRocketChatProvider_Send_Webhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload["text"] != "Backup concluído" || payload["alias"] != "cast" {
+			t.Errorf("Payload inesperado: %v", payload)
+		}
+		if _, ok := payload["channel"]; ok {
+			t.Errorf("Target 'default' não deveria sobrescrever o canal: %v", payload)
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	provider := NewRocketChatProvider(&config.RocketChatConfig{WebhookURL: server.URL + "/hooks/abc/def", Username: "cast"})
+	if err := provider.Send("default", "Backup concluído"); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestRocketChatProvider_Send_PostMessage(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-User-Id") != "uid123" || r.Header.Get("X-Auth-Token") != "tok123" {
+			t.Errorf("Headers de autenticação inesperados: %s / %s", r.Header.Get("X-User-Id"), r.Header.Get("X-Auth-Token"))
+		}
+		if r.URL.Path != "/api/v1/chat.postMessage" {
+			t.Errorf("Path inesperado: %s", r.URL.Path)
+		}
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		w.Write([]byte(`{"success":true,"message":{"rid":"GENERAL"}}`))
+	}))
+	defer server.Close()
+
+	provider := NewRocketChatProvider(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid123", AuthToken: "tok123"}).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{
+		"username":         {"deploy-bot"},
+		"avatar":           {"https://exemplo.com/bot.png"},
+		"attachments-json": {`[{"title":"Build #42","color":"danger"}]`},
+	}}
+	if err := provider.SendWithOptions("ops,@joao,Xy7kP2mQr9sT4vW8z", "Deploy", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+
+	if len(payloads) != 3 {
+		t.Fatalf("Esperado 3 envios, obtido %d", len(payloads))
+	}
+	if payloads[0]["channel"] != "#ops" || payloads[1]["channel"] != "@joao" {
+		t.Errorf("Canais inesperados: %v / %v", payloads[0]["channel"], payloads[1]["channel"])
+	}
+	if payloads[2]["roomId"] != "Xy7kP2mQr9sT4vW8z" {
+		t.Errorf("Esperado roomId para ID de sala, obtido %v", payloads[2])
+	}
+	if payloads[0]["alias"] != "deploy-bot" || payloads[0]["avatar"] != "https://exemplo.com/bot.png" {
+		t.Errorf("Overrides inesperados: %v", payloads[0])
+	}
+	if payloads[0]["attachments"] == nil {
+		t.Errorf("Attachments ausentes: %v", payloads[0])
+	}
+}
+
+func TestRocketChatProvider_Send_FileOnly(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "grafico.png")
+	if err := os.WriteFile(filePath, []byte("png"), 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/rooms.info":
+			if r.URL.Query().Get("roomName") != "ops" {
+				t.Errorf("roomName inesperado: %s", r.URL.Query().Get("roomName"))
+			}
+			w.Write([]byte(`{"success":true,"room":{"_id":"ROOM123"}}`))
+		case "/api/v1/rooms.media/ROOM123":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("Campo file ausente: %v", err)
+			}
+			data, _ := io.ReadAll(file)
+			if header.Filename != "grafico.png" || string(data) != "png" {
+				t.Errorf("Arquivo inesperado: %s %q", header.Filename, string(data))
+			}
+			w.Write([]byte(`{"success":true,"file":{"_id":"FILE1","url":"/file-upload/FILE1/grafico.png"}}`))
+		case "/api/v1/rooms.mediaConfirm/ROOM123/FILE1":
+			w.Write([]byte(`{"success":true,"message":{"rid":"ROOM123"}}`))
+		default:
+			t.Errorf("Requisição inesperada: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewRocketChatProvider(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid", AuthToken: "tok", DefaultChannel: "#ops"}).(OptionsProvider)
+	if err := provider.SendWithOptions("default", "", SendOptions{Attachments: []string{filePath}}); err != nil {
+		t.Fatalf("Erro ao enviar arquivo: %v", err)
+	}
+
+	expected := "GET /api/v1/rooms.info,POST /api/v1/rooms.media/ROOM123,POST /api/v1/rooms.mediaConfirm/ROOM123/FILE1"
+	if strings.Join(calls, ",") != expected {
+		t.Errorf("Sequência inesperada:\n%s\nesperado:\n%s", strings.Join(calls, ","), expected)
+	}
+}
+
+func TestRocketChatProvider_Send_LegacyUpload(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "log.txt")
+	os.WriteFile(filePath, []byte("log"), 0644)
+
+	legacy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/chat.postMessage":
+			w.Write([]byte(`{"success":true,"message":{"rid":"ROOM123"}}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v1/rooms.media/"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"error":"Not found"}`))
+		case r.URL.Path == "/api/v1/rooms.upload/ROOM123":
+			legacy = true
+			w.Write([]byte(`{"success":true}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := NewRocketChatProvider(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid", AuthToken: "tok"}).(OptionsProvider)
+	if err := provider.SendWithOptions("#ops", "Log em anexo", SendOptions{Attachments: []string{filePath}}); err != nil {
+		t.Fatalf("Erro ao enviar arquivo: %v", err)
+	}
+	if !legacy {
+		t.Error("Esperado fallback para rooms.upload em servidores antigos")
+	}
+}
+
+func TestRocketChatProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") == "expirado" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":"error","message":"You must be logged in to do this."}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success":false,"error":"error-room-not-found"}`))
+	}))
+	defer server.Close()
+
+	expired := NewRocketChatProvider(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid", AuthToken: "expirado"})
+	err := expired.Send("#ops", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "credenciais inválidas") {
+		t.Errorf("Esperado erro de credenciais, obtido: %v", err)
+	}
+
+	provider := NewRocketChatProvider(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid", AuthToken: "tok"})
+	err = provider.Send("#inexistente", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "sala não encontrada") {
+		t.Errorf("Esperado erro de sala não encontrada, obtido: %v", err)
+	}
+
+	unconfigured := NewRocketChatProvider(&config.RocketChatConfig{})
+	err = unconfigured.Send("#ops", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "requer server_url") {
+		t.Errorf("Esperado erro de configuração, obtido: %v", err)
+	}
+}
+
+func TestRocketChatConnection_Me(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/me" {
+			t.Errorf("Teste sem target não deveria publicar (path %s)", r.URL.Path)
+		}
+		if r.Header.Get("X-Auth-Token") != "tok_valido" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":"error","message":"You must be logged in to do this."}`))
+			return
+		}
+		w.Write([]byte(`{"_id":"uid","username":"cast-bot","success":true}`))
+	}))
+	defer server.Close()
+
+	if err := testRocketChatConnection(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid", AuthToken: "tok_valido"}, ""); err != nil {
+		t.Errorf("Credenciais válidas não deveriam retornar erro: %v", err)
+	}
+	if err := testRocketChatConnection(&config.RocketChatConfig{ServerURL: server.URL, UserID: "uid", AuthToken: "tok_invalido"}, ""); err == nil {
+		t.Error("Credenciais inválidas deveriam retornar erro")
+	}
+}
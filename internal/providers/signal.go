@@ -0,0 +1,344 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "signal",
+		DisplayName: "Signal",
+		Order:       160,
+		TargetHint:  "número E.164, group.ID, group:Nome ou 'default'",
+		Fields: []ConfigField{
+			{Key: "api_url", Flag: "api-url", Label: "URL da signal-cli-rest-api", Required: true, Validate: ValidateHTTPURL},
+			{Key: "number", Flag: "number", Label: "Número da conta (E.164)", Required: true, Validate: validateE164},
+			{Key: "default_recipient", Flag: "default-recipient", Label: "Destinatário padrão (número ou group.ID)"},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "styled", Usage: "Interpreta *negrito*, _itálico_, ~tachado~ e `mono` (Signal)", Bool: true},
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Signal.APIURL != "" && conf.Signal.Number != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewSignalProvider(&conf.Signal), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testSignalConnection(&conf.Signal, target)
+		},
+	})
+}
+
+// signalGroupPrefix é o prefixo dos IDs de grupo na signal-cli-rest-api.
+const signalGroupPrefix = "group."
+
+// SignalGroup representa um grupo retornado por GET /v1/groups/{number}.
+type SignalGroup struct {
+	Name       string   `json:"name"`
+	ID         string   `json:"id"` // group.XXXX, usado como destinatário
+	InternalID string   `json:"internal_id"`
+	Members    []string `json:"members"`
+}
+
+// signalProvider implementa o Provider para Signal via signal-cli-rest-api.
+type signalProvider struct {
+	config *config.SignalConfig
+	client *http.Client
+	groups []SignalGroup // cache para resolução de group:Nome
+}
+
+// NewSignalProvider cria uma nova instância do SignalProvider.
+func NewSignalProvider(cfg *config.SignalConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &signalProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *signalProvider) Name() string {
+	return "signal"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *signalProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem via Signal.
+func (p *signalProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem via POST /v2/send.
+// Lógica de Target:
+// - "default", "me" ou vazio: usa default_recipient
+// - Número E.164 (+5511999998888)
+// - group.ID: grupo pelo ID (veja 'cast signal groups')
+// - group:Nome: grupo pelo nome, resolvido via GET /v1/groups/{number}
+// - Múltiplos targets separados por vírgula ou ponto-e-vírgula vão em um único envio
+func (p *signalProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	if p.config.APIURL == "" || p.config.Number == "" {
+		return fmt.Errorf("api_url e number do Signal não configurados")
+	}
+	if message == "" && len(opts.Attachments) == 0 {
+		return fmt.Errorf("mensagem vazia")
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	recipients := make([]string, 0, len(targets))
+	for _, t := range targets {
+		recipient, err := p.resolveRecipient(t)
+		if err != nil {
+			return err
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	payload := map[string]interface{}{
+		"number":     p.config.Number,
+		"recipients": recipients,
+		"message":    message,
+	}
+	if opts.Get("styled") == "true" {
+		payload["text_mode"] = "styled"
+	}
+	if len(opts.Attachments) > 0 {
+		attachments := make([]string, 0, len(opts.Attachments))
+		for _, file := range opts.Attachments {
+			encoded, err := encodeSignalAttachment(file)
+			if err != nil {
+				return err
+			}
+			attachments = append(attachments, encoded)
+		}
+		payload["base64_attachments"] = attachments
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+	req, err := http.NewRequest("POST", p.apiURL("/v2/send"), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return p.do(req, nil)
+}
+
+// resolveRecipient converte um target em número E.164 ou ID de grupo.
+func (p *signalProvider) resolveRecipient(target string) (string, error) {
+	if target == "default" || target == "me" {
+		if p.config.DefaultRecipient == "" {
+			return "", fmt.Errorf("target '%s' requer default_recipient configurado", target)
+		}
+		target = p.config.DefaultRecipient
+	}
+
+	switch {
+	case strings.HasPrefix(target, signalGroupPrefix):
+		return target, nil
+	case strings.HasPrefix(target, "group:"):
+		return p.resolveGroupName(strings.TrimSpace(strings.TrimPrefix(target, "group:")))
+	}
+	return NormalizeE164(target)
+}
+
+// resolveGroupName procura o grupo pelo nome (sem diferenciar maiúsculas).
+func (p *signalProvider) resolveGroupName(name string) (string, error) {
+	if p.groups == nil {
+		groups, err := p.listGroups()
+		if err != nil {
+			return "", fmt.Errorf("erro ao listar grupos: %w", err)
+		}
+		p.groups = groups
+	}
+
+	for _, g := range p.groups {
+		if strings.EqualFold(g.Name, name) {
+			return g.ID, nil
+		}
+	}
+	return "", fmt.Errorf("grupo '%s' não encontrado (use 'cast signal groups' para listar)", name)
+}
+
+// listGroups retorna os grupos da conta configurada.
+func (p *signalProvider) listGroups() ([]SignalGroup, error) {
+	req, err := http.NewRequest("GET", p.apiURL("/v1/groups/"+url.PathEscape(p.config.Number)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	var groups []SignalGroup
+	if err := p.do(req, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// encodeSignalAttachment lê o arquivo e o codifica no formato aceito pela API:
+// data:<mime>;filename=<nome>;base64,<conteúdo>.
+func encodeSignalAttachment(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler anexo %s: %w", path, err)
+	}
+
+	filename := filepath.Base(path)
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	mimetype, _, _ := mime.ParseMediaType(contentType)
+
+	return fmt.Sprintf("data:%s;filename=%s;base64,%s", mimetype, filename, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// do executa a requisição e converte erros da API ({"error": "..."}).
+func (p *signalProvider) do(req *http.Request, out interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar com a signal-cli-rest-api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return signalError(resp.StatusCode, body)
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("erro ao decodificar resposta: %w", err)
+		}
+	}
+	return nil
+}
+
+// apiURL retorna a URL de um endpoint da signal-cli-rest-api.
+func (p *signalProvider) apiURL(path string) string {
+	return strings.TrimRight(p.config.APIURL, "/") + path
+}
+
+// signalError converte erros da API em mensagens amigáveis.
+func signalError(statusCode int, body []byte) error {
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		message = apiErr.Error
+	}
+
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "unregistered user") || strings.Contains(lower, "not registered"):
+		// A mesma mensagem é usada para remetente não vinculado e destinatário sem Signal
+		return fmt.Errorf("número não registrado no Signal (verifique o destinatário ou vincule a conta com 'cast signal link'): %s", message)
+	case strings.Contains(lower, "rate limit") || statusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("limite de envio do Signal atingido: %s", message)
+	}
+	return fmt.Errorf("signal-cli-rest-api retornou status %d: %s", statusCode, message)
+}
+
+// ListSignalGroups lista os grupos da conta configurada (usado por 'cast signal groups').
+func ListSignalGroups(cfg *config.SignalConfig) ([]SignalGroup, error) {
+	if cfg.APIURL == "" || cfg.Number == "" {
+		return nil, fmt.Errorf("api_url e number do Signal não configurados")
+	}
+	return NewSignalProvider(cfg).(*signalProvider).listGroups()
+}
+
+// FetchSignalLinkQRCode obtém o PNG do QR code de vinculação de aparelho
+// (GET /v1/qrcodelink?device_name=...). A conta vinculada passa a enviar como o
+// celular que escaneou o código.
+func FetchSignalLinkQRCode(cfg *config.SignalConfig, deviceName string) ([]byte, error) {
+	if cfg.APIURL == "" {
+		return nil, fmt.Errorf("api_url do Signal não configurada")
+	}
+
+	p := NewSignalProvider(cfg).(*signalProvider)
+	req, err := http.NewRequest("GET", p.apiURL("/v1/qrcodelink?device_name="+url.QueryEscape(deviceName)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar com a signal-cli-rest-api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, signalError(resp.StatusCode, body)
+	}
+	if !strings.HasPrefix(http.DetectContentType(body), "image/png") {
+		return nil, fmt.Errorf("resposta inesperada (esperado PNG): %s", strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// testSignalConnection verifica a API (GET /v1/about) e se o número está vinculado (GET /v1/accounts).
+func testSignalConnection(cfg *config.SignalConfig, target string) error {
+	p := NewSignalProvider(cfg).(*signalProvider)
+
+	req, err := http.NewRequest("GET", p.apiURL("/v1/about"), nil)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	if err := p.do(req, nil); err != nil {
+		return err
+	}
+
+	req, err = http.NewRequest("GET", p.apiURL("/v1/accounts"), nil)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	var accounts []string
+	if err := p.do(req, &accounts); err != nil {
+		return err
+	}
+	registered := false
+	for _, account := range accounts {
+		if account == cfg.Number {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		return fmt.Errorf("número %s não está registrado nem vinculado na signal-cli-rest-api (use 'cast signal link')", cfg.Number)
+	}
+
+	if target == "" {
+		return nil
+	}
+	return p.Send(target, "Mensagem de teste enviada por 'cast gateway test signal'.")
+}
@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestSignalProvider_Name(t *testing.T) {
+	provider := NewSignalProvider(&config.SignalConfig{})
+	if provider.Name() != "signal" {
+		t.Errorf("Esperado 'signal', obtido '%s'", provider.Name())
+	}
+}
+
+func TestSignalProvider_Send_NumbersAndGroups(t *testing.T) {
+	groupCalls := 0
+	var payload struct {
+		Number     string   `json:"number"`
+		Recipients []string `json:"recipients"`
+		Message    string   `json:"message"`
+		TextMode   string   `json:"text_mode"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v1/groups/+5511900001111":
+			groupCalls++
+			w.Write([]byte(`[{"name":"Plantão","id":"group.cGxhbnRhbw==","internal_id":"cGxhbnRhbw==","members":["+5511999998888"]}]`))
+		case r.Method == "POST" && r.URL.Path == "/v2/send":
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("Erro ao decodificar payload: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"timestamp":"1700000000000"}`))
+		default:
+			t.Errorf("Requisição inesperada: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewSignalProvider(&config.SignalConfig{
+		APIURL:           server.URL + "/",
+		Number:           "+5511900001111",
+		DefaultRecipient: "+55 11 99999-8888",
+	}).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{"styled": {"true"}}}
+	if err := provider.SendWithOptions("default,group:plantão,group.b3V0cm8=", "*Alerta*: disco cheio", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+
+	if payload.Number != "+5511900001111" || payload.Message != "*Alerta*: disco cheio" || payload.TextMode != "styled" {
+		t.Errorf("Payload inesperado: %+v", payload)
+	}
+	expected := "+5511999998888,group.cGxhbnRhbw==,group.b3V0cm8="
+	if strings.Join(payload.Recipients, ",") != expected {
+		t.Errorf("Destinatários inesperados: %v, esperado %s", payload.Recipients, expected)
+	}
+	if groupCalls != 1 {
+		t.Errorf("Esperado 1 consulta de grupos, obtido %d", groupCalls)
+	}
+}
+
+func TestSignalProvider_Send_Attachment(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "grafico.png")
+	png := []byte("\x89PNG\r\n\x1a\nfake")
+	if err := os.WriteFile(filePath, png, 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Attachments []string `json:"base64_attachments"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if len(payload.Attachments) != 1 {
+			t.Fatalf("Esperado 1 anexo, obtido %d", len(payload.Attachments))
+		}
+		prefix := "data:image/png;filename=grafico.png;base64,"
+		if !strings.HasPrefix(payload.Attachments[0], prefix) {
+			t.Errorf("Formato de anexo inesperado: %s", payload.Attachments[0])
+		}
+		data, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(payload.Attachments[0], prefix))
+		if string(data) != string(png) {
+			t.Error("Conteúdo do anexo diferente do arquivo")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	provider := NewSignalProvider(&config.SignalConfig{APIURL: server.URL, Number: "+5511900001111"}).(OptionsProvider)
+	if err := provider.SendWithOptions("+5511999998888", "", SendOptions{Attachments: []string{filePath}}); err != nil {
+		t.Fatalf("Erro ao enviar anexo: %v", err)
+	}
+}
+
+func TestSignalProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/groups/+5511900001111" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Failed to send message: Unregistered user \"+5511988887777\""}`))
+	}))
+	defer server.Close()
+
+	provider := NewSignalProvider(&config.SignalConfig{APIURL: server.URL, Number: "+5511900001111"})
+
+	tests := []struct {
+		target   string
+		contains string
+	}{
+		{"+5511988887777", "não registrado no Signal"},
+		{"group:Inexistente", "grupo 'Inexistente' não encontrado"},
+		{"11988887777", "E.164"},
+		{"default", "default_recipient"},
+	}
+	for _, tt := range tests {
+		err := provider.Send(tt.target, "Teste")
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("Target %s: esperado erro contendo '%s', obtido: %v", tt.target, tt.contains, err)
+		}
+	}
+}
+
+func TestFetchSignalLinkQRCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/qrcodelink" || r.URL.Query().Get("device_name") != "cast servidor" {
+			t.Errorf("Requisição inesperada: %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG\r\n\x1a\nqr"))
+	}))
+	defer server.Close()
+
+	data, err := FetchSignalLinkQRCode(&config.SignalConfig{APIURL: server.URL}, "cast servidor")
+	if err != nil {
+		t.Fatalf("Erro ao obter QR code: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "\x89PNG") {
+		t.Errorf("Esperado PNG, obtido %q", string(data))
+	}
+}
+
+func TestSignalConnection_Accounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/about":
+			w.Write([]byte(`{"versions":["v1","v2"],"mode":"json-rpc","version":"0.80"}`))
+		case "/v1/accounts":
+			w.Write([]byte(`["+5511900001111"]`))
+		default:
+			t.Errorf("Teste sem target não deveria enviar mensagens (path %s)", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	if err := testSignalConnection(&config.SignalConfig{APIURL: server.URL, Number: "+5511900001111"}, ""); err != nil {
+		t.Errorf("Conta vinculada não deveria retornar erro: %v", err)
+	}
+	err := testSignalConnection(&config.SignalConfig{APIURL: server.URL, Number: "+5511922223333"}, "")
+	if err == nil || !strings.Contains(err.Error(), "cast signal link") {
+		t.Errorf("Esperado erro de conta não vinculada, obtido: %v", err)
+	}
+}
@@ -0,0 +1,439 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "slack",
+		DisplayName: "Slack",
+		Order:       60,
+		TargetHint:  "canal (#geral, C0123ABC), webhook_url ou 'default'",
+		Fields: []ConfigField{
+			{Key: "webhook_url", Flag: "webhook-url", Label: "Webhook URL", Secret: true, Validate: ValidateHTTPURL},
+			{Key: "bot_token", Flag: "bot-token", Label: "Bot Token (xoxb-...)", Secret: true, Validate: validateSlackBotToken},
+			{Key: "default_channel", Flag: "default-channel", Label: "Default Channel"},
+			{Key: "username", Flag: "username", Label: "Username"},
+			{Key: "icon_emoji", Flag: "icon-emoji", Label: "Icon Emoji (ex: :robot_face:)"},
+			{Key: "api_url", Label: "API URL", Default: "https://slack.com/api"},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Attachments:     true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "blocks", Usage: "Blocos Block Kit em JSON ou @arquivo.json (Slack)"},
+			{Name: "thread", Usage: "Responde na thread indicada pelo thread_ts (Slack)"},
+		},
+		Validate: func(conf *config.Config) error {
+			if conf.Slack.WebhookURL == "" && conf.Slack.BotToken == "" {
+				return fmt.Errorf("configuração do Slack incompleta: webhook_url ou bot_token é obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Slack.WebhookURL != "" || conf.Slack.BotToken != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewSlackProvider(&conf.Slack), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testSlackConnection(&conf.Slack)
+		},
+		URLSchemes: []string{"slack"},
+		FromURL:    slackFromURL,
+	})
+}
+
+// slackFromURL interpreta slack://T000/B000/XXXX (Incoming Webhook) ou
+// slack://xoxb-TOKEN/#canal[/#canal...] (Web API com bot token).
+func slackFromURL(u *ProviderURL, conf *config.Config) (string, error) {
+	if strings.HasPrefix(u.Host, "xoxb-") {
+		if len(u.Path) == 0 {
+			return "", fmt.Errorf("informe ao menos um canal (use slack://xoxb-TOKEN/#canal)")
+		}
+		conf.Slack.BotToken = u.Host
+		conf.Slack.DefaultChannel = u.Path[0]
+		return strings.Join(u.Path, ","), nil
+	}
+	if u.Host == "" || len(u.Path) != 2 {
+		return "", fmt.Errorf("use slack://T000/B000/XXXX ou slack://xoxb-TOKEN/#canal")
+	}
+	conf.Slack.WebhookURL = "https://hooks.slack.com/services/" + u.Host + "/" + u.Path[0] + "/" + u.Path[1]
+	return "default", nil
+}
+
+// validateSlackBotToken valida o formato do bot token do Slack.
+func validateSlackBotToken(value string) error {
+	if value != "" && !strings.HasPrefix(value, "xox") {
+		return fmt.Errorf("bot token deve começar com xoxb- (ou xoxp- para token de usuário)")
+	}
+	return nil
+}
+
+// slackProvider implementa o Provider para Slack (Incoming Webhooks e Web API).
+type slackProvider struct {
+	config *config.SlackConfig
+	client *http.Client
+}
+
+// slackAPIResponse representa a resposta padrão da Web API do Slack.
+type slackAPIResponse struct {
+	OK        bool   `json:"ok"`
+	Error     string `json:"error"`
+	Channel   string `json:"channel"`
+	TS        string `json:"ts"`
+	UploadURL string `json:"upload_url"`
+	FileID    string `json:"file_id"`
+}
+
+// NewSlackProvider cria uma nova instância do SlackProvider.
+func NewSlackProvider(cfg *config.SlackConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &slackProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *slackProvider) Name() string {
+	return "slack"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *slackProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem de texto via Slack.
+func (p *slackProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem via Slack com Block Kit, thread e arquivos.
+// Lógica de Target:
+// - URL completa (https://...): envia via Incoming Webhook
+// - "default", "me" ou vazio: usa default_channel (bot_token) ou webhook_url
+// - Qualquer outro valor: canal (#nome ou ID) via chat.postMessage (requer bot_token)
+// - Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula
+func (p *slackProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	blocks, err := parseSlackBlocks(opts.Get("blocks"))
+	if err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		webhookURL, channel, err := p.resolveTarget(t)
+		if err != nil {
+			return err
+		}
+
+		if webhookURL != "" {
+			if len(opts.Attachments) > 0 {
+				return fmt.Errorf("upload de arquivos requer bot_token (Incoming Webhooks não suportam arquivos)")
+			}
+			err = p.sendToWebhook(webhookURL, message, blocks, opts.Get("thread"))
+		} else {
+			err = p.postMessage(channel, message, blocks, opts.Get("thread"), opts.Attachments)
+		}
+		if err != nil {
+			return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", t, i+1, len(targets), err)
+		}
+	}
+
+	return nil
+}
+
+// resolveTarget decide se o target é um webhook ou um canal da Web API.
+func (p *slackProvider) resolveTarget(target string) (webhookURL string, channel string, err error) {
+	switch {
+	case strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://"):
+		return target, "", nil
+	case target == "default" || target == "me" || target == "":
+		if p.config.BotToken != "" && p.config.DefaultChannel != "" {
+			return "", p.config.DefaultChannel, nil
+		}
+		if p.config.WebhookURL != "" {
+			return p.config.WebhookURL, "", nil
+		}
+		return "", "", fmt.Errorf("target '%s' requer webhook_url ou default_channel configurado", target)
+	default:
+		if p.config.BotToken == "" {
+			return "", "", fmt.Errorf("envio para o canal '%s' requer bot_token configurado", target)
+		}
+		return "", target, nil
+	}
+}
+
+// parseSlackBlocks interpreta o valor de --blocks: JSON inline ou @arquivo.
+// Aceita tanto um array de blocos quanto o objeto {"blocks": [...]} do Block Kit Builder.
+func parseSlackBlocks(value string) (json.RawMessage, error) {
+	value, err := readValueOrFile(value)
+	if err != nil || value == "" {
+		return nil, err
+	}
+
+	if strings.HasPrefix(value, "{") {
+		var wrapper struct {
+			Blocks json.RawMessage `json:"blocks"`
+		}
+		if err := json.Unmarshal([]byte(value), &wrapper); err != nil {
+			return nil, fmt.Errorf("blocos inválidos: %w", err)
+		}
+		if wrapper.Blocks == nil {
+			return nil, fmt.Errorf("blocos inválidos: objeto sem a chave \"blocks\"")
+		}
+		value = string(wrapper.Blocks)
+	}
+
+	var blocks []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &blocks); err != nil {
+		return nil, fmt.Errorf("blocos inválidos (esperado array JSON): %w", err)
+	}
+	return json.RawMessage(value), nil
+}
+
+// buildPayload monta o payload comum a webhooks e chat.postMessage.
+func (p *slackProvider) buildPayload(message string, blocks json.RawMessage, threadTS string) map[string]interface{} {
+	payload := map[string]interface{}{
+		"text": message,
+	}
+	if blocks != nil {
+		payload["blocks"] = blocks
+	}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
+	}
+	if p.config.Username != "" {
+		payload["username"] = p.config.Username
+	}
+	if p.config.IconEmoji != "" {
+		payload["icon_emoji"] = p.config.IconEmoji
+	}
+	return payload
+}
+
+// sendToWebhook envia mensagem para um Incoming Webhook.
+func (p *slackProvider) sendToWebhook(webhookURL string, message string, blocks json.RawMessage, threadTS string) error {
+	jsonData, err := json.Marshal(p.buildPayload(message, blocks, threadTS))
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		// Webhooks retornam o código de erro em texto puro (ex: invalid_payload, no_service)
+		return fmt.Errorf("Slack retornou status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// postMessage envia mensagem via chat.postMessage e, se houver, faz upload dos arquivos.
+func (p *slackProvider) postMessage(channel string, message string, blocks json.RawMessage, threadTS string, files []string) error {
+	payload := p.buildPayload(message, blocks, threadTS)
+	payload["channel"] = channel
+
+	result, err := p.callJSON("chat.postMessage", payload)
+	if err != nil {
+		return err
+	}
+
+	// files.completeUploadExternal exige o ID do canal, retornado pelo chat.postMessage
+	for _, file := range files {
+		if err := p.uploadFile(result.Channel, threadTS, file); err != nil {
+			return fmt.Errorf("erro ao enviar arquivo %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+// uploadFile envia um arquivo usando o fluxo files.getUploadURLExternal + completeUploadExternal.
+func (p *slackProvider) uploadFile(channelID string, threadTS string, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+	filename := filepath.Base(path)
+
+	// 1. Solicita URL de upload
+	form := url.Values{}
+	form.Set("filename", filename)
+	form.Set("length", strconv.Itoa(len(data)))
+	upload, err := p.callForm("files.getUploadURLExternal", form)
+	if err != nil {
+		return err
+	}
+
+	// 2. Envia o conteúdo do arquivo
+	req, err := http.NewRequest("POST", upload.UploadURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição de upload: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro no upload: %w", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upload retornou status %d", resp.StatusCode)
+	}
+
+	// 3. Finaliza o upload compartilhando no canal
+	complete := map[string]interface{}{
+		"files":      []map[string]string{{"id": upload.FileID, "title": filename}},
+		"channel_id": channelID,
+	}
+	if threadTS != "" {
+		complete["thread_ts"] = threadTS
+	}
+	_, err = p.callJSON("files.completeUploadExternal", complete)
+	return err
+}
+
+// callJSON chama um método da Web API com corpo JSON.
+func (p *slackProvider) callJSON(method string, payload interface{}) (*slackAPIResponse, error) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+	req, err := http.NewRequest("POST", p.methodURL(method), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return p.doAPI(method, req)
+}
+
+// callForm chama um método da Web API com corpo form-urlencoded.
+func (p *slackProvider) callForm(method string, form url.Values) (*slackAPIResponse, error) {
+	req, err := http.NewRequest("POST", p.methodURL(method), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return p.doAPI(method, req)
+}
+
+// doAPI executa a requisição autenticada e interpreta a resposta {"ok": ..., "error": ...}.
+func (p *slackProvider) doAPI(method string, req *http.Request) (*slackAPIResponse, error) {
+	req.Header.Set("Authorization", "Bearer "+p.config.BotToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Slack %s retornou status %d: %s", method, resp.StatusCode, string(body))
+	}
+
+	var result slackAPIResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar resposta do Slack: %w", err)
+	}
+	if !result.OK {
+		return nil, slackError(method, result.Error)
+	}
+
+	return &result, nil
+}
+
+// methodURL retorna a URL de um método da Web API.
+func (p *slackProvider) methodURL(method string) string {
+	apiURL := p.config.APIURL
+	if apiURL == "" {
+		apiURL = "https://slack.com/api"
+	}
+	return strings.TrimRight(apiURL, "/") + "/" + method
+}
+
+// slackError converte códigos de erro da Web API em mensagens amigáveis.
+func slackError(method string, code string) error {
+	switch code {
+	case "invalid_auth", "not_authed", "token_revoked", "account_inactive":
+		return fmt.Errorf("Slack %s: autenticação falhou (%s). Verifique o bot_token", method, code)
+	case "channel_not_found":
+		return fmt.Errorf("Slack %s: canal não encontrado. Use o ID do canal ou convide o bot", method)
+	case "not_in_channel":
+		return fmt.Errorf("Slack %s: o bot não é membro do canal. Use /invite @bot no canal", method)
+	case "missing_scope":
+		return fmt.Errorf("Slack %s: escopo ausente no token (ex: chat:write, files:write)", method)
+	case "invalid_blocks", "invalid_blocks_format":
+		return fmt.Errorf("Slack %s: blocos Block Kit inválidos (%s)", method, code)
+	}
+	return fmt.Errorf("Slack %s retornou erro: %s", method, code)
+}
+
+// testSlackConnection testa a configuração do Slack sem publicar mensagens.
+// Com bot_token usa auth.test; com apenas webhook envia payload vazio, que um
+// webhook válido rejeita com "no_text" (400) e um inválido com 403/404.
+func testSlackConnection(cfg *config.SlackConfig) error {
+	p := NewSlackProvider(cfg).(*slackProvider)
+
+	if cfg.BotToken != "" {
+		if _, err := p.callJSON("auth.test", map[string]string{}); err != nil {
+			return err
+		}
+	}
+
+	if cfg.WebhookURL != "" {
+		req, err := http.NewRequest("POST", cfg.WebhookURL, strings.NewReader("{}"))
+		if err != nil {
+			return fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("erro ao conectar com o webhook: %w", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), "no_text") {
+			return fmt.Errorf("webhook inválido: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestSlackProvider_Name(t *testing.T) {
+	provider := NewSlackProvider(&config.SlackConfig{})
+	if provider.Name() != "slack" {
+		t.Errorf("Esperado 'slack', obtido '%s'", provider.Name())
+	}
+}
+
+func TestSlackProvider_Webhook_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Esperado método POST, obtido %s", r.Method)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Webhook não deveria enviar header Authorization")
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload["text"] != "Teste de mensagem" {
+			t.Errorf("Esperado text 'Teste de mensagem', obtido '%v'", payload["text"])
+		}
+		if payload["username"] != "cast-bot" {
+			t.Errorf("Esperado username 'cast-bot', obtido '%v'", payload["username"])
+		}
+		blocks, ok := payload["blocks"].([]interface{})
+		if !ok || len(blocks) != 1 {
+			t.Errorf("Esperado 1 bloco, obtido '%v'", payload["blocks"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	provider := NewSlackProvider(&config.SlackConfig{
+		WebhookURL: server.URL,
+		Username:   "cast-bot",
+		Timeout:    30,
+	}).(OptionsProvider)
+
+	opts := SendOptions{Values: map[string][]string{
+		"blocks": {`[{"type":"section","text":{"type":"mrkdwn","text":"*Olá*"}}]`},
+	}}
+	if err := provider.SendWithOptions("default", "Teste de mensagem", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestSlackProvider_Webhook_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid_payload"))
+	}))
+	defer server.Close()
+
+	provider := NewSlackProvider(&config.SlackConfig{WebhookURL: server.URL})
+
+	err := provider.Send("default", "Teste")
+	if err == nil {
+		t.Fatal("Esperado erro, mas não houve")
+	}
+	if !strings.Contains(err.Error(), "invalid_payload") {
+		t.Errorf("Erro deveria conter 'invalid_payload', obtido: %v", err)
+	}
+}
+
+func TestSlackProvider_PostMessage_Thread(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("Esperado path '/chat.postMessage', obtido '%s'", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			t.Errorf("Esperado Authorization 'Bearer xoxb-test', obtido '%s'", r.Header.Get("Authorization"))
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload["channel"] != "#ops" {
+			t.Errorf("Esperado channel '#ops', obtido '%v'", payload["channel"])
+		}
+		if payload["thread_ts"] != "1700000000.000100" {
+			t.Errorf("Esperado thread_ts '1700000000.000100', obtido '%v'", payload["thread_ts"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"channel":"C123","ts":"1700000001.000200"}`))
+	}))
+	defer server.Close()
+
+	provider := NewSlackProvider(&config.SlackConfig{
+		BotToken: "xoxb-test",
+		APIURL:   server.URL,
+	}).(OptionsProvider)
+
+	opts := SendOptions{Values: map[string][]string{"thread": {"1700000000.000100"}}}
+	if err := provider.SendWithOptions("#ops", "Resposta na thread", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestSlackProvider_PostMessage_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+	}))
+	defer server.Close()
+
+	provider := NewSlackProvider(&config.SlackConfig{
+		BotToken: "xoxb-test",
+		APIURL:   server.URL,
+	})
+
+	err := provider.Send("#inexistente", "Teste")
+	if err == nil {
+		t.Fatal("Esperado erro, mas não houve")
+	}
+	if !strings.Contains(err.Error(), "canal não encontrado") {
+		t.Errorf("Erro deveria mencionar canal não encontrado, obtido: %v", err)
+	}
+}
+
+func TestSlackProvider_FileUpload(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "relatorio.txt")
+	if err := os.WriteFile(filePath, []byte("conteúdo do relatório"), 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	var calls []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			w.Write([]byte(`{"ok":true,"channel":"C123","ts":"1.2"}`))
+		case "/files.getUploadURLExternal":
+			r.ParseForm()
+			if r.Form.Get("filename") != "relatorio.txt" {
+				t.Errorf("Esperado filename 'relatorio.txt', obtido '%s'", r.Form.Get("filename"))
+			}
+			if r.Form.Get("length") != "23" {
+				t.Errorf("Esperado length '23', obtido '%s'", r.Form.Get("length"))
+			}
+			w.Write([]byte(`{"ok":true,"upload_url":"` + server.URL + `/upload","file_id":"F999"}`))
+		case "/upload":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != "conteúdo do relatório" {
+				t.Errorf("Conteúdo do upload incorreto: %s", string(body))
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/files.completeUploadExternal":
+			var payload map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload["channel_id"] != "C123" {
+				t.Errorf("Esperado channel_id 'C123', obtido '%v'", payload["channel_id"])
+			}
+			w.Write([]byte(`{"ok":true}`))
+		default:
+			t.Errorf("Path inesperado: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewSlackProvider(&config.SlackConfig{
+		BotToken: "xoxb-test",
+		APIURL:   server.URL,
+	}).(OptionsProvider)
+
+	if err := provider.SendWithOptions("#ops", "Segue o relatório", SendOptions{Attachments: []string{filePath}}); err != nil {
+		t.Fatalf("Erro ao enviar arquivo: %v", err)
+	}
+
+	expected := []string{"/chat.postMessage", "/files.getUploadURLExternal", "/upload", "/files.completeUploadExternal"}
+	if strings.Join(calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Sequência de chamadas incorreta: %v", calls)
+	}
+}
+
+func TestSlackProvider_TargetValidation(t *testing.T) {
+	// Canal sem bot_token
+	provider := NewSlackProvider(&config.SlackConfig{WebhookURL: "https://hooks.slack.com/services/X"})
+	if err := provider.Send("#ops", "Teste"); err == nil || !strings.Contains(err.Error(), "bot_token") {
+		t.Errorf("Esperado erro exigindo bot_token, obtido: %v", err)
+	}
+
+	// Anexo via webhook
+	optProvider := provider.(OptionsProvider)
+	err := optProvider.SendWithOptions("default", "Teste", SendOptions{Attachments: []string{"arquivo.pdf"}})
+	if err == nil || !strings.Contains(err.Error(), "bot_token") {
+		t.Errorf("Esperado erro de upload via webhook, obtido: %v", err)
+	}
+
+	// Nada configurado
+	provider = NewSlackProvider(&config.SlackConfig{})
+	if err := provider.Send("default", "Teste"); err == nil {
+		t.Error("Esperado erro sem webhook_url nem default_channel")
+	}
+}
+
+func TestParseSlackBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"vazio", "", false},
+		{"array", `[{"type":"divider"}]`, false},
+		{"objeto do Block Kit Builder", `{"blocks":[{"type":"divider"}]}`, false},
+		{"objeto sem blocks", `{"type":"divider"}`, true},
+		{"JSON inválido", `[{"type":`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSlackBlocks(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSlackBlocks(%q) erro = %v, esperado erro = %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlackConnection_Webhook(t *testing.T) {
+	valid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("no_text"))
+	}))
+	defer valid.Close()
+
+	if err := testSlackConnection(&config.SlackConfig{WebhookURL: valid.URL}); err != nil {
+		t.Errorf("Webhook válido não deveria retornar erro: %v", err)
+	}
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no_service"))
+	}))
+	defer invalid.Close()
+
+	if err := testSlackConnection(&config.SlackConfig{WebhookURL: invalid.URL}); err == nil {
+		t.Error("Webhook inválido deveria retornar erro")
+	}
+}
+
+func TestGetProvider_Slack(t *testing.T) {
+	if _, err := GetProvider("slack", &config.Config{}); err == nil {
+		t.Error("Esperado erro para Slack sem configuração")
+	}
+
+	cfg := &config.Config{Slack: config.SlackConfig{WebhookURL: "https://hooks.slack.com/services/X"}}
+	provider, err := GetProvider("slack", cfg)
+	if err != nil {
+		t.Fatalf("Erro ao obter provider Slack: %v", err)
+	}
+	if provider.Name() != "slack" {
+		t.Errorf("Esperado nome 'slack', obtido '%s'", provider.Name())
+	}
+}
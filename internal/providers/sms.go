@@ -0,0 +1,381 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "sms",
+		Aliases:     []string{"twilio"},
+		DisplayName: "SMS",
+		Order:       150,
+		TargetHint:  "número E.164 (+5511999998888) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "account_sid", Flag: "account-sid", Label: "Account SID", Required: true},
+			{Key: "auth_token", Flag: "auth-token", Label: "Auth Token", Required: true, Secret: true},
+			{Key: "from_number", Flag: "from-number", Label: "Número remetente (E.164)", Validate: validateE164},
+			{Key: "messaging_service_sid", Flag: "messaging-service-sid", Label: "Messaging Service SID (MG...)"},
+			{Key: "default_to", Flag: "default-to", Label: "Destinatário padrão (E.164)", Validate: validateE164},
+			{Key: "base_url", Flag: "base-url", Label: "URL base da API", Default: "https://api.twilio.com", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "media-url", Usage: "URL pública de mídia para MMS (SMS; pode ser repetido)", Repeatable: true},
+			{Name: "status-callback", Usage: "URL notificada a cada mudança de status da entrega (SMS)"},
+		},
+		Validate: func(conf *config.Config) error {
+			if conf.SMS.FromNumber == "" && conf.SMS.MessagingServiceSID == "" {
+				return fmt.Errorf("configuração de SMS incompleta: from_number ou messaging_service_sid é obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.SMS.AccountSID != "" && conf.SMS.AuthToken != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewSMSProviderWithVerbose(&conf.SMS, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testSMSConnection(&conf.SMS, target)
+		},
+	})
+}
+
+// smsMaxBodyLength é o limite de caracteres do corpo aceito pela API Messages.
+const smsMaxBodyLength = 1600
+
+// e164Pattern valida números no formato E.164: "+" seguido de 2 a 15 dígitos, sem zero inicial.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// NormalizeE164 remove espaços, hífens, pontos e parênteses e valida o número no formato E.164.
+func NormalizeE164(number string) (string, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimSpace(number))
+	if !e164Pattern.MatchString(normalized) {
+		return "", fmt.Errorf("número inválido: '%s' (use o formato E.164, ex: +5511999998888)", number)
+	}
+	return normalized, nil
+}
+
+// validateE164 valida um número E.164 opcional da configuração.
+func validateE164(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := NormalizeE164(value)
+	return err
+}
+
+// gsm7Basic contém o alfabeto padrão GSM 03.38 (cada caractere ocupa 1 septeto).
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extended contém os caracteres da tabela de extensão GSM (ocupam 2 septetos: ESC + caractere).
+const gsm7Extended = "^{}\\[~]|€\f"
+
+// SMSSegments descreve a estimativa de segmentos de uma mensagem.
+type SMSSegments struct {
+	Encoding   string // "GSM-7" ou "UCS-2"
+	Units      int    // Septetos (GSM-7) ou unidades UTF-16 (UCS-2)
+	Segments   int
+	PerSegment int // Capacidade de cada segmento na codificação usada
+}
+
+// EstimateSMSSegments estima quantos segmentos a operadora cobrará pela mensagem.
+// Mensagens só com o alfabeto GSM-7 cabem em 160 caracteres (153 por segmento quando
+// concatenadas); qualquer outro caractere (ex: "ã", "õ", emojis) força UCS-2, com 70 (67).
+func EstimateSMSSegments(message string) SMSSegments {
+	gsm := true
+	septets := 0
+	for _, r := range message {
+		switch {
+		case strings.ContainsRune(gsm7Basic, r):
+			septets++
+		case strings.ContainsRune(gsm7Extended, r):
+			septets += 2
+		default:
+			gsm = false
+		}
+		if !gsm {
+			break
+		}
+	}
+
+	info := SMSSegments{Encoding: "GSM-7", Units: septets, PerSegment: 160}
+	single, multi := 160, 153
+	if !gsm {
+		info = SMSSegments{Encoding: "UCS-2", Units: len(utf16.Encode([]rune(message))), PerSegment: 70}
+		single, multi = 70, 67
+	}
+
+	switch {
+	case info.Units == 0:
+		info.Segments = 0
+	case info.Units <= single:
+		info.Segments = 1
+	default:
+		info.PerSegment = multi
+		info.Segments = (info.Units + multi - 1) / multi
+	}
+	return info
+}
+
+// SMSStatus representa um recurso Message da API (resposta do envio e da consulta de status).
+type SMSStatus struct {
+	SID          string `json:"sid"`
+	To           string `json:"to"`
+	From         string `json:"from"`
+	Status       string `json:"status"` // queued, sending, sent, delivered, undelivered, failed...
+	NumSegments  string `json:"num_segments"`
+	ErrorCode    *int   `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+	DateSent     string `json:"date_sent"`
+	Price        string `json:"price"`
+	PriceUnit    string `json:"price_unit"`
+}
+
+// smsProvider implementa o Provider para SMS via API Messages da Twilio (ou compatível).
+type smsProvider struct {
+	config  *config.SMSConfig
+	client  *http.Client
+	verbose bool
+}
+
+// NewSMSProvider cria uma nova instância do SMSProvider.
+func NewSMSProvider(cfg *config.SMSConfig) Provider {
+	return NewSMSProviderWithVerbose(cfg, false)
+}
+
+// NewSMSProviderWithVerbose cria uma nova instância do SMSProvider com modo verbose.
+func NewSMSProviderWithVerbose(cfg *config.SMSConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &smsProvider{
+		config:  cfg,
+		client:  &http.Client{Timeout: timeout},
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *smsProvider) Name() string {
+	return "sms"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *smsProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia um SMS.
+func (p *smsProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia um SMS (ou MMS, com --media-url) para um ou mais números.
+// Lógica de Target:
+// - "default", "me" ou vazio: usa default_to
+// - Número E.164 (espaços, hífens e parênteses são removidos)
+// - Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula
+func (p *smsProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	if p.config.AccountSID == "" || p.config.AuthToken == "" {
+		return fmt.Errorf("account_sid e auth_token de SMS não configurados")
+	}
+	if p.config.FromNumber == "" && p.config.MessagingServiceSID == "" {
+		return fmt.Errorf("from_number ou messaging_service_sid de SMS não configurado")
+	}
+
+	mediaURLs := opts.GetAll("media-url")
+	if message == "" && len(mediaURLs) == 0 {
+		return fmt.Errorf("mensagem vazia")
+	}
+	if n := len([]rune(message)); n > smsMaxBodyLength {
+		return fmt.Errorf("mensagem com %d caracteres excede o limite de %d", n, smsMaxBodyLength)
+	}
+	for _, mediaURL := range mediaURLs {
+		if err := ValidateHTTPURL(mediaURL); err != nil {
+			return fmt.Errorf("--media-url: %w", err)
+		}
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	// Valida todos os números antes de enviar, evitando envios parciais por erro de digitação
+	numbers := make([]string, 0, len(targets))
+	for _, t := range targets {
+		if t == "default" || t == "me" {
+			if p.config.DefaultTo == "" {
+				return fmt.Errorf("target '%s' requer default_to configurado", t)
+			}
+			t = p.config.DefaultTo
+		}
+		number, err := NormalizeE164(t)
+		if err != nil {
+			return err
+		}
+		numbers = append(numbers, number)
+	}
+
+	if p.verbose {
+		seg := EstimateSMSSegments(message)
+		fmt.Fprintf(os.Stderr, "[DEBUG] Codificação: %s, %d unidade(s), %d segmento(s) por destinatário\n", seg.Encoding, seg.Units, seg.Segments)
+	}
+
+	for i, number := range numbers {
+		form := url.Values{}
+		form.Set("To", number)
+		if message != "" {
+			form.Set("Body", message)
+		}
+		if p.config.MessagingServiceSID != "" {
+			form.Set("MessagingServiceSid", p.config.MessagingServiceSID)
+		} else {
+			form.Set("From", p.config.FromNumber)
+		}
+		for _, mediaURL := range mediaURLs {
+			form.Add("MediaUrl", mediaURL)
+		}
+		if callback := opts.Get("status-callback"); callback != "" {
+			form.Set("StatusCallback", callback)
+		}
+
+		var status SMSStatus
+		if err := p.call("POST", "/Messages.json", form, &status); err != nil {
+			return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", number, i+1, len(numbers), err)
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] SID: %s (status: %s) - consulte com 'cast sms status %s'\n", status.SID, status.Status, status.SID)
+		}
+	}
+
+	return nil
+}
+
+// call executa uma requisição autenticada (Basic Auth com account_sid:auth_token)
+// relativa à conta e decodifica a resposta em out.
+func (p *smsProvider) call(method string, path string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, p.accountURL(path), body)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.SetBasicAuth(p.config.AccountSID, p.config.AuthToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return smsError(resp.StatusCode, respBody)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("erro ao decodificar resposta: %w", err)
+		}
+	}
+	return nil
+}
+
+// accountURL retorna a URL de um recurso da conta (/2010-04-01/Accounts/{sid}{path}).
+func (p *smsProvider) accountURL(path string) string {
+	baseURL := p.config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.twilio.com"
+	}
+	return strings.TrimRight(baseURL, "/") + "/2010-04-01/Accounts/" + url.PathEscape(p.config.AccountSID) + path
+}
+
+// smsError converte erros da API ({"code":21211,"message":"...","status":400}) em mensagens amigáveis.
+func smsError(statusCode int, body []byte) error {
+	var apiErr struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Message == "" {
+		return fmt.Errorf("API de SMS retornou status %d: %s", statusCode, strings.TrimSpace(string(body)))
+	}
+
+	switch apiErr.Code {
+	case 20003:
+		return fmt.Errorf("credenciais inválidas: verifique account_sid e auth_token")
+	case 20404:
+		return fmt.Errorf("recurso não encontrado: %s", apiErr.Message)
+	case 21211, 21614:
+		return fmt.Errorf("número de destino inválido: %s", apiErr.Message)
+	case 21408:
+		return fmt.Errorf("envio não permitido para a região do destino: %s", apiErr.Message)
+	case 21606, 21212:
+		return fmt.Errorf("número remetente inválido ou não habilitado para SMS: %s", apiErr.Message)
+	case 21608:
+		return fmt.Errorf("conta trial só envia para números verificados: %s", apiErr.Message)
+	}
+	return fmt.Errorf("API de SMS retornou erro %d (código %d): %s", statusCode, apiErr.Code, apiErr.Message)
+}
+
+// LookupSMSStatus consulta o status de entrega de uma mensagem pelo SID (SM... ou MM...).
+func LookupSMSStatus(cfg *config.SMSConfig, sid string) (*SMSStatus, error) {
+	sid = strings.TrimSpace(sid)
+	if sid == "" {
+		return nil, fmt.Errorf("SID da mensagem é obrigatório")
+	}
+	if cfg.AccountSID == "" || cfg.AuthToken == "" {
+		return nil, fmt.Errorf("account_sid e auth_token de SMS não configurados")
+	}
+
+	p := NewSMSProvider(cfg).(*smsProvider)
+	var status SMSStatus
+	if err := p.call("GET", "/Messages/"+url.PathEscape(sid)+".json", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// testSMSConnection valida as credenciais consultando a conta (GET /Accounts/{sid}.json).
+// O SMS de teste só é enviado se target for informado, pois envios são cobrados.
+func testSMSConnection(cfg *config.SMSConfig, target string) error {
+	p := NewSMSProvider(cfg).(*smsProvider)
+
+	var account struct {
+		Status string `json:"status"`
+	}
+	if err := p.call("GET", ".json", nil, &account); err != nil {
+		return err
+	}
+	if account.Status != "" && account.Status != "active" {
+		return fmt.Errorf("conta com status '%s'", account.Status)
+	}
+
+	if target == "" {
+		return nil
+	}
+	return p.Send(target, "CAST - SMS de teste enviado por 'cast gateway test sms'.")
+}
@@ -0,0 +1,229 @@
+package providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func newTestSMSConfig(serverURL string) *config.SMSConfig {
+	return &config.SMSConfig{
+		BaseURL:    serverURL,
+		AccountSID: "AC123",
+		AuthToken:  "segredo",
+		FromNumber: "+15005550006",
+		DefaultTo:  "+5511999998888",
+		Timeout:    30,
+	}
+}
+
+func TestSMSProvider_Name(t *testing.T) {
+	provider := NewSMSProvider(&config.SMSConfig{})
+	if provider.Name() != "sms" {
+		t.Errorf("Esperado 'sms', obtido '%s'", provider.Name())
+	}
+}
+
+func TestSMSProvider_Send_MultipleTargets(t *testing.T) {
+	var recipients []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+			t.Errorf("Esperado POST Messages.json, obtido %s %s", r.Method, r.URL.Path)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "AC123" || pass != "segredo" {
+			t.Errorf("Basic Auth inesperado: %s:%s", user, pass)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Erro ao ler formulário: %v", err)
+		}
+		if r.PostForm.Get("From") != "+15005550006" || r.PostForm.Get("Body") != "Servidor fora do ar" {
+			t.Errorf("Formulário inesperado: %v", r.PostForm)
+		}
+		if r.PostForm.Get("StatusCallback") != "https://exemplo.com/sms-status" {
+			t.Errorf("StatusCallback inesperado: %s", r.PostForm.Get("StatusCallback"))
+		}
+		recipients = append(recipients, r.PostForm.Get("To"))
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"SM001","status":"queued","num_segments":"1"}`))
+	}))
+	defer server.Close()
+
+	provider := NewSMSProvider(newTestSMSConfig(server.URL)).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{"status-callback": {"https://exemplo.com/sms-status"}}}
+	if err := provider.SendWithOptions("default,+55 (21) 98888-7777", "Servidor fora do ar", opts); err != nil {
+		t.Fatalf("Erro ao enviar SMS: %v", err)
+	}
+
+	if strings.Join(recipients, ",") != "+5511999998888,+5521988887777" {
+		t.Errorf("Destinatários inesperados: %v", recipients)
+	}
+}
+
+func TestSMSProvider_Send_MessagingServiceAndMedia(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("MessagingServiceSid") != "MG123" || r.PostForm.Get("From") != "" {
+			t.Errorf("Esperado MessagingServiceSid sem From, obtido %v", r.PostForm)
+		}
+		if len(r.PostForm["MediaUrl"]) != 2 {
+			t.Errorf("Esperado 2 MediaUrl, obtido %v", r.PostForm["MediaUrl"])
+		}
+		if _, ok := r.PostForm["Body"]; ok {
+			t.Error("MMS sem texto não deveria enviar Body")
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"MM001","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	cfg := newTestSMSConfig(server.URL)
+	cfg.FromNumber = ""
+	cfg.MessagingServiceSID = "MG123"
+	provider := NewSMSProvider(cfg).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{"media-url": {"https://exemplo.com/a.png", "https://exemplo.com/b.png"}}}
+	if err := provider.SendWithOptions("+5511999998888", "", opts); err != nil {
+		t.Fatalf("Erro ao enviar MMS: %v", err)
+	}
+}
+
+func TestSMSProvider_Send_InvalidTargetSendsNothing(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	provider := NewSMSProvider(newTestSMSConfig(server.URL))
+	err := provider.Send("+5511999998888,11999998888", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "E.164") {
+		t.Errorf("Esperado erro de formato E.164, obtido: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Nenhum SMS deveria ser enviado com target inválido, obtido %d requisições", requests)
+	}
+}
+
+func TestSMSProvider_Send_APIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Authorization"), "Basic") {
+			_, pass, _ := r.BasicAuth()
+			if pass == "errado" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":20003,"message":"Authenticate","more_info":"https://www.twilio.com/docs/errors/20003","status":401}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":21608,"message":"The number +5511999998888 is unverified.","status":400}`))
+	}))
+	defer server.Close()
+
+	provider := NewSMSProvider(newTestSMSConfig(server.URL))
+	err := provider.Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "conta trial") {
+		t.Errorf("Esperado erro de conta trial, obtido: %v", err)
+	}
+
+	cfg := newTestSMSConfig(server.URL)
+	cfg.AuthToken = "errado"
+	err = NewSMSProvider(cfg).Send("default", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "credenciais inválidas") {
+		t.Errorf("Esperado erro de credenciais, obtido: %v", err)
+	}
+
+	err = provider.Send("default", strings.Repeat("a", 1601))
+	if err == nil || !strings.Contains(err.Error(), "excede o limite") {
+		t.Errorf("Esperado erro de tamanho, obtido: %v", err)
+	}
+}
+
+func TestNormalizeE164(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"+5511999998888", "+5511999998888", false},
+		{"+55 (11) 99999-8888", "+5511999998888", false},
+		{"+1.415.555.0100", "+14155550100", false},
+		{"5511999998888", "", true},
+		{"+0511999998888", "", true},
+		{"+55119999988881234", "", true},
+		{"+55abc", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeE164(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeE164(%q) erro = %v, esperado erro = %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("NormalizeE164(%q) = %q, esperado %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestEstimateSMSSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		encoding string
+		units    int
+		segments int
+	}{
+		{"vazia", "", "GSM-7", 0, 0},
+		{"gsm curta", "Deploy concluido", "GSM-7", 16, 1},
+		{"gsm limite", strings.Repeat("a", 160), "GSM-7", 160, 1},
+		{"gsm concatenada", strings.Repeat("a", 161), "GSM-7", 161, 2},
+		{"gsm estendido", "Custo: 10€ [ok]", "GSM-7", 18, 1},
+		{"acentos ucs2", "Manutenção às 22h", "UCS-2", 17, 1},
+		{"ucs2 concatenada", strings.Repeat("ã", 71), "UCS-2", 71, 2},
+		{"emoji", "🚨 Alerta", "UCS-2", 9, 1},
+	}
+
+	for _, tt := range tests {
+		got := EstimateSMSSegments(tt.message)
+		if got.Encoding != tt.encoding || got.Units != tt.units || got.Segments != tt.segments {
+			t.Errorf("%s: obtido %+v, esperado %s/%d unidades/%d segmentos", tt.name, got, tt.encoding, tt.units, tt.segments)
+		}
+	}
+}
+
+func TestLookupSMSStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2010-04-01/Accounts/AC123/Messages/SM001.json" {
+			t.Errorf("Requisição inesperada: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"sid":"SM001","to":"+5511999998888","status":"undelivered","error_code":30003,"error_message":"Unreachable destination handset"}`))
+	}))
+	defer server.Close()
+
+	status, err := LookupSMSStatus(newTestSMSConfig(server.URL), "SM001")
+	if err != nil {
+		t.Fatalf("Erro ao consultar status: %v", err)
+	}
+	if status.Status != "undelivered" || status.ErrorCode == nil || *status.ErrorCode != 30003 {
+		t.Errorf("Status inesperado: %+v", status)
+	}
+}
+
+func TestSMSConnection_Account(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2010-04-01/Accounts/AC123.json" {
+			t.Errorf("Teste sem target não deveria enviar SMS (path %s)", r.URL.Path)
+		}
+		w.Write([]byte(`{"sid":"AC123","status":"suspended"}`))
+	}))
+	defer server.Close()
+
+	err := testSMSConnection(newTestSMSConfig(server.URL), "")
+	if err == nil || !strings.Contains(err.Error(), "suspended") {
+		t.Errorf("Esperado erro de conta suspensa, obtido: %v", err)
+	}
+}
@@ -0,0 +1,412 @@
+package providers
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "syslog",
+		DisplayName: "Syslog",
+		Order:       210,
+		TargetHint:  "APP-NAME da mensagem (ex: deploy) ou 'default'",
+		Fields: []ConfigField{
+			{Key: "address", Flag: "address", Label: "Endereço (udp://host:514, tcp://host:514, tls://host:6514 ou unix:///dev/log)", Required: true, Validate: validateSyslogAddress},
+			{Key: "facility", Flag: "facility", Label: "Facility (user, daemon, local0..local7...)", Default: "user", Validate: validateSyslogFacility},
+			{Key: "default_severity", Flag: "default-severity", Label: "Severidade padrão (emerg, alert, crit, err, warning, notice, info, debug)", Default: "notice", Validate: validateSyslogSeverity},
+			{Key: "app_name", Flag: "app-name", Label: "APP-NAME padrão", Default: "cast"},
+			{Key: "hostname", Flag: "hostname", Label: "HOSTNAME informado (padrão: hostname da máquina)"},
+			{Key: "ca_file", Flag: "ca-file", Label: "Arquivo PEM com a CA do servidor (tls://)"},
+			{Key: "tls_insecure", Flag: "tls-insecure", Label: "Não verificar o certificado do servidor", Kind: FieldBool},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+		},
+		SendFlags: []SendFlag{
+			sharedSendFlag("severity"),
+			sharedSendFlag("facility"),
+			sharedSendFlag("meta"),
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Syslog.Address != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewSyslogProviderWithVerbose(&conf.Syslog, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testSyslogConnection(&conf.Syslog, target)
+		},
+	})
+}
+
+// syslogSDID é o SD-ID do elemento de structured data com assunto e metadados.
+// 32473 é o número de empresa reservado pela IANA para exemplos e documentação (RFC 5612).
+const syslogSDID = "cast@32473"
+
+// syslogSeverities mapeia os nomes de severidade (RFC 5424, seção 6.2.1) para o código.
+// Os nomes do PagerDuty também são aceitos, para que o mesmo alias sirva aos dois providers.
+var syslogSeverities = map[string]int{
+	"emerg": 0, "emergency": 0, "panic": 0,
+	"alert": 1,
+	"crit":  2, "critical": 2,
+	"err": 3, "error": 3,
+	"warning": 4, "warn": 4,
+	"notice": 5,
+	"info":   6, "informational": 6,
+	"debug": 7,
+}
+
+// syslogFacilities mapeia os nomes de facility (RFC 5424, seção 6.2.1) para o código.
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11, "ntp": 12, "security": 13, "console": 14, "solaris-cron": 15,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// parseSyslogSeverity converte o nome ou o código (0-7) da severidade.
+func parseSyslogSeverity(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if code, err := strconv.Atoi(value); err == nil && code >= 0 && code <= 7 {
+		return code, nil
+	}
+	if code, ok := syslogSeverities[value]; ok {
+		return code, nil
+	}
+	return 0, fmt.Errorf("severidade inválida: '%s' (use emerg, alert, crit, err, warning, notice, info ou debug)", value)
+}
+
+// parseSyslogFacility converte o nome ou o código (0-23) da facility.
+func parseSyslogFacility(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if code, err := strconv.Atoi(value); err == nil && code >= 0 && code <= 23 {
+		return code, nil
+	}
+	if code, ok := syslogFacilities[value]; ok {
+		return code, nil
+	}
+	return 0, fmt.Errorf("facility inválida: '%s' (ex: user, daemon, local0..local7)", value)
+}
+
+// validateSyslogSeverity valida a severidade padrão configurada.
+func validateSyslogSeverity(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseSyslogSeverity(value)
+	return err
+}
+
+// validateSyslogFacility valida a facility configurada.
+func validateSyslogFacility(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseSyslogFacility(value)
+	return err
+}
+
+// syslogEndpoint descreve o destino extraído de address.
+type syslogEndpoint struct {
+	Network string // udp, tcp ou unixgram
+	Addr    string
+	Host    string
+	TLS     bool
+}
+
+// parseSyslogAddress interpreta address (udp://, tcp://, tls:// ou unix://).
+func parseSyslogAddress(raw string) (syslogEndpoint, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return syslogEndpoint{}, fmt.Errorf("address inválido: '%s' (ex: udp://localhost:514)", raw)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme == "unix" {
+		if u.Path == "" {
+			return syslogEndpoint{}, fmt.Errorf("address inválido: '%s' (ex: unix:///dev/log)", raw)
+		}
+		return syslogEndpoint{Network: "unixgram", Addr: u.Path}, nil
+	}
+	if u.Host == "" {
+		return syslogEndpoint{}, fmt.Errorf("address inválido: '%s' (ex: udp://localhost:514)", raw)
+	}
+
+	endpoint := syslogEndpoint{Host: u.Hostname()}
+	port := "514"
+	switch scheme {
+	case "udp":
+		endpoint.Network = "udp"
+	case "tcp":
+		endpoint.Network = "tcp"
+	case "tls":
+		endpoint.Network = "tcp"
+		endpoint.TLS = true
+		port = "6514"
+	default:
+		return syslogEndpoint{}, fmt.Errorf("esquema '%s' não suportado em address (use udp://, tcp://, tls:// ou unix://)", u.Scheme)
+	}
+	if u.Port() != "" {
+		port = u.Port()
+	}
+	endpoint.Addr = net.JoinHostPort(endpoint.Host, port)
+	return endpoint, nil
+}
+
+// validateSyslogAddress valida o address configurado.
+func validateSyslogAddress(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseSyslogAddress(value)
+	return err
+}
+
+// syslogHeaderField normaliza um campo do cabeçalho RFC 5424: apenas ASCII imprimível,
+// sem espaços, limitado a max caracteres; vazio vira o NILVALUE "-".
+func syslogHeaderField(value string, max int) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r > 32 && r < 127 {
+			b.WriteRune(r)
+		}
+		if b.Len() == max {
+			break
+		}
+	}
+	if b.Len() == 0 {
+		return "-"
+	}
+	return b.String()
+}
+
+// syslogSDName valida o nome de um parâmetro de structured data (SD-NAME).
+func syslogSDName(name string) error {
+	if name == "" || len(name) > 32 {
+		return fmt.Errorf("nome de metadado inválido para syslog: '%s' (até 32 caracteres)", name)
+	}
+	for _, r := range name {
+		if r <= 32 || r >= 127 || r == '=' || r == ']' || r == '"' {
+			return fmt.Errorf("nome de metadado inválido para syslog: '%s' (use ASCII sem espaços, '=', ']' ou '\"')", name)
+		}
+	}
+	return nil
+}
+
+// syslogSDValue escapa um PARAM-VALUE de structured data ('"', '\' e ']').
+func syslogSDValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// syslogProvider implementa o Provider para syslog no formato RFC 5424, com transporte
+// UDP (RFC 5426), TCP (RFC 6587, octet counting), TLS (RFC 5425) ou socket Unix local.
+type syslogProvider struct {
+	config  *config.SyslogConfig
+	timeout time.Duration
+	verbose bool
+}
+
+// NewSyslogProvider cria uma nova instância do SyslogProvider.
+func NewSyslogProvider(cfg *config.SyslogConfig) Provider {
+	return NewSyslogProviderWithVerbose(cfg, false)
+}
+
+// NewSyslogProviderWithVerbose cria uma nova instância do SyslogProvider com modo verbose.
+func NewSyslogProviderWithVerbose(cfg *config.SyslogConfig, verbose bool) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &syslogProvider{
+		config:  cfg,
+		timeout: timeout,
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *syslogProvider) Name() string {
+	return "syslog"
+}
+
+// Send envia uma mensagem para o syslog.
+func (p *syslogProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia a mensagem no formato RFC 5424. O assunto e os metadados de --meta
+// vão no structured data [cast@32473 ...].
+// Lógica de Target:
+// - "default" ou vazio: usa app_name (padrão "cast")
+// - Qualquer outro valor: APP-NAME da mensagem (ex: deploy)
+// - Suporta múltiplos APP-NAMEs separados por vírgula ou ponto-e-vírgula
+func (p *syslogProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	if p.config.Address == "" {
+		return fmt.Errorf("address do syslog não configurado")
+	}
+
+	pri, err := syslogPriority(p.config.Facility, p.config.DefaultSeverity, opts)
+	if err != nil {
+		return err
+	}
+	sd, err := p.structuredData(opts)
+	if err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	endpoint, err := parseSyslogAddress(p.config.Address)
+	if err != nil {
+		return err
+	}
+	conn, err := p.dial(endpoint)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for i, appName := range targets {
+		if appName == "default" || appName == "me" {
+			appName = p.config.AppName
+		}
+		line := p.format(pri, appName, sd, message)
+
+		frame := []byte(line)
+		if endpoint.Network == "tcp" {
+			// Octet counting (RFC 6587 e RFC 5425): "TAMANHO SP MENSAGEM"
+			frame = []byte(strconv.Itoa(len(line)) + " " + line)
+		}
+		if _, err := conn.Write(frame); err != nil {
+			if len(targets) > 1 {
+				return fmt.Errorf("erro ao enviar para o syslog (target %d/%d): %w", i+1, len(targets), err)
+			}
+			return fmt.Errorf("erro ao enviar para o syslog: %w", err)
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Syslog: %s\n", line)
+		}
+	}
+	return nil
+}
+
+// syslogPriority calcula o PRI (facility * 8 + severidade) a partir da configuração e das flags.
+func syslogPriority(facility string, severity string, opts SendOptions) (int, error) {
+	if value := opts.Get("facility"); value != "" {
+		facility = value
+	}
+	if value := opts.Get("severity"); value != "" {
+		severity = value
+	}
+	if facility == "" {
+		facility = "user"
+	}
+	if severity == "" {
+		severity = "notice"
+	}
+
+	facilityCode, err := parseSyslogFacility(facility)
+	if err != nil {
+		return 0, err
+	}
+	severityCode, err := parseSyslogSeverity(severity)
+	if err != nil {
+		return 0, err
+	}
+	return facilityCode*8 + severityCode, nil
+}
+
+// structuredData monta o STRUCTURED-DATA com o assunto e os metadados, ou "-" se não houver.
+func (p *syslogProvider) structuredData(opts SendOptions) (string, error) {
+	var params []string
+	if opts.Subject != "" {
+		params = append(params, fmt.Sprintf(`subject="%s"`, syslogSDValue(opts.Subject)))
+	}
+	for _, meta := range opts.GetAll("meta") {
+		key, value, ok := strings.Cut(meta, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return "", fmt.Errorf("--meta inválido: '%s' (use chave=valor)", meta)
+		}
+		if err := syslogSDName(key); err != nil {
+			return "", err
+		}
+		params = append(params, fmt.Sprintf(`%s="%s"`, key, syslogSDValue(value)))
+	}
+	if len(params) == 0 {
+		return "-", nil
+	}
+	return "[" + syslogSDID + " " + strings.Join(params, " ") + "]", nil
+}
+
+// format monta a mensagem RFC 5424:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA BOM MSG
+// O BOM (U+FEFF) indica que a mensagem está em UTF-8 (RFC 5424, seção 6.4).
+func (p *syslogProvider) format(pri int, appName string, sd string, message string) string {
+	hostname := p.config.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s %d - %s \ufeff%s",
+		pri,
+		time.Now().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(hostname, 255),
+		syslogHeaderField(appName, 48),
+		os.Getpid(),
+		sd,
+		message,
+	)
+}
+
+// dial abre a conexão com o servidor syslog.
+func (p *syslogProvider) dial(endpoint syslogEndpoint) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: p.timeout}
+	var conn net.Conn
+	var err error
+	if endpoint.TLS {
+		tlsConfig, tlsErr := brokerTLSConfig(endpoint.Host, p.config.CAFile, p.config.TLSInsecure)
+		if tlsErr != nil {
+			return nil, tlsErr
+		}
+		conn, err = tls.DialWithDialer(dialer, endpoint.Network, endpoint.Addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial(endpoint.Network, endpoint.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar com o syslog %s: %w", endpoint.Addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(p.timeout))
+	return conn, nil
+}
+
+// testSyslogConnection testa a conexão com o syslog. Com target, envia uma mensagem de teste.
+func testSyslogConnection(cfg *config.SyslogConfig, target string) error {
+	p := NewSyslogProvider(cfg).(*syslogProvider)
+	endpoint, err := parseSyslogAddress(cfg.Address)
+	if err != nil {
+		return err
+	}
+	conn, err := p.dial(endpoint)
+	if err != nil {
+		return err
+	}
+	conn.Close()
+
+	if target == "" {
+		return nil
+	}
+	return p.SendWithOptions(target, "Mensagem de teste enviada por 'cast gateway test syslog'.", SendOptions{Values: map[string][]string{"severity": {"info"}}})
+}
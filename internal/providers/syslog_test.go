@@ -0,0 +1,177 @@
+package providers
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func TestSyslogProvider_Name(t *testing.T) {
+	provider := NewSyslogProvider(&config.SyslogConfig{})
+	if provider.Name() != "syslog" {
+		t.Errorf("Esperado 'syslog', obtido '%s'", provider.Name())
+	}
+}
+
+func TestSyslogProvider_Send_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao abrir servidor UDP: %v", err)
+	}
+	defer conn.Close()
+
+	provider := NewSyslogProvider(&config.SyslogConfig{
+		Address:         "udp://" + conn.LocalAddr().String(),
+		Facility:        "local3",
+		DefaultSeverity: "notice",
+		AppName:         "cast",
+		Hostname:        "srv-01",
+		Timeout:         5,
+	}).(OptionsProvider)
+	opts := SendOptions{
+		Subject: `Deploy "v1.2"`,
+		Values:  map[string][]string{"severity": {"err"}, "meta": {"versao=1.2]"}},
+	}
+	if err := provider.SendWithOptions("deploy", "Falha no deploy", opts); err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Erro ao ler datagrama: %v", err)
+	}
+	msg := string(buf[:n])
+
+	// local3 (19) * 8 + err (3) = 155
+	if !strings.HasPrefix(msg, "<155>1 ") {
+		t.Errorf("PRI/versão inesperados: %s", msg)
+	}
+	fields := strings.SplitN(msg, " ", 7)
+	if fields[2] != "srv-01" || fields[3] != "deploy" || fields[5] != "-" {
+		t.Errorf("Cabeçalho inesperado: %q", fields[:6])
+	}
+	expectedSD := `[cast@32473 subject="Deploy \"v1.2\"" versao="1.2\]"] ` + "\ufeff" + "Falha no deploy"
+	if fields[6] != expectedSD {
+		t.Errorf("Structured data/mensagem inesperados:\n obtido: %q\nesperado: %q", fields[6], expectedSD)
+	}
+}
+
+func TestSyslogProvider_Send_TCPOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao abrir servidor TCP: %v", err)
+	}
+	defer listener.Close()
+
+	frames := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var got []string
+		for {
+			size, err := reader.ReadString(' ')
+			if err != nil {
+				break
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			data := make([]byte, n)
+			if _, err := io.ReadFull(reader, data); err != nil {
+				break
+			}
+			got = append(got, string(data))
+		}
+		frames <- got
+	}()
+
+	provider := NewSyslogProvider(&config.SyslogConfig{Address: "tcp://" + listener.Addr().String(), AppName: "cast", Timeout: 5})
+	if err := provider.Send("default,backup", "Linha 1\nLinha 2"); err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	got := <-frames
+	if len(got) != 2 {
+		t.Fatalf("Esperado 2 mensagens, obtido %d: %q", len(got), got)
+	}
+	// Padrão: user (1) * 8 + notice (5) = 13, sem structured data
+	if !strings.HasPrefix(got[0], "<13>1 ") || !strings.Contains(got[0], " cast ") || !strings.HasSuffix(got[0], " - \ufeffLinha 1\nLinha 2") {
+		t.Errorf("Primeira mensagem inesperada: %q", got[0])
+	}
+	if !strings.Contains(got[1], " backup ") {
+		t.Errorf("Segunda mensagem deveria usar APP-NAME backup: %q", got[1])
+	}
+}
+
+func TestSyslogProvider_Errors(t *testing.T) {
+	provider := NewSyslogProvider(&config.SyslogConfig{Address: "udp://127.0.0.1:514", Timeout: 5}).(OptionsProvider)
+	tests := []struct {
+		values   map[string][]string
+		contains string
+	}{
+		{map[string][]string{"severity": {"fatal"}}, "severidade inválida"},
+		{map[string][]string{"facility": {"local9"}}, "facility inválida"},
+		{map[string][]string{"meta": {"sem-valor"}}, "--meta inválido"},
+		{map[string][]string{"meta": {"com espaco=1"}}, "nome de metadado inválido"},
+	}
+	for _, tt := range tests {
+		err := provider.SendWithOptions("default", "Teste", SendOptions{Values: tt.values})
+		if err == nil || !strings.Contains(err.Error(), tt.contains) {
+			t.Errorf("%v: esperado erro contendo '%s', obtido: %v", tt.values, tt.contains, err)
+		}
+	}
+
+	if err := NewSyslogProvider(&config.SyslogConfig{}).Send("default", "Teste"); err == nil {
+		t.Error("Esperado erro sem address configurado")
+	}
+}
+
+func TestParseSyslogAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		network string
+		addr    string
+		tls     bool
+		wantErr bool
+	}{
+		{"udp://logs.local", "udp", "logs.local:514", false, false},
+		{"tcp://logs.local:1514", "tcp", "logs.local:1514", false, false},
+		{"tls://logs.local", "tcp", "logs.local:6514", true, false},
+		{"unix:///dev/log", "unixgram", "/dev/log", false, false},
+		{"http://logs.local", "", "", false, true},
+		{"logs.local:514", "", "", false, true},
+	}
+	for _, tt := range tests {
+		endpoint, err := parseSyslogAddress(tt.address)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: erro = %v, esperado erro = %v", tt.address, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (endpoint.Network != tt.network || endpoint.Addr != tt.addr || endpoint.TLS != tt.tls) {
+			t.Errorf("%s: obtido %+v", tt.address, endpoint)
+		}
+	}
+}
+
+func TestParseSyslogSeverityAndFacility(t *testing.T) {
+	severities := map[string]int{"emerg": 0, "critical": 2, "Error": 3, "warn": 4, "info": 6, "7": 7}
+	for name, expected := range severities {
+		if code, err := parseSyslogSeverity(name); err != nil || code != expected {
+			t.Errorf("parseSyslogSeverity(%s) = %d, %v; esperado %d", name, code, err, expected)
+		}
+	}
+	facilities := map[string]int{"kern": 0, "user": 1, "daemon": 3, "local0": 16, "LOCAL7": 23, "10": 10}
+	for name, expected := range facilities {
+		if code, err := parseSyslogFacility(name); err != nil || code != expected {
+			t.Errorf("parseSyslogFacility(%s) = %d, %v; esperado %d", name, code, err, expected)
+		}
+	}
+}
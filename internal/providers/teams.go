@@ -0,0 +1,268 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "teams",
+		Aliases:     []string{"msteams"},
+		DisplayName: "Microsoft Teams",
+		Order:       70,
+		TargetHint:  "webhook_url ou 'default'",
+		Fields: []ConfigField{
+			{Key: "webhook_url", Flag: "webhook-url", Label: "Webhook URL", Required: true, Secret: true, Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "fact", Usage: "Fato do Adaptive Card no formato Chave=Valor (Teams, pode ser repetido)", Repeatable: true},
+			{Name: "action", Usage: "Botão do Adaptive Card no formato Título=URL (Teams, pode ser repetido)", Repeatable: true},
+			{Name: "card", Usage: "Adaptive Card completo em JSON ou @arquivo.json (Teams)"},
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Teams.WebhookURL != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewTeamsProvider(&conf.Teams), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testTeamsConnection(&conf.Teams, target)
+		},
+	})
+}
+
+// teamsProvider implementa o Provider para Microsoft Teams (Incoming Webhooks e Workflows).
+type teamsProvider struct {
+	config *config.TeamsConfig
+	client *http.Client
+}
+
+// NewTeamsProvider cria uma nova instância do TeamsProvider.
+func NewTeamsProvider(cfg *config.TeamsConfig) Provider {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &teamsProvider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *teamsProvider) Name() string {
+	return "teams"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *teamsProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.client.Transport = transport
+}
+
+// Send envia uma mensagem de texto via Teams.
+func (p *teamsProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem via Teams como Adaptive Card.
+// O assunto (--subject) vira o título do card; --fact e --action adicionam
+// fatos e botões; --card substitui o card gerado por um card completo.
+// Lógica de Target:
+// - URL completa (https://...): usa essa URL
+// - "default" ou vazio: usa a URL configurada no cast.yaml
+// - Suporta múltiplos webhooks separados por vírgula ou ponto-e-vírgula
+func (p *teamsProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	payload, err := buildTeamsPayload(message, opts)
+	if err != nil {
+		return err
+	}
+
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		targets = []string{"default"}
+	}
+
+	for i, t := range targets {
+		webhookURL := t
+		if !strings.HasPrefix(t, "https://") && !strings.HasPrefix(t, "http://") {
+			if p.config.WebhookURL == "" {
+				return fmt.Errorf("target '%s' requer webhook_url configurado", t)
+			}
+			webhookURL = p.config.WebhookURL
+		}
+
+		if err := p.sendToWebhook(webhookURL, payload); err != nil {
+			return fmt.Errorf("erro ao enviar para webhook (target %d/%d): %w", i+1, len(targets), err)
+		}
+	}
+
+	return nil
+}
+
+// buildTeamsPayload monta a mensagem com o Adaptive Card no formato aceito
+// tanto pelos Incoming Webhooks quanto pelos webhooks do Workflows (Power Automate).
+func buildTeamsPayload(message string, opts SendOptions) (map[string]interface{}, error) {
+	var card map[string]interface{}
+
+	if raw := opts.Get("card"); raw != "" {
+		value, err := readValueOrFile(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(value), &card); err != nil {
+			return nil, fmt.Errorf("card inválido: %w", err)
+		}
+		// Mensagem já completa (com attachments) é enviada como está
+		if _, ok := card["attachments"]; ok {
+			return card, nil
+		}
+		if card["type"] != "AdaptiveCard" {
+			return nil, fmt.Errorf("card inválido: esperado \"type\": \"AdaptiveCard\"")
+		}
+	} else {
+		var err error
+		card, err = buildAdaptiveCard(message, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"contentUrl":  nil,
+				"content":     card,
+			},
+		},
+	}, nil
+}
+
+// buildAdaptiveCard gera um Adaptive Card com título, texto, fatos e botões.
+func buildAdaptiveCard(message string, opts SendOptions) (map[string]interface{}, error) {
+	var body []map[string]interface{}
+
+	if opts.Subject != "" {
+		body = append(body, map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   opts.Subject,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"wrap":   true,
+		})
+	}
+
+	body = append(body, map[string]interface{}{
+		"type": "TextBlock",
+		"text": message,
+		"wrap": true,
+	})
+
+	if facts := opts.GetAll("fact"); len(facts) > 0 {
+		var factSet []map[string]string
+		for _, fact := range facts {
+			title, value, ok := strings.Cut(fact, "=")
+			if !ok {
+				return nil, fmt.Errorf("fato inválido: '%s' (use Chave=Valor)", fact)
+			}
+			factSet = append(factSet, map[string]string{
+				"title": strings.TrimSpace(title),
+				"value": strings.TrimSpace(value),
+			})
+		}
+		body = append(body, map[string]interface{}{
+			"type":  "FactSet",
+			"facts": factSet,
+		})
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+		"msteams": map[string]string{"width": "Full"},
+	}
+
+	if actions := opts.GetAll("action"); len(actions) > 0 {
+		var list []map[string]string
+		for _, action := range actions {
+			title, url, ok := strings.Cut(action, "=")
+			url = strings.TrimSpace(url)
+			if !ok || !(strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")) {
+				return nil, fmt.Errorf("ação inválida: '%s' (use Título=https://url)", action)
+			}
+			list = append(list, map[string]string{
+				"type":  "Action.OpenUrl",
+				"title": strings.TrimSpace(title),
+				"url":   url,
+			})
+		}
+		card["actions"] = list
+	}
+
+	return card, nil
+}
+
+// sendToWebhook envia o payload para um webhook específico.
+func (p *teamsProvider) sendToWebhook(webhookURL string, payload map[string]interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	// Incoming Webhooks respondem 200 ("1"); Workflows respondem 202 (Accepted)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Teams retornou status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	// Webhooks legados podem responder 200 com o erro no corpo (ex: throttling)
+	if strings.Contains(string(body), "HTTP error") {
+		return fmt.Errorf("Teams retornou erro: %s", strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// testTeamsConnection valida o webhook do Teams. Como o Teams não oferece
+// endpoint de verificação, a mensagem de teste só é enviada se target for informado.
+func testTeamsConnection(cfg *config.TeamsConfig, target string) error {
+	if err := ValidateHTTPURL(cfg.WebhookURL); err != nil {
+		return err
+	}
+	if target == "" {
+		return nil
+	}
+
+	p := NewTeamsProvider(cfg).(*teamsProvider)
+	opts := SendOptions{Subject: "CAST - Teste de conectividade"}
+	return p.SendWithOptions(target, "Mensagem de teste enviada por 'cast gateway test teams'.", opts)
+}
@@ -0,0 +1,181 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// decodeTeamsCard extrai o Adaptive Card do payload enviado ao webhook.
+func decodeTeamsCard(t *testing.T, r *http.Request) map[string]interface{} {
+	t.Helper()
+
+	var payload struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string                 `json:"contentType"`
+			Content     map[string]interface{} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		t.Fatalf("Erro ao decodificar payload: %v", err)
+	}
+	if payload.Type != "message" || len(payload.Attachments) != 1 {
+		t.Fatalf("Payload inesperado: type=%s attachments=%d", payload.Type, len(payload.Attachments))
+	}
+	if payload.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("contentType inesperado: %s", payload.Attachments[0].ContentType)
+	}
+	return payload.Attachments[0].Content
+}
+
+func TestTeamsProvider_Name(t *testing.T) {
+	provider := NewTeamsProvider(&config.TeamsConfig{})
+	if provider.Name() != "teams" {
+		t.Errorf("Esperado 'teams', obtido '%s'", provider.Name())
+	}
+}
+
+func TestTeamsProvider_Send_PlainText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		card := decodeTeamsCard(t, r)
+		if card["type"] != "AdaptiveCard" {
+			t.Errorf("Esperado type 'AdaptiveCard', obtido '%v'", card["type"])
+		}
+		body := card["body"].([]interface{})
+		if len(body) != 1 {
+			t.Fatalf("Esperado 1 elemento no body, obtido %d", len(body))
+		}
+		text := body[0].(map[string]interface{})
+		if text["text"] != "Teste de mensagem" {
+			t.Errorf("Esperado text 'Teste de mensagem', obtido '%v'", text["text"])
+		}
+
+		// Workflows respondem 202
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider := NewTeamsProvider(&config.TeamsConfig{WebhookURL: server.URL, Timeout: 30})
+	if err := provider.Send("default", "Teste de mensagem"); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestTeamsProvider_Send_FactsAndActions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		card := decodeTeamsCard(t, r)
+		body := card["body"].([]interface{})
+		if len(body) != 3 {
+			t.Fatalf("Esperado título, texto e FactSet no body, obtido %d elementos", len(body))
+		}
+
+		title := body[0].(map[string]interface{})
+		if title["text"] != "Deploy" || title["weight"] != "Bolder" {
+			t.Errorf("Título inesperado: %v", title)
+		}
+
+		factSet := body[2].(map[string]interface{})
+		facts := factSet["facts"].([]interface{})
+		if len(facts) != 2 {
+			t.Fatalf("Esperado 2 fatos, obtido %d", len(facts))
+		}
+		first := facts[0].(map[string]interface{})
+		if first["title"] != "Ambiente" || first["value"] != "produção" {
+			t.Errorf("Fato inesperado: %v", first)
+		}
+
+		actions := card["actions"].([]interface{})
+		action := actions[0].(map[string]interface{})
+		if action["type"] != "Action.OpenUrl" || action["url"] != "https://ci.exemplo.com/build/42?tab=log" {
+			t.Errorf("Ação inesperada: %v", action)
+		}
+
+		w.Write([]byte("1"))
+	}))
+	defer server.Close()
+
+	provider := NewTeamsProvider(&config.TeamsConfig{WebhookURL: server.URL}).(OptionsProvider)
+	opts := SendOptions{
+		Subject: "Deploy",
+		Values: map[string][]string{
+			"fact":   {"Ambiente=produção", "Versão=1.2.3"},
+			"action": {"Ver build=https://ci.exemplo.com/build/42?tab=log"},
+		},
+	}
+	if err := provider.SendWithOptions("default", "Deploy concluído", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestTeamsProvider_Send_CustomCard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		card := decodeTeamsCard(t, r)
+		if card["version"] != "1.5" {
+			t.Errorf("Esperado card customizado (version 1.5), obtido '%v'", card["version"])
+		}
+		w.Write([]byte("1"))
+	}))
+	defer server.Close()
+
+	provider := NewTeamsProvider(&config.TeamsConfig{WebhookURL: server.URL}).(OptionsProvider)
+	opts := SendOptions{Values: map[string][]string{
+		"card": {`{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"Oi"}]}`},
+	}}
+	if err := provider.SendWithOptions(server.URL, "ignorado", opts); err != nil {
+		t.Fatalf("Erro ao enviar card: %v", err)
+	}
+}
+
+func TestTeamsProvider_InvalidOptions(t *testing.T) {
+	provider := NewTeamsProvider(&config.TeamsConfig{WebhookURL: "https://exemplo.com/webhook"}).(OptionsProvider)
+
+	tests := []struct {
+		name string
+		opts SendOptions
+	}{
+		{"fato sem =", SendOptions{Values: map[string][]string{"fact": {"Ambiente"}}}},
+		{"ação sem URL", SendOptions{Values: map[string][]string{"action": {"Abrir=ftp://x"}}}},
+		{"card inválido", SendOptions{Values: map[string][]string{"card": {`{"type":"Outro"}`}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := provider.SendWithOptions("default", "Teste", tt.opts); err == nil {
+				t.Error("Esperado erro de validação, mas não houve")
+			}
+		})
+	}
+}
+
+func TestTeamsProvider_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad payload received by generic incoming webhook."))
+	}))
+	defer server.Close()
+
+	provider := NewTeamsProvider(&config.TeamsConfig{WebhookURL: server.URL})
+	err := provider.Send("default", "Teste")
+	if err == nil {
+		t.Fatal("Esperado erro, mas não houve")
+	}
+	if !strings.Contains(err.Error(), "400") {
+		t.Errorf("Erro deveria conter status 400, obtido: %v", err)
+	}
+}
+
+func TestGetProvider_TeamsAlias(t *testing.T) {
+	cfg := &config.Config{Teams: config.TeamsConfig{WebhookURL: "https://exemplo.com/webhook"}}
+	provider, err := GetProvider("msteams", cfg)
+	if err != nil {
+		t.Fatalf("Erro ao obter provider Teams: %v", err)
+	}
+	if provider.Name() != "teams" {
+		t.Errorf("Esperado nome 'teams', obtido '%s'", provider.Name())
+	}
+}
@@ -14,11 +14,57 @@ import (
 	"github.com/eduardoalcantara/cast/internal/config"
 )
 
+func init() {
+	Register(&Registration{
+		Name:        "telegram",
+		ShortName:   "tg",
+		Aliases:     []string{"tg"},
+		DisplayName: "Telegram",
+		Order:       10,
+		TargetHint:  "chat_id ou 'me'",
+		Fields: []ConfigField{
+			{Key: "token", Flag: "token", Label: "Token", Required: true, Secret: true},
+			{Key: "default_chat_id", Flag: "default-chat-id", Label: "Default Chat ID"},
+			{Key: "api_url", Flag: "api-url", Label: "API URL", Validate: ValidateHTTPURL},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{MultipleTargets: true, HTTP: true},
+		Validate: func(conf *config.Config) error {
+			if conf.Telegram.Token == "" {
+				return fmt.Errorf("configuração do Telegram não encontrada: token obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.Telegram.Token != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewTelegramProviderWithVerbose(&conf.Telegram, "", verbose), nil
+		},
+		URLSchemes: []string{"tgram"},
+		FromURL:    telegramFromURL,
+	})
+}
+
+// telegramFromURL interpreta tgram://TOKEN/CHAT_ID[/CHAT_ID...].
+func telegramFromURL(u *ProviderURL, conf *config.Config) (string, error) {
+	if !strings.Contains(u.Host, ":") {
+		return "", fmt.Errorf("token do bot ausente ou inválido (use tgram://TOKEN/CHAT_ID)")
+	}
+	if len(u.Path) == 0 {
+		return "", fmt.Errorf("informe ao menos um chat_id (use tgram://TOKEN/CHAT_ID)")
+	}
+	conf.Telegram.Token = u.Host
+	conf.Telegram.DefaultChatID = u.Path[0]
+	return strings.Join(u.Path, ","), nil
+}
+
 // telegramProvider implementa o Provider para Telegram.
 type telegramProvider struct {
 	config        *config.TelegramConfig
 	defaultTarget string
 	verbose       bool
+	transport     http.RoundTripper // Transporte HTTP (nil = padrão)
 }
 
 // NewTelegramProvider cria uma nova instância do TelegramProvider.
@@ -44,6 +90,11 @@ func (p *telegramProvider) Name() string {
 	return "telegram"
 }
 
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *telegramProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.transport = transport
+}
+
 // Send envia uma mensagem via Telegram.
 // Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula.
 func (p *telegramProvider) Send(target string, message string) error {
@@ -144,9 +195,7 @@ func (p *telegramProvider) sendToChatID(chatID string, message string) error {
 		timeout = 30 * time.Second
 	}
 
-	client := &http.Client{
-		Timeout: timeout,
-	}
+	client := &http.Client{Timeout: timeout, Transport: p.transport}
 
 	// Executa requisição
 	if p.verbose {
@@ -0,0 +1,158 @@
+package providers
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// URLProvider é o nome usado em "cast send url <url> <mensagem>" e em aliases definidos por URL.
+const URLProvider = "url"
+
+// ProviderURL é uma URL de provider no estilo do Apprise (ex: tgram://TOKEN/CHAT_ID).
+// A URL é interpretada manualmente porque alguns esquemas não são URLs válidas para
+// net/url (o token do Telegram, por exemplo, contém ':' no lugar do host).
+type ProviderURL struct {
+	Scheme   string
+	User     string
+	Password string
+	Host     string   // host[:porta] como informado
+	Path     []string // Segmentos não vazios do caminho, já decodificados
+	Query    url.Values
+}
+
+// ParseProviderURL interpreta esquema://[usuário[:senha]@]host[/segmento...][?chave=valor...].
+func ParseProviderURL(raw string) (*ProviderURL, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(raw), "://")
+	if !ok || scheme == "" || rest == "" {
+		return nil, fmt.Errorf("URL de provider inválida: '%s' (ex: tgram://TOKEN/CHAT_ID)", RedactURL(raw))
+	}
+
+	rest, rawQuery, _ := strings.Cut(rest, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("parâmetros inválidos na URL: %w", err)
+	}
+
+	authority, path, _ := strings.Cut(rest, "/")
+	u := &ProviderURL{Scheme: strings.ToLower(scheme), Host: authority, Query: query}
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		userinfo := authority[:i]
+		u.Host = authority[i+1:]
+		user, password, _ := strings.Cut(userinfo, ":")
+		if u.User, err = url.PathUnescape(user); err != nil {
+			return nil, fmt.Errorf("usuário inválido na URL: %w", err)
+		}
+		if u.Password, err = url.PathUnescape(password); err != nil {
+			return nil, fmt.Errorf("senha inválida na URL: %w", err)
+		}
+	}
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		decoded, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, fmt.Errorf("caminho inválido na URL: %w", err)
+		}
+		u.Path = append(u.Path, decoded)
+	}
+	return u, nil
+}
+
+// Hostname retorna o host sem a porta.
+func (u *ProviderURL) Hostname() string {
+	if host, _, err := net.SplitHostPort(u.Host); err == nil {
+		return host
+	}
+	return u.Host
+}
+
+// Port retorna a porta informada, ou "" se ausente.
+func (u *ProviderURL) Port() string {
+	if _, port, err := net.SplitHostPort(u.Host); err == nil {
+		return port
+	}
+	return ""
+}
+
+// TakeQuery retorna o valor do parâmetro de query e o remove da URL.
+func (u *ProviderURL) TakeQuery(key string) string {
+	value := u.Query.Get(key)
+	u.Query.Del(key)
+	return value
+}
+
+// ResolveURL monta uma configuração efêmera a partir de uma URL de provider e retorna
+// o registro do provider, a configuração (com valores padrão aplicados) e o target.
+func ResolveURL(raw string) (*Registration, *config.Config, string, error) {
+	u, err := ParseProviderURL(raw)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	reg, ok := urlSchemes[u.Scheme]
+	if !ok {
+		return nil, nil, "", fmt.Errorf("esquema '%s' não suportado (suportados: %s)", u.Scheme, strings.Join(URLSchemes(), ", "))
+	}
+
+	conf := &config.Config{}
+	target, err := reg.FromURL(u, conf)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("URL %s inválida: %w", u.Scheme, err)
+	}
+
+	// Parâmetros restantes configuram campos do schema (ex: ?timeout=60)
+	for key, values := range u.Query {
+		field, ok := reg.Field(key)
+		if !ok {
+			return nil, nil, "", fmt.Errorf("parâmetro '%s' não suportado em URLs %s", key, u.Scheme)
+		}
+		if field.Validate != nil {
+			if err := field.Validate(values[0]); err != nil {
+				return nil, nil, "", fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		if err := conf.SetField(reg.Name, key, values[0]); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	conf.ApplyURLDefaults()
+	if reg.Validate != nil {
+		if err := reg.Validate(conf); err != nil {
+			return nil, nil, "", err
+		}
+	}
+	return reg, conf, target, nil
+}
+
+// URLSchemes retorna os esquemas de URL suportados, ordenados.
+func URLSchemes() []string {
+	schemes := make([]string, 0, len(urlSchemes))
+	for scheme := range urlSchemes {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// RedactURL oculta as credenciais de uma URL de provider para exibição,
+// mantendo apenas o esquema e o início do endereço (ex: tgram://1234****).
+func RedactURL(raw string) string {
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok {
+		return "****"
+	}
+	if i := strings.LastIndex(strings.SplitN(rest, "/", 2)[0], "@"); i >= 0 {
+		rest = rest[i+1:]
+	}
+	if len(rest) > 4 {
+		rest = rest[:4]
+	}
+	return scheme + "://" + rest + "****"
+}
@@ -12,6 +12,52 @@ import (
 	"github.com/eduardoalcantara/cast/internal/config"
 )
 
+func init() {
+	Register(&Registration{
+		Name:        "waha",
+		DisplayName: "WAHA",
+		Order:       50,
+		TargetHint:  "5511999998888@c.us ou 120363XXX@g.us",
+		Fields: []ConfigField{
+			{Key: "api_url", Flag: "api-url", Label: "API URL", Required: true, Validate: ValidateHTTPURL},
+			{Key: "session", Flag: "session", Label: "Session", Default: "default"},
+			{Key: "api_key", Flag: "api-key", Label: "API Key", Secret: true},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{MultipleTargets: true, HTTP: true},
+		Validate: func(conf *config.Config) error {
+			if conf.WAHA.APIURL == "" {
+				return fmt.Errorf("configuração do WAHA incompleta: api_url é obrigatório")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.WAHA.APIURL != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewWAHAProvider(conf.WAHA)
+		},
+		URLSchemes: []string{"waha", "wahas"},
+		FromURL:    wahaFromURL,
+	})
+}
+
+// wahaFromURL interpreta waha://[API_KEY@]host[:porta]/SESSAO/CHAT_ID[/CHAT_ID...]
+// (wahas:// usa HTTPS).
+func wahaFromURL(u *ProviderURL, conf *config.Config) (string, error) {
+	if u.Host == "" || len(u.Path) < 2 {
+		return "", fmt.Errorf("use waha://API_KEY@host:3000/SESSAO/CHAT_ID")
+	}
+	scheme := "http"
+	if u.Scheme == "wahas" {
+		scheme = "https"
+	}
+	conf.WAHA.APIURL = scheme + "://" + u.Host
+	conf.WAHA.APIKey = u.User
+	conf.WAHA.Session = u.Path[0]
+	return strings.Join(u.Path[1:], ","), nil
+}
+
 // wahaProvider implementa o Provider para WAHA (WhatsApp HTTP API).
 type wahaProvider struct {
 	apiURL  string        // Base URL do WAHA (ex: http://localhost:3000)
@@ -74,6 +120,11 @@ func (w *wahaProvider) Name() string {
 	return "WAHA"
 }
 
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (w *wahaProvider) SetHTTPTransport(transport http.RoundTripper) {
+	w.client.Transport = transport
+}
+
 // Send envia uma mensagem via WAHA (WhatsApp HTTP API).
 func (w *wahaProvider) Send(target string, message string) error {
 	// Parseia múltiplos targets
@@ -0,0 +1,463 @@
+package providers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+func init() {
+	Register(&Registration{
+		Name:        "webhook",
+		Aliases:     []string{"http"},
+		DisplayName: "Webhook HTTP",
+		Order:       90,
+		TargetHint:  "nome do endpoint configurado ou URL",
+		Fields: []ConfigField{
+			timeoutField(),
+		},
+		Capabilities: Capabilities{
+			MultipleTargets: true,
+			Subject:         true,
+			HTTP:            true,
+		},
+		SendFlags: []SendFlag{
+			{Name: "var", Usage: "Variável para o template do webhook no formato chave=valor (Webhook, pode ser repetido)", Repeatable: true},
+		},
+		Validate: func(conf *config.Config) error {
+			for name, endpoint := range conf.Webhook.Endpoints {
+				if err := ValidateWebhookEndpoint(endpoint); err != nil {
+					return fmt.Errorf("webhook '%s': %w", name, err)
+				}
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return len(conf.Webhook.Endpoints) > 0
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewWebhookProviderWithVerbose(&conf.Webhook, verbose), nil
+		},
+		Test: func(conf *config.Config, target string) error {
+			return testWebhookEndpoints(&conf.Webhook, target)
+		},
+	})
+}
+
+// Corpos padrão usados quando o endpoint não define template.
+const (
+	defaultWebhookJSONBody = `{"text": {{json .Message}}{{if .Subject}}, "subject": {{json .Subject}}{{end}}}`
+	defaultWebhookFormBody = `message={{urlquery .Message}}{{if .Subject}}&subject={{urlquery .Subject}}{{end}}`
+)
+
+// secretRefPattern reconhece referências a segredos: ${env:NOME} e ${file:/caminho}.
+var secretRefPattern = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+// IsSecretRef informa se o valor contém referências ${env:...} ou ${file:...}.
+func IsSecretRef(value string) bool {
+	return secretRefPattern.MatchString(value)
+}
+
+// ResolveSecretRefs substitui referências ${env:NOME} e ${file:/caminho} pelos valores reais.
+// Permite manter tokens fora do cast.yaml.
+func ResolveSecretRefs(value string) (string, error) {
+	var resolveErr error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		match := secretRefPattern.FindStringSubmatch(ref)
+		kind, name := match[1], strings.TrimSpace(match[2])
+		switch kind {
+		case "env":
+			envValue, ok := os.LookupEnv(name)
+			if !ok && resolveErr == nil {
+				resolveErr = fmt.Errorf("variável de ambiente %s não definida", name)
+			}
+			return envValue
+		default:
+			data, err := os.ReadFile(name)
+			if err != nil && resolveErr == nil {
+				resolveErr = fmt.Errorf("erro ao ler segredo de %s: %w", name, err)
+			}
+			return strings.TrimSpace(string(data))
+		}
+	})
+	return resolved, resolveErr
+}
+
+// webhookTemplateData são os dados disponíveis no template do corpo.
+type webhookTemplateData struct {
+	Message   string            // Mensagem enviada
+	Subject   string            // Assunto (--subject)
+	Target    string            // Nome do endpoint ou URL
+	Timestamp string            // Data/hora do envio (RFC 3339)
+	Hostname  string            // Nome da máquina
+	Vars      map[string]string // Variáveis informadas com --var chave=valor
+}
+
+// webhookFuncs são as funções disponíveis nos templates, além das nativas
+// (ex: urlquery, printf).
+var webhookFuncs = template.FuncMap{
+	// json serializa o valor como JSON (strings saem com aspas e escape)
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// ValidateWebhookEndpoint valida a definição de um endpoint (URL, método, formato e template).
+func ValidateWebhookEndpoint(endpoint config.WebhookEndpoint) error {
+	if err := ValidateHTTPURL(endpoint.URL); err != nil || endpoint.URL == "" {
+		return fmt.Errorf("url deve começar com http:// ou https://")
+	}
+	switch strings.ToUpper(endpoint.Method) {
+	case "", "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		return fmt.Errorf("método HTTP não suportado: %s", endpoint.Method)
+	}
+	switch strings.ToLower(endpoint.Format) {
+	case "", "json", "form", "text":
+	default:
+		return fmt.Errorf("formato inválido: %s (use json, form ou text)", endpoint.Format)
+	}
+	switch strings.ToLower(endpoint.HMACAlgorithm) {
+	case "", "sha256", "sha1", "sha512":
+	default:
+		return fmt.Errorf("algoritmo HMAC inválido: %s (use sha256, sha1 ou sha512)", endpoint.HMACAlgorithm)
+	}
+	if _, err := template.New("body").Funcs(webhookFuncs).Parse(endpoint.Body); err != nil {
+		return fmt.Errorf("template do corpo inválido: %w", err)
+	}
+	// GET não tem corpo: apenas o formato form é enviado (como query string)
+	if strings.ToUpper(endpoint.Method) == "GET" && endpoint.Body != "" && strings.ToLower(endpoint.Format) != "form" {
+		return fmt.Errorf("método GET não envia corpo: use format form (o corpo vira query string) ou remova o body")
+	}
+	return nil
+}
+
+// webhookProvider implementa o Provider para webhooks HTTP genéricos.
+type webhookProvider struct {
+	config    *config.WebhookConfig
+	verbose   bool
+	transport http.RoundTripper // Transporte HTTP (nil = padrão)
+}
+
+// NewWebhookProvider cria uma nova instância do WebhookProvider.
+func NewWebhookProvider(cfg *config.WebhookConfig) Provider {
+	return NewWebhookProviderWithVerbose(cfg, false)
+}
+
+// NewWebhookProviderWithVerbose cria uma nova instância do WebhookProvider com modo verbose.
+func NewWebhookProviderWithVerbose(cfg *config.WebhookConfig, verbose bool) Provider {
+	return &webhookProvider{
+		config:  cfg,
+		verbose: verbose,
+	}
+}
+
+// Name retorna o nome do provider.
+func (p *webhookProvider) Name() string {
+	return "webhook"
+}
+
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *webhookProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.transport = transport
+}
+
+// Send envia uma mensagem para um webhook.
+func (p *webhookProvider) Send(target string, message string) error {
+	return p.SendWithOptions(target, message, SendOptions{})
+}
+
+// SendWithOptions envia uma mensagem para um ou mais webhooks.
+// Lógica de Target:
+// - Nome de um endpoint configurado em webhook.endpoints: usa sua definição
+// - URL completa (https://...): POST JSON com o corpo padrão
+// - Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula
+func (p *webhookProvider) SendWithOptions(target string, message string, opts SendOptions) error {
+	targets := config.ParseTargets(target)
+	if len(targets) == 0 {
+		return fmt.Errorf("nenhum webhook especificado")
+	}
+
+	vars := map[string]string{}
+	for _, v := range opts.GetAll("var") {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return fmt.Errorf("variável inválida: '%s' (use chave=valor)", v)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+
+	for i, t := range targets {
+		endpoint, err := p.resolveEndpoint(t)
+		if err != nil {
+			return err
+		}
+
+		hostname, _ := os.Hostname()
+		data := webhookTemplateData{
+			Message:   message,
+			Subject:   opts.Subject,
+			Target:    t,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Hostname:  hostname,
+			Vars:      vars,
+		}
+
+		if err := p.send(endpoint, data); err != nil {
+			return fmt.Errorf("erro ao enviar para %s (target %d/%d): %w", t, i+1, len(targets), err)
+		}
+	}
+
+	return nil
+}
+
+// resolveEndpoint retorna o endpoint configurado com o nome informado ou um endpoint ad-hoc para URLs.
+func (p *webhookProvider) resolveEndpoint(target string) (config.WebhookEndpoint, error) {
+	if endpoint, ok := p.config.Endpoints[target]; ok {
+		return endpoint, nil
+	}
+	// O viper normaliza as chaves do cast.yaml para minúsculas
+	if endpoint, ok := p.config.Endpoints[strings.ToLower(target)]; ok {
+		return endpoint, nil
+	}
+	if strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://") {
+		return config.WebhookEndpoint{URL: target}, nil
+	}
+
+	names := make([]string, 0, len(p.config.Endpoints))
+	for name := range p.config.Endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return config.WebhookEndpoint{}, fmt.Errorf("webhook '%s' não encontrado (nenhum endpoint configurado em webhook.endpoints)", target)
+	}
+	return config.WebhookEndpoint{}, fmt.Errorf("webhook '%s' não encontrado (configurados: %s)", target, strings.Join(names, ", "))
+}
+
+// renderWebhookBody executa o template do corpo e retorna o corpo e o Content-Type.
+func renderWebhookBody(endpoint config.WebhookEndpoint, data webhookTemplateData) ([]byte, string, error) {
+	format := strings.ToLower(endpoint.Format)
+	if format == "" {
+		format = "json"
+	}
+
+	body := endpoint.Body
+	if body == "" {
+		switch format {
+		case "form":
+			body = defaultWebhookFormBody
+		case "text":
+			body = "{{.Message}}"
+		default:
+			body = defaultWebhookJSONBody
+		}
+	}
+
+	tmpl, err := template.New("body").Funcs(webhookFuncs).Option("missingkey=zero").Parse(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("template do corpo inválido: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, "", fmt.Errorf("erro ao executar template do corpo: %w", err)
+	}
+
+	switch format {
+	case "form":
+		if _, err := url.ParseQuery(buf.String()); err != nil {
+			return nil, "", fmt.Errorf("corpo form inválido após template: %w", err)
+		}
+		return buf.Bytes(), "application/x-www-form-urlencoded", nil
+	case "text":
+		return buf.Bytes(), "text/plain; charset=utf-8", nil
+	default:
+		if !json.Valid(buf.Bytes()) {
+			return nil, "", fmt.Errorf("corpo JSON inválido após template (use {{json .Message}} para escapar valores): %s", buf.String())
+		}
+		return buf.Bytes(), "application/json", nil
+	}
+}
+
+// signWebhookBody calcula a assinatura HMAC do corpo no formato "<algoritmo>=<hex>".
+func signWebhookBody(algorithm string, secret string, body []byte) string {
+	algorithm = strings.ToLower(algorithm)
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "sha1":
+		newHash = sha1.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		algorithm = "sha256"
+		newHash = sha256.New
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return algorithm + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send monta e executa a requisição para um endpoint.
+func (p *webhookProvider) send(endpoint config.WebhookEndpoint, data webhookTemplateData) error {
+	if err := ValidateWebhookEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	body, contentType, err := renderWebhookBody(endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(endpoint.Method)
+	if method == "" {
+		method = "POST"
+	}
+
+	// GET não tem corpo: o conteúdo renderizado vira query string (formato form)
+	requestURL := endpoint.URL
+	var reqBody io.Reader = bytes.NewReader(body)
+	if method == "GET" {
+		reqBody = nil
+		if strings.ToLower(endpoint.Format) == "form" {
+			sep := "?"
+			if strings.Contains(requestURL, "?") {
+				sep = "&"
+			}
+			requestURL += sep + string(body)
+		}
+	}
+
+	req, err := http.NewRequest(method, requestURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for name, value := range endpoint.Headers {
+		resolved, err := ResolveSecretRefs(value)
+		if err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+		req.Header.Set(name, resolved)
+	}
+
+	// Headers do endpoint e de autenticação não são exibidos no modo verbose, sejam
+	// literais ou referências ${env:...}/${file:...} (já resolvidas na requisição)
+	maskedHeaders := map[string]bool{"Authorization": true, "Proxy-Authorization": true}
+	for name := range endpoint.Headers {
+		maskedHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+
+	if endpoint.HMACSecret != "" {
+		secret, err := ResolveSecretRefs(endpoint.HMACSecret)
+		if err != nil {
+			return fmt.Errorf("hmac_secret: %w", err)
+		}
+		header := endpoint.HMACHeader
+		if header == "" {
+			header = "X-Signature-256"
+		}
+		req.Header.Set(header, signWebhookBody(endpoint.HMACAlgorithm, secret, body))
+		maskedHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+
+	timeout := time.Duration(endpoint.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = time.Duration(p.config.Timeout) * time.Second
+	}
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	if p.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Enviando requisição HTTP %s para: %s\n", method, requestURL)
+		for name, values := range req.Header {
+			value := strings.Join(values, ", ")
+			if maskedHeaders[name] {
+				value = maskToken(value)
+			}
+			fmt.Fprintf(os.Stderr, "[DEBUG] Header %s: %s\n", name, value)
+		}
+		if reqBody != nil {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Body: %s\n", string(body))
+		}
+	}
+
+	client := &http.Client{Timeout: timeout, Transport: p.transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if p.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Status Code: %d\n", resp.StatusCode)
+		fmt.Fprintf(os.Stderr, "[DEBUG] Resposta: %s\n", strings.TrimSpace(string(respBody)))
+	}
+
+	if !webhookStatusExpected(endpoint.ExpectedStatus, resp.StatusCode) {
+		return fmt.Errorf("status inesperado %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// webhookStatusExpected verifica o status HTTP (sem lista configurada, aceita 2xx).
+func webhookStatusExpected(expected []int, status int) bool {
+	if len(expected) == 0 {
+		return status >= 200 && status < 300
+	}
+	for _, code := range expected {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// testWebhookEndpoints valida os endpoints configurados sem enviar requisições.
+// Se target for informado, envia uma mensagem de teste para esse endpoint.
+func testWebhookEndpoints(cfg *config.WebhookConfig, target string) error {
+	for name, endpoint := range cfg.Endpoints {
+		if err := ValidateWebhookEndpoint(endpoint); err != nil {
+			return fmt.Errorf("webhook '%s': %w", name, err)
+		}
+		for header, value := range endpoint.Headers {
+			if _, err := ResolveSecretRefs(value); err != nil {
+				return fmt.Errorf("webhook '%s', header %s: %w", name, header, err)
+			}
+		}
+		if _, err := ResolveSecretRefs(endpoint.HMACSecret); err != nil {
+			return fmt.Errorf("webhook '%s', hmac_secret: %w", name, err)
+		}
+	}
+
+	if target == "" {
+		return nil
+	}
+	p := NewWebhookProvider(cfg).(*webhookProvider)
+	return p.SendWithOptions(target, "Mensagem de teste enviada por 'cast gateway test webhook'.", SendOptions{Subject: "CAST - Teste de conectividade"})
+}
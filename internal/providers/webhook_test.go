@@ -0,0 +1,338 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardoalcantara/cast/internal/config"
+)
+
+// newTestWebhookProvider cria um provider com os endpoints informados.
+func newTestWebhookProvider(endpoints map[string]config.WebhookEndpoint) OptionsProvider {
+	return NewWebhookProvider(&config.WebhookConfig{Timeout: 30, Endpoints: endpoints}).(OptionsProvider)
+}
+
+func TestWebhookProvider_Name(t *testing.T) {
+	provider := NewWebhookProvider(&config.WebhookConfig{})
+	if provider.Name() != "webhook" {
+		t.Errorf("Esperado 'webhook', obtido '%s'", provider.Name())
+	}
+}
+
+func TestWebhookProvider_Send_URLDefaultBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Esperado método POST, obtido %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Esperado Content-Type application/json, obtido '%s'", r.Header.Get("Content-Type"))
+		}
+
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload["text"] != `Deploy "v2" concluído` {
+			t.Errorf("Esperado text escapado corretamente, obtido '%s'", payload["text"])
+		}
+		if _, ok := payload["subject"]; ok {
+			t.Error("Sem --subject, o corpo padrão não deveria conter subject")
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestWebhookProvider(nil)
+	if err := provider.Send(server.URL, `Deploy "v2" concluído`); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestWebhookProvider_Send_NamedTemplate(t *testing.T) {
+	t.Setenv("CAST_TEST_WEBHOOK_TOKEN", "segredo-123")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Esperado método PUT, obtido %s", r.Method)
+		}
+		if r.Header.Get("Authorization") != "Bearer segredo-123" {
+			t.Errorf("Referência ${env:...} não resolvida: '%s'", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Fixo") != "valor" {
+			t.Errorf("Esperado header X-Fixo 'valor', obtido '%s'", r.Header.Get("X-Fixo"))
+		}
+
+		var payload struct {
+			Title   string `json:"title"`
+			Summary string `json:"summary"`
+			Env     string `json:"env"`
+			Target  string `json:"target"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Erro ao decodificar payload: %v", err)
+		}
+		if payload.Title != "Deploy" || payload.Summary != "Versão 1.2.3" {
+			t.Errorf("Payload inesperado: %+v", payload)
+		}
+		if payload.Env != "PRODUÇÃO" {
+			t.Errorf("Esperado env 'PRODUÇÃO' (via --var e upper), obtido '%s'", payload.Env)
+		}
+		if payload.Target != "deploy" {
+			t.Errorf("Esperado target 'deploy', obtido '%s'", payload.Target)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	provider := newTestWebhookProvider(map[string]config.WebhookEndpoint{
+		"deploy": {
+			URL:    server.URL,
+			Method: "PUT",
+			Headers: map[string]string{
+				"Authorization": "Bearer ${env:CAST_TEST_WEBHOOK_TOKEN}",
+				"X-Fixo":        "valor",
+			},
+			Body: `{"title": {{json .Subject}}, "summary": {{json .Message}}, "env": {{json (upper .Vars.env)}}, "target": {{json .Target}}}`,
+		},
+	})
+
+	opts := SendOptions{
+		Subject: "Deploy",
+		Values:  map[string][]string{"var": {"env=produção"}},
+	}
+	if err := provider.SendWithOptions("deploy", "Versão 1.2.3", opts); err != nil {
+		t.Fatalf("Erro ao enviar mensagem: %v", err)
+	}
+}
+
+func TestWebhookProvider_Send_Form(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("Esperado Content-Type de formulário, obtido '%s'", r.Header.Get("Content-Type"))
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Erro ao ler formulário: %v", err)
+		}
+		if r.PostForm.Get("message") != "a & b = c" || r.PostForm.Get("subject") != "Alerta" {
+			t.Errorf("Formulário inesperado: %v", r.PostForm)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestWebhookProvider(map[string]config.WebhookEndpoint{
+		"form": {URL: server.URL, Format: "form"},
+	})
+	if err := provider.SendWithOptions("form", "a & b = c", SendOptions{Subject: "Alerta"}); err != nil {
+		t.Fatalf("Erro ao enviar formulário: %v", err)
+	}
+}
+
+func TestWebhookProvider_Send_HMAC(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "secret")
+	if err := os.WriteFile(secretPath, []byte("chave-hmac\n"), 0600); err != nil {
+		t.Fatalf("Erro ao criar arquivo de segredo: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte("chave-hmac"))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+		if got := r.Header.Get("X-Hub-Signature-256"); got != expected {
+			t.Errorf("Assinatura inválida: esperado '%s', obtido '%s'", expected, got)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestWebhookProvider(map[string]config.WebhookEndpoint{
+		"assinado": {
+			URL:        server.URL,
+			HMACSecret: "${file:" + secretPath + "}",
+			HMACHeader: "X-Hub-Signature-256",
+		},
+	})
+	if err := provider.Send("assinado", "Teste"); err != nil {
+		t.Fatalf("Erro ao enviar mensagem assinada: %v", err)
+	}
+}
+
+func TestWebhookProvider_ExpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("enfileirado"))
+	}))
+	defer server.Close()
+
+	// 202 não está na lista esperada
+	provider := newTestWebhookProvider(map[string]config.WebhookEndpoint{
+		"estrito": {URL: server.URL, ExpectedStatus: []int{200, 201}},
+		"aceito":  {URL: server.URL, ExpectedStatus: []int{202}},
+	})
+
+	err := provider.Send("estrito", "Teste")
+	if err == nil || !strings.Contains(err.Error(), "202") {
+		t.Errorf("Esperado erro de status 202, obtido: %v", err)
+	}
+
+	if err := provider.Send("aceito", "Teste"); err != nil {
+		t.Errorf("Status 202 esperado não deveria retornar erro: %v", err)
+	}
+}
+
+func TestWebhookProvider_Errors(t *testing.T) {
+	provider := newTestWebhookProvider(map[string]config.WebhookEndpoint{
+		"quebrado": {URL: "https://exemplo.com/hook", Body: `{"text": {{.Message}}}`},
+		"segredo":  {URL: "https://exemplo.com/hook", Headers: map[string]string{"Authorization": "${env:CAST_TEST_INEXISTENTE}"}},
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		opts     SendOptions
+		contains string
+	}{
+		{"endpoint desconhecido", "outro", SendOptions{}, "não encontrado"},
+		{"JSON inválido após template", "quebrado", SendOptions{}, "JSON inválido"},
+		{"variável de ambiente ausente", "segredo", SendOptions{}, "CAST_TEST_INEXISTENTE"},
+		{"variável sem =", "quebrado", SendOptions{Values: map[string][]string{"var": {"env"}}}, "chave=valor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := provider.SendWithOptions(tt.target, "Teste", tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Esperado erro contendo '%s', obtido: %v", tt.contains, err)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint config.WebhookEndpoint
+		wantErr  bool
+	}{
+		{"válido", config.WebhookEndpoint{URL: "https://exemplo.com/hook", Format: "form"}, false},
+		{"sem URL", config.WebhookEndpoint{}, true},
+		{"URL sem esquema", config.WebhookEndpoint{URL: "exemplo.com/hook"}, true},
+		{"método inválido", config.WebhookEndpoint{URL: "https://exemplo.com", Method: "TRACE"}, true},
+		{"formato inválido", config.WebhookEndpoint{URL: "https://exemplo.com", Format: "xml"}, true},
+		{"algoritmo inválido", config.WebhookEndpoint{URL: "https://exemplo.com", HMACAlgorithm: "md5"}, true},
+		{"template inválido", config.WebhookEndpoint{URL: "https://exemplo.com", Body: "{{.Message"}, true},
+		{"GET com corpo JSON", config.WebhookEndpoint{URL: "https://exemplo.com", Method: "GET", Body: `{"text": {{json .Message}}}`}, true},
+		{"GET com corpo texto", config.WebhookEndpoint{URL: "https://exemplo.com", Method: "get", Format: "text", Body: "{{.Message}}"}, true},
+		{"GET com form", config.WebhookEndpoint{URL: "https://exemplo.com", Method: "GET", Format: "form", Body: "msg={{urlquery .Message}}"}, false},
+		{"GET sem corpo", config.WebhookEndpoint{URL: "https://exemplo.com", Method: "GET"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWebhookEndpoint(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWebhookEndpoint() erro = %v, esperado erro = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookConnection_NoTarget(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	cfg := &config.WebhookConfig{Endpoints: map[string]config.WebhookEndpoint{
+		"deploy": {URL: server.URL},
+	}}
+
+	if err := testWebhookEndpoints(cfg, ""); err != nil {
+		t.Errorf("Endpoint válido não deveria retornar erro: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Teste sem target não deveria enviar requisições, obtido %d", requests)
+	}
+
+	if err := testWebhookEndpoints(cfg, "deploy"); err != nil {
+		t.Errorf("Teste com target não deveria retornar erro: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Esperado 1 requisição com target, obtido %d", requests)
+	}
+}
+
+// captureStderr executa fn e retorna o que foi escrito em os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Erro ao criar pipe: %v", err)
+	}
+	original := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = original }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-output
+}
+
+func TestWebhookProvider_VerboseMasksHeaders(t *testing.T) {
+	t.Setenv("CAST_TEST_WEBHOOK_TOKEN", "segredo-do-ambiente-123")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	provider := NewWebhookProviderWithVerbose(&config.WebhookConfig{Timeout: 30, Endpoints: map[string]config.WebhookEndpoint{
+		"ops": {
+			URL: server.URL,
+			Headers: map[string]string{
+				"Authorization": "Bearer ${env:CAST_TEST_WEBHOOK_TOKEN}",
+				"X-Api-Key":     "chave-literal-456",
+			},
+			HMACSecret: "segredo-hmac",
+		},
+	}}, true)
+
+	output := captureStderr(t, func() {
+		if err := provider.Send("ops", "Deploy concluído"); err != nil {
+			t.Errorf("Erro ao enviar mensagem: %v", err)
+		}
+	})
+
+	for _, secret := range []string{"segredo-do-ambiente-123", "chave-literal-456"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Saída [DEBUG] não deveria conter %q:\n%s", secret, output)
+		}
+	}
+	for _, header := range []string{"Authorization", "X-Api-Key", "X-Signature-256"} {
+		if !strings.Contains(output, "[DEBUG] Header "+header+": ") {
+			t.Errorf("Saída [DEBUG] deveria listar o header %s mascarado:\n%s", header, output)
+		}
+	}
+	signature := signWebhookBody("sha256", "segredo-hmac", []byte(`{"text":"Deploy concluído"}`))
+	if strings.Contains(output, signature[len(signature)-20:]) {
+		t.Errorf("Saída [DEBUG] não deveria conter a assinatura HMAC:\n%s", output)
+	}
+	if !strings.Contains(output, "[DEBUG] Header Content-Type: application/json") {
+		t.Errorf("Headers sem segredo deveriam ser exibidos normalmente:\n%s", output)
+	}
+}
@@ -11,9 +11,42 @@ import (
 	"github.com/eduardoalcantara/cast/internal/config"
 )
 
+func init() {
+	Register(&Registration{
+		Name:        "whatsapp",
+		ShortName:   "zap",
+		Aliases:     []string{"zap"},
+		DisplayName: "WhatsApp",
+		Order:       30,
+		TargetHint:  "número no formato internacional",
+		Fields: []ConfigField{
+			{Key: "phone_number_id", Flag: "phone-id", Label: "Phone Number ID", Required: true},
+			{Key: "access_token", Flag: "access-token", Label: "Access Token", Required: true, Secret: true},
+			{Key: "business_account_id", Flag: "business-account-id", Label: "Business Account ID"},
+			{Key: "api_version", Flag: "api-version", Label: "API Version", Default: "v18.0"},
+			{Key: "api_url", Label: "API URL"},
+			timeoutField(),
+		},
+		Capabilities: Capabilities{MultipleTargets: true, HTTP: true},
+		Validate: func(conf *config.Config) error {
+			if conf.WhatsApp.PhoneNumberID == "" || conf.WhatsApp.AccessToken == "" {
+				return fmt.Errorf("configuração do WhatsApp incompleta: phone_number_id e access_token são obrigatórios")
+			}
+			return nil
+		},
+		Configured: func(conf *config.Config) bool {
+			return conf.WhatsApp.PhoneNumberID != "" && conf.WhatsApp.AccessToken != ""
+		},
+		New: func(conf *config.Config, verbose bool) (Provider, error) {
+			return NewWhatsAppProvider(&conf.WhatsApp), nil
+		},
+	})
+}
+
 // whatsappProvider implementa o Provider para WhatsApp (Meta Cloud API).
 type whatsappProvider struct {
-	config *config.WhatsAppConfig
+	config    *config.WhatsAppConfig
+	transport http.RoundTripper // Transporte HTTP (nil = padrão)
 }
 
 // NewWhatsAppProvider cria uma nova instância do WhatsAppProvider.
@@ -28,6 +61,11 @@ func (p *whatsappProvider) Name() string {
 	return "whatsapp"
 }
 
+// SetHTTPTransport substitui o transporte HTTP do provider (usado pelo preview).
+func (p *whatsappProvider) SetHTTPTransport(transport http.RoundTripper) {
+	p.transport = transport
+}
+
 // Send envia uma mensagem via WhatsApp (Meta Cloud API).
 // Suporta múltiplos targets separados por vírgula ou ponto-e-vírgula.
 func (p *whatsappProvider) Send(target string, message string) error {
@@ -98,9 +136,7 @@ func (p *whatsappProvider) sendToPhone(phoneNumber string, message string) error
 		timeout = 30 * time.Second
 	}
 
-	client := &http.Client{
-		Timeout: timeout,
-	}
+	client := &http.Client{Timeout: timeout, Transport: p.transport}
 
 	// Executa requisição
 	resp, err := client.Do(req)
@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/eduardoalcantara/cast/internal/providers"
 )
 
 // MCPRequest representa uma requisição MCP
@@ -114,8 +116,8 @@ func (s *CastMCPServer) handleToolsList(id interface{}) MCPResponse {
 							},
 							"provider": map[string]interface{}{
 								"type":        "string",
-								"description": "Nome do provider: tg (telegram), mail (email), zap (whatsapp), google_chat, waha",
-								"enum":        []string{"tg", "mail", "zap", "google_chat", "waha"},
+								"description": "Nome do provider: " + providerDescriptions(),
+								"enum":        providers.ShortNames(),
 							},
 							"target": map[string]interface{}{
 								"type":        "string",
@@ -169,8 +171,8 @@ func (s *CastMCPServer) handleToolsList(id interface{}) MCPResponse {
 							},
 							"provider": map[string]interface{}{
 								"type":        "string",
-								"description": "Provider: tg, mail, zap, google_chat, waha",
-								"enum":        []string{"tg", "mail", "zap", "google_chat", "waha"},
+								"description": "Provider: " + strings.Join(providers.ShortNames(), ", "),
+								"enum":        providers.ShortNames(),
 							},
 							"target": map[string]interface{}{
 								"type":        "string",
@@ -783,3 +785,16 @@ func main() {
 		}
 	}
 }
+
+// providerDescriptions descreve os providers registrados (ex: "tg (telegram), mail (email)").
+func providerDescriptions() string {
+	parts := make([]string, 0, len(providers.Names()))
+	for _, reg := range providers.Registered() {
+		if reg.Short() == reg.Name {
+			parts = append(parts, reg.Name)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", reg.Short(), reg.Name))
+	}
+	return strings.Join(parts, ", ")
+}